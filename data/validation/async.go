@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrPending is returned by an AsyncValidator while the check of the current text has not completed.
+var ErrPending = errors.New("validation pending")
+
+// AsyncValidator runs a slow check, like a request to a server, in the background.
+// Use its Validate method as the validator of a widget and call the widget's Validate
+// from OnValidated to update the error state when the result is known.
+type AsyncValidator struct {
+	// OnValidated is called from a background goroutine when the check of a text completes.
+	OnValidated func(text string, err error)
+
+	check    func(context.Context, string) error
+	debounce time.Duration
+
+	lock       sync.Mutex
+	timer      *time.Timer
+	cancel     context.CancelFunc
+	generation uint64
+	checked    bool
+	text       string
+	result     error
+}
+
+// NewAsync returns a validator that calls check once the text has not changed for the debounce duration.
+// When the text changes again before a check completes, the context passed to that check is cancelled
+// and its result is ignored, so a check that makes a request should pass the context on.
+//
+// Example:
+//
+//	v := validation.NewAsync(func(ctx context.Context, name string) error {
+//	    return checkUsername(ctx, name)
+//	}, 300*time.Millisecond)
+//	entry.Validator = v.Validate
+//	v.OnValidated = func(string, error) {
+//	    entry.Validate()
+//	}
+func NewAsync(check func(ctx context.Context, text string) error, debounce time.Duration) *AsyncValidator {
+	return &AsyncValidator{check: check, debounce: debounce}
+}
+
+// Validate returns the result of the check if the text has already been checked.
+// Otherwise it schedules a check and returns ErrPending.
+// It has the signature of fyne.StringValidator so that it can be assigned to a widget.
+func (a *AsyncValidator) Validate(text string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.text == text {
+		if a.checked {
+			return a.result
+		}
+		if a.timer != nil {
+			return ErrPending
+		}
+	}
+
+	a.generation++
+	a.text, a.checked, a.result = text, false, nil
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	if a.cancel != nil {
+		a.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	generation := a.generation
+	a.timer = time.AfterFunc(a.debounce, func() {
+		a.run(ctx, text, generation)
+	})
+	return ErrPending
+}
+
+func (a *AsyncValidator) run(ctx context.Context, text string, generation uint64) {
+	err := a.check(ctx, text)
+
+	a.lock.Lock()
+	if generation != a.generation {
+		// the text changed while checking
+		a.lock.Unlock()
+		return
+	}
+	a.checked, a.result = true, err
+	a.cancel()
+	a.cancel = nil
+	onValidated := a.OnValidated
+	a.lock.Unlock()
+
+	if onValidated != nil {
+		onValidated(text, err)
+	}
+}
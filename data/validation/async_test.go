@@ -0,0 +1,92 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/x/fyne/data/validation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsync(t *testing.T) {
+	errTaken := errors.New("username taken")
+	var calls int32
+	v := validation.NewAsync(func(_ context.Context, text string) error {
+		atomic.AddInt32(&calls, 1)
+		if text == "admin" {
+			return errTaken
+		}
+		return nil
+	}, 20*time.Millisecond)
+
+	results := make(chan error, 1)
+	v.OnValidated = func(text string, err error) {
+		results <- err
+	}
+
+	assert.Equal(t, validation.ErrPending, v.Validate("adm"))
+	assert.Equal(t, validation.ErrPending, v.Validate("admin"))
+	select {
+	case err := <-results:
+		assert.Equal(t, errTaken, err)
+	case <-time.After(time.Second):
+		t.Fatal("check did not complete")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, errTaken, v.Validate("admin"))
+
+	assert.Equal(t, validation.ErrPending, v.Validate("someone"))
+	select {
+	case err := <-results:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("check did not complete")
+	}
+	assert.NoError(t, v.Validate("someone"))
+}
+
+func TestAsync_Cancel(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan error, 1)
+	v := validation.NewAsync(func(ctx context.Context, text string) error {
+		if text == "slow" {
+			close(started)
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return ctx.Err()
+		}
+		return nil
+	}, time.Millisecond)
+
+	results := make(chan string, 2)
+	v.OnValidated = func(text string, err error) {
+		results <- text
+	}
+
+	assert.Equal(t, validation.ErrPending, v.Validate("slow"))
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("check did not start")
+	}
+
+	// a newer text cancels the check that is still running
+	assert.Equal(t, validation.ErrPending, v.Validate("fast"))
+	select {
+	case err := <-cancelled:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(time.Second):
+		t.Fatal("check was not cancelled")
+	}
+	select {
+	case text := <-results:
+		assert.Equal(t, "fast", text)
+	case <-time.After(time.Second):
+		t.Fatal("check did not complete")
+	}
+	assert.NoError(t, v.Validate("fast"))
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// ValidatorError is returned by combined validators to report which of the child validators failed.
+type ValidatorError struct {
+	// Index is the position of the failing validator in the list passed to All or Any, starting at 0.
+	Index int
+	// Err is the error returned by the failing validator.
+	Err error
+}
+
+// Error returns a message naming the failing validator and its error.
+func (e *ValidatorError) Error() string {
+	return fmt.Sprintf("validator %d failed: %v", e.Index+1, e.Err)
+}
+
+// Unwrap returns the error of the failing validator.
+func (e *ValidatorError) Unwrap() error {
+	return e.Err
+}
+
+// All returns a validator that passes only if all of the validators pass.
+// They are run in order and the first error is returned as a *ValidatorError.
+func All(validators ...fyne.StringValidator) fyne.StringValidator {
+	return func(text string) error {
+		for i, v := range validators {
+			if err := v(text); err != nil {
+				return &ValidatorError{Index: i, Err: err}
+			}
+		}
+		return nil
+	}
+}
+
+// Any returns a validator that passes if at least one of the validators passes.
+// If they all fail the returned error lists the error of each validator.
+// Combining no validators always passes.
+func Any(validators ...fyne.StringValidator) fyne.StringValidator {
+	return func(text string) error {
+		if len(validators) == 0 {
+			return nil
+		}
+
+		messages := make([]string, 0, len(validators))
+		for i, v := range validators {
+			err := v(text)
+			if err == nil {
+				return nil
+			}
+			messages = append(messages, (&ValidatorError{Index: i, Err: err}).Error())
+		}
+		return errors.New("no validator passed: " + strings.Join(messages, "; "))
+	}
+}
@@ -0,0 +1,62 @@
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"fyne.io/x/fyne/data/validation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errEmpty   = errors.New("empty")
+	errNoAtSym = errors.New("missing @")
+)
+
+func notEmpty(text string) error {
+	if text == "" {
+		return errEmpty
+	}
+	return nil
+}
+
+func hasAt(text string) error {
+	if !strings.Contains(text, "@") {
+		return errNoAtSym
+	}
+	return nil
+}
+
+func TestAll(t *testing.T) {
+	all := validation.All(notEmpty, hasAt)
+
+	assert.NoError(t, all("me@example.com"))
+
+	err := all("")
+	var verr *validation.ValidatorError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, 0, verr.Index)
+	assert.True(t, errors.Is(err, errEmpty))
+
+	err = all("example.com")
+	assert.True(t, errors.Is(err, errNoAtSym))
+	assert.Equal(t, "validator 2 failed: missing @", err.Error())
+
+	assert.NoError(t, validation.All()(""))
+}
+
+func TestAny(t *testing.T) {
+	anyOf := validation.Any(notEmpty, hasAt)
+
+	assert.NoError(t, anyOf("example.com"))
+	assert.NoError(t, anyOf("@"))
+
+	err := validation.Any(hasAt, hasAt)("")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "validator 1 failed: missing @")
+	assert.Contains(t, err.Error(), "validator 2 failed: missing @")
+
+	assert.NoError(t, validation.Any()(""))
+}
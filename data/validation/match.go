@@ -0,0 +1,40 @@
+package validation
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// NewMatchBinding returns a new validator that checks the text equals the current value of the other binding,
+// for example to confirm that a password was typed the same way twice. The message is returned when they differ,
+// it may use ValuePlaceholder. An empty message uses the default set for MessageMatch.
+//
+// The validator only runs when the widget it is set on validates its own text. To check it again when the
+// other value changes, use RevalidateOnChange:
+//
+//	confirm.Validator = validation.NewMatchBinding(password, "")
+//	validation.RevalidateOnChange(password, confirm)
+func NewMatchBinding(other binding.String, msg string) fyne.StringValidator {
+	msg = messageOrDefault(msg, MessageMatch)
+	return func(text string) error {
+		value, err := other.Get()
+		if err != nil {
+			return err
+		}
+		if text != value {
+			return newMessageError(msg, text)
+		}
+		return nil
+	}
+}
+
+// RevalidateOnChange validates the widget each time the data changes. This keeps validators that depend on
+// another value, like NewMatchBinding, up to date. The returned listener can be passed to the data's
+// RemoveListener to stop it.
+func RevalidateOnChange(data binding.DataItem, w fyne.Validatable) binding.DataListener {
+	listener := binding.NewDataListener(func() {
+		_ = w.Validate()
+	})
+	data.AddListener(listener)
+	return listener
+}
@@ -0,0 +1,52 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"fyne.io/x/fyne/data/validation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchBinding(t *testing.T) {
+	password := binding.NewString()
+	_ = password.Set("secret")
+	m := validation.NewMatchBinding(password, "")
+
+	assert.NoError(t, m("secret"))
+	assert.EqualError(t, m("secrets"), "does not match")
+	assert.Error(t, m(""))
+
+	_ = password.Set("secrets")
+	assert.NoError(t, m("secrets"))
+
+	m = validation.NewMatchBinding(password, "{value} is not the password")
+	assert.EqualError(t, m("guess"), "guess is not the password")
+}
+
+func TestRevalidateOnChange(t *testing.T) {
+	test.NewApp()
+	password := binding.NewString()
+	_ = password.Set("secret")
+	confirm := widget.NewEntry()
+	confirm.Validator = validation.NewMatchBinding(password, "")
+	confirm.SetText("secret")
+	validation.RevalidateOnChange(password, confirm)
+	assert.NoError(t, confirm.Validate())
+
+	validated := make(chan error, 1)
+	confirm.SetOnValidationChanged(func(err error) {
+		validated <- err
+	})
+	_ = password.Set("changed")
+	select {
+	case err := <-validated:
+		assert.EqualError(t, err, "does not match")
+	case <-time.After(time.Second):
+		t.Error("entry was not validated when the password changed")
+	}
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// MessageKey identifies the default error message of a validator in this package.
+type MessageKey string
+
+const (
+	// MessagePassword is the message returned by NewPassword when a password is too weak.
+	// By default it is empty, which keeps the advice returned by the password strength checker.
+	MessagePassword MessageKey = "password"
+	// MessageNumberRange is the message returned by NewNumberRange when the value is not in range.
+	MessageNumberRange MessageKey = "number_range"
+	// MessageLength is the message returned by NewLength when the value is too short or too long.
+	MessageLength MessageKey = "length"
+	// MessageMatch is the message returned by NewMatchBinding when the value does not match the other value.
+	MessageMatch MessageKey = "match"
+	// MessagePhone is the message returned by NewPhone when the value is not a phone number of the region.
+	MessagePhone MessageKey = "phone"
+	// MessagePostalCode is the message returned by NewPostalCode when the value is not a postal code of the country.
+	MessagePostalCode MessageKey = "postal_code"
+)
+
+const (
+	// ValuePlaceholder is replaced in message templates by the value that failed the validation.
+	ValuePlaceholder = "{value}"
+	// MinPlaceholder is replaced in message templates by the minimum accepted by a range validator.
+	MinPlaceholder = "{min}"
+	// MaxPlaceholder is replaced in message templates by the maximum accepted by a range validator.
+	MaxPlaceholder = "{max}"
+)
+
+var (
+	defaultMessages = map[MessageKey]string{
+		MessagePassword:    "",
+		MessageNumberRange: "must be a number between {min} and {max}",
+		MessageLength:      "must be between {min} and {max} characters long",
+		MessageMatch:       "does not match",
+		MessagePhone:       "is not a valid phone number",
+		MessagePostalCode:  "is not a valid postal code",
+	}
+	messagesLock sync.RWMutex
+)
+
+// SetDefaultMessage sets the template of the message returned by the validators using the given key.
+// Any occurrence of ValuePlaceholder in the template is replaced by the value being validated,
+// which allows the messages to be localized, for example:
+//
+//	validation.SetDefaultMessage(validation.MessagePassword, "Le mot de passe est trop faible")
+//
+// It applies to validators created after the call.
+func SetDefaultMessage(key MessageKey, template string) {
+	messagesLock.Lock()
+	defer messagesLock.Unlock()
+	defaultMessages[key] = template
+}
+
+// DefaultMessage returns the template of the message used by the validators with the given key.
+func DefaultMessage(key MessageKey) string {
+	messagesLock.RLock()
+	defer messagesLock.RUnlock()
+	return defaultMessages[key]
+}
+
+// newMessageError returns an error built from the template with the value and any placeholder pairs interpolated.
+func newMessageError(template, value string, placeholders ...string) error {
+	r := strings.NewReplacer(append([]string{ValuePlaceholder, value}, placeholders...)...)
+	return errors.New(r.Replace(template))
+}
@@ -11,8 +11,21 @@ import (
 // to the minimum entropy. If not, an error is returned that explains
 // how the password can be strengthened. Advice on entropy value:
 // https://github.com/wagslane/go-password-validator/tree/main#what-entropy-value-should-i-use
+//
+// The message can be replaced for all password validators using SetDefaultMessage with MessagePassword.
 func NewPassword(minEntropy float64) fyne.StringValidator {
+	return NewPasswordWithMessage(minEntropy, DefaultMessage(MessagePassword))
+}
+
+// NewPasswordWithMessage returns a new validator for validating passwords like NewPassword,
+// but that returns the given message when the password is too weak.
+// An empty message keeps the advice explaining how the password can be strengthened.
+func NewPasswordWithMessage(minEntropy float64, message string) fyne.StringValidator {
 	return func(text string) error {
-		return gpv.Validate(text, minEntropy)
+		err := gpv.Validate(text, minEntropy)
+		if err != nil && message != "" {
+			return newMessageError(message, text)
+		}
+		return err
 	}
 }
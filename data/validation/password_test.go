@@ -19,3 +19,16 @@ func TestPassword(t *testing.T) {
 	assert.NoError(t, pw("7-BreaD-Crumbs.^_SpeciaL"))
 	assert.Error(t, pw("12345--12345"))
 }
+
+func TestPasswordWithMessage(t *testing.T) {
+	pw := validation.NewPasswordWithMessage(100, "mot de passe trop faible")
+
+	assert.NoError(t, pw("5 horses Ran around"))
+	assert.EqualError(t, pw("bad-password"), "mot de passe trop faible")
+
+	validation.SetDefaultMessage(validation.MessagePassword, "{value} is too weak")
+	defer validation.SetDefaultMessage(validation.MessagePassword, "")
+	pw = validation.NewPassword(100)
+
+	assert.EqualError(t, pw("bad-password"), "bad-password is too weak")
+}
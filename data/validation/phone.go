@@ -0,0 +1,173 @@
+package validation
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+// phoneRegion describes how the phone numbers of a region are written
+type phoneRegion struct {
+	callingCode string
+	// trunkPrefix is dialled before national numbers within the region, like the 0 of "020 7946 0018"
+	trunkPrefix string
+	// pattern matches the national number, the digits after the calling code or trunk prefix
+	pattern *regexp.Regexp
+}
+
+var (
+	phoneRegions = map[string]phoneRegion{
+		"AT": {"43", "0", anchored(`[1-9]\d{3,12}`)},
+		"AU": {"61", "0", anchored(`[2-478]\d{8}`)},
+		"BE": {"32", "0", anchored(`[1-9]\d{7,8}`)},
+		"BR": {"55", "0", anchored(`[1-9]{2}\d{8,9}`)},
+		"CA": {"1", "1", anchored(`[2-9]\d{2}[2-9]\d{6}`)},
+		"CH": {"41", "0", anchored(`[1-9]\d{8}`)},
+		"CN": {"86", "0", anchored(`1\d{10}|[2-9]\d{8,10}`)},
+		"DE": {"49", "0", anchored(`[1-9]\d{5,13}`)},
+		"ES": {"34", "", anchored(`[6-9]\d{8}`)},
+		"FR": {"33", "0", anchored(`[1-9]\d{8}`)},
+		"GB": {"44", "0", anchored(`[1-9]\d{8,9}`)},
+		"IE": {"353", "0", anchored(`[1-9]\d{6,9}`)},
+		"IN": {"91", "0", anchored(`[1-9]\d{9}`)},
+		"JP": {"81", "0", anchored(`[1-9]\d{8,9}`)},
+		"MX": {"52", "", anchored(`\d{10}`)},
+		"NL": {"31", "0", anchored(`[1-9]\d{8}`)},
+		"NZ": {"64", "0", anchored(`[2-9]\d{7,9}`)},
+		"SE": {"46", "0", anchored(`[1-9]\d{6,9}`)},
+		"US": {"1", "1", anchored(`[2-9]\d{2}[2-9]\d{6}`)},
+	}
+	// internationalPhone matches the digits of a number in international format, without the leading +
+	internationalPhone = anchored(`[1-9]\d{6,14}`)
+	patternsLock       sync.RWMutex
+)
+
+// NewPhone returns a new validator that checks the text is a phone number of the region, given as a two letter
+// country code like "US" or "GB". The number may be written in the national format, with or without the trunk
+// prefix, or in the international format starting with + or 00 and the calling code of the region. Spaces,
+// hyphens, dots, slashes and parentheses between the digits are ignored. For a region without a known pattern,
+// or an empty region, any number in the international format is accepted.
+//
+// The message can be replaced for all phone validators using SetDefaultMessage with MessagePhone.
+// Empty text is not a phone number, wrap the validator with AllowEmpty to accept it.
+func NewPhone(region string) fyne.StringValidator {
+	msg := DefaultMessage(MessagePhone)
+	r, known := lookupPhoneRegion(region)
+	return func(text string) error {
+		if _, ok := normalizePhone(text, r, known); !ok {
+			return newMessageError(msg, text)
+		}
+		return nil
+	}
+}
+
+// NormalizePhone returns the phone number in the E.164 format, like "+442079460018", for storing or dialling.
+// The text is read as described for NewPhone and an error is returned if it is not a phone number of the region.
+func NormalizePhone(text, region string) (string, error) {
+	r, known := lookupPhoneRegion(region)
+	number, ok := normalizePhone(text, r, known)
+	if !ok {
+		return "", newMessageError(DefaultMessage(MessagePhone), text)
+	}
+	return number, nil
+}
+
+// SetPhonePattern replaces the pattern of the national numbers of a region with a known calling code.
+// The pattern is matched against the whole of the national number, which is the digits that are left once
+// the formatting, the calling code and the trunk prefix are removed, for example "2079460018" for "020 7946 0018"
+// in "GB". It applies to validators created after the call.
+func SetPhonePattern(region, pattern string) error {
+	compiled, err := compileAnchored(pattern)
+	if err != nil {
+		return err
+	}
+
+	patternsLock.Lock()
+	defer patternsLock.Unlock()
+	region = strings.ToUpper(region)
+	r, ok := phoneRegions[region]
+	if !ok {
+		return errors.New("no calling code known for region " + region)
+	}
+	r.pattern = compiled
+	phoneRegions[region] = r
+	return nil
+}
+
+func lookupPhoneRegion(region string) (phoneRegion, bool) {
+	patternsLock.RLock()
+	defer patternsLock.RUnlock()
+	r, ok := phoneRegions[strings.ToUpper(region)]
+	return r, ok
+}
+
+// normalizePhone returns the number in the E.164 format and true if the text is a phone number of the region
+func normalizePhone(text string, r phoneRegion, known bool) (string, bool) {
+	digits, international, ok := phoneDigits(text)
+	if !ok {
+		return "", false
+	}
+
+	if !known {
+		if !international || !internationalPhone.MatchString(digits) {
+			return "", false
+		}
+		return "+" + digits, true
+	}
+
+	national := digits
+	if international {
+		if !strings.HasPrefix(digits, r.callingCode) {
+			return "", false
+		}
+		national = strings.TrimPrefix(digits, r.callingCode)
+	} else if r.trunkPrefix != "" && strings.HasPrefix(digits, r.trunkPrefix) && !r.pattern.MatchString(digits) {
+		national = strings.TrimPrefix(digits, r.trunkPrefix)
+	}
+	if !r.pattern.MatchString(national) {
+		return "", false
+	}
+	return "+" + r.callingCode + national, true
+}
+
+// phoneDigits returns the digits of the text, without the formatting, and whether the text starts with
+// an international prefix
+func phoneDigits(text string) (string, bool, bool) {
+	text = strings.TrimSpace(text)
+	international := false
+	if strings.HasPrefix(text, "+") {
+		international = true
+		text = text[1:]
+	} else if strings.HasPrefix(text, "00") {
+		international = true
+		text = text[2:]
+	}
+
+	digits := strings.Builder{}
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case strings.ContainsRune(" -./()", r):
+		default:
+			return "", false, false
+		}
+	}
+	return digits.String(), international, digits.Len() > 0
+}
+
+// anchored compiles the pattern so that it matches the whole of the text
+func anchored(pattern string) *regexp.Regexp {
+	compiled, err := compileAnchored(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+func compileAnchored(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
@@ -0,0 +1,63 @@
+package validation_test
+
+import (
+	"testing"
+
+	"fyne.io/x/fyne/data/validation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhone(t *testing.T) {
+	gb := validation.NewPhone("GB")
+	assert.NoError(t, gb("020 7946 0018"))
+	assert.NoError(t, gb("+44 20 7946 0018"))
+	assert.NoError(t, gb("0044 20-7946-0018"))
+	assert.NoError(t, gb("07700 900123"))
+	assert.EqualError(t, gb("020 7946"), "is not a valid phone number")
+	assert.Error(t, gb("+1 212 555 0100"))
+	assert.Error(t, gb("020 7946 0018 ext 2"))
+	assert.Error(t, gb(""))
+
+	us := validation.NewPhone("us")
+	assert.NoError(t, us("(212) 555-0100"))
+	assert.NoError(t, us("1-212-555-0100"))
+	assert.NoError(t, us("+1 212.555.0100"))
+	assert.Error(t, us("(012) 555-0100"))
+
+	// unknown regions accept any number in the international format
+	international := validation.NewPhone("")
+	assert.NoError(t, international("+358 40 1234567"))
+	assert.Error(t, international("040 1234567"))
+}
+
+func TestNormalizePhone(t *testing.T) {
+	number, err := validation.NormalizePhone("020 7946 0018", "GB")
+	assert.NoError(t, err)
+	assert.Equal(t, "+442079460018", number)
+
+	number, err = validation.NormalizePhone("1 (212) 555-0100", "US")
+	assert.NoError(t, err)
+	assert.Equal(t, "+12125550100", number)
+
+	number, err = validation.NormalizePhone("00 33 1 23 45 67 89", "FR")
+	assert.NoError(t, err)
+	assert.Equal(t, "+33123456789", number)
+
+	_, err = validation.NormalizePhone("12345", "DE")
+	assert.Error(t, err)
+}
+
+func TestSetPhonePattern(t *testing.T) {
+	assert.Error(t, validation.SetPhonePattern("NL", "[1-9"))
+	assert.Error(t, validation.SetPhonePattern("XX", `\d+`))
+
+	before := validation.NewPhone("NL")
+	assert.NoError(t, validation.SetPhonePattern("NL", `6\d{8}`))
+	defer validation.SetPhonePattern("NL", `[1-9]\d{8}`)
+	mobile := validation.NewPhone("NL")
+
+	assert.NoError(t, before("020 123 4567"))
+	assert.Error(t, mobile("020 123 4567"))
+	assert.NoError(t, mobile("06 12345678"))
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+var (
+	postalCodes = map[string]*regexp.Regexp{
+		"AT": anchored(`\d{4}`),
+		"AU": anchored(`\d{4}`),
+		"BE": anchored(`\d{4}`),
+		"BR": anchored(`\d{5}-?\d{3}`),
+		"CA": anchored(`[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d`),
+		"CH": anchored(`\d{4}`),
+		"CN": anchored(`\d{6}`),
+		"DE": anchored(`\d{5}`),
+		"DK": anchored(`\d{4}`),
+		"ES": anchored(`\d{5}`),
+		"FR": anchored(`\d{5}`),
+		"GB": anchored(`[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}`),
+		"IE": anchored(`[A-Z]\d[\dW] ?[A-Z\d]{4}`),
+		"IN": anchored(`[1-9]\d{2} ?\d{3}`),
+		"IT": anchored(`\d{5}`),
+		"JP": anchored(`\d{3}-?\d{4}`),
+		"MX": anchored(`\d{5}`),
+		"NL": anchored(`[1-9]\d{3} ?[A-Z]{2}`),
+		"NO": anchored(`\d{4}`),
+		"NZ": anchored(`\d{4}`),
+		"PL": anchored(`\d{2}-\d{3}`),
+		"PT": anchored(`\d{4}-\d{3}`),
+		"SE": anchored(`\d{3} ?\d{2}`),
+		"US": anchored(`\d{5}(-\d{4})?`),
+	}
+	// anyPostalCode is used for countries without a known pattern, it accepts letters and digits
+	// which may be separated by single spaces or hyphens
+	anyPostalCode = anchored(`[A-Z\d]+([ -][A-Z\d]+)*`)
+)
+
+// NewPostalCode returns a new validator that checks the text is a postal code of the country, given as a two
+// letter country code like "US" or "GB". Letters are accepted in either case. For a country without a known
+// pattern, any letters and digits separated by single spaces or hyphens are accepted.
+//
+// The message can be replaced for all postal code validators using SetDefaultMessage with MessagePostalCode.
+// Empty text is not a postal code, wrap the validator with AllowEmpty to accept it.
+func NewPostalCode(country string) fyne.StringValidator {
+	msg := DefaultMessage(MessagePostalCode)
+	patternsLock.RLock()
+	pattern, ok := postalCodes[strings.ToUpper(country)]
+	patternsLock.RUnlock()
+	if !ok {
+		pattern = anyPostalCode
+	}
+
+	return func(text string) error {
+		if !pattern.MatchString(strings.ToUpper(strings.TrimSpace(text))) {
+			return newMessageError(msg, text)
+		}
+		return nil
+	}
+}
+
+// SetPostalCodePattern sets the pattern of the postal codes of a country, replacing the built in pattern if
+// there is one. The pattern is matched against the whole of the text in upper case.
+// It applies to validators created after the call.
+func SetPostalCodePattern(country, pattern string) error {
+	compiled, err := compileAnchored(pattern)
+	if err != nil {
+		return err
+	}
+
+	patternsLock.Lock()
+	defer patternsLock.Unlock()
+	postalCodes[strings.ToUpper(country)] = compiled
+	return nil
+}
@@ -0,0 +1,43 @@
+package validation_test
+
+import (
+	"testing"
+
+	"fyne.io/x/fyne/data/validation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostalCode(t *testing.T) {
+	us := validation.NewPostalCode("US")
+	assert.NoError(t, us("90210"))
+	assert.NoError(t, us("90210-1234"))
+	assert.EqualError(t, us("9021"), "is not a valid postal code")
+	assert.Error(t, us(""))
+
+	gb := validation.NewPostalCode("gb")
+	assert.NoError(t, gb("SW1A 1AA"))
+	assert.NoError(t, gb("sw1a1aa"))
+	assert.NoError(t, gb("M1 1AE"))
+	assert.Error(t, gb("SW1A 1A"))
+
+	ca := validation.NewPostalCode("CA")
+	assert.NoError(t, ca("K1A 0B1"))
+	assert.Error(t, ca("D1A 0B1"))
+
+	// unknown countries accept letters and digits
+	other := validation.NewPostalCode("")
+	assert.NoError(t, other("AB-123"))
+	assert.Error(t, other("AB--123"))
+	assert.Error(t, other("#12"))
+}
+
+func TestSetPostalCodePattern(t *testing.T) {
+	assert.Error(t, validation.SetPostalCodePattern("LU", "[0-9"))
+	assert.NoError(t, validation.SetPostalCodePattern("LU", `(L-)?\d{4}`))
+
+	lu := validation.NewPostalCode("LU")
+	assert.NoError(t, lu("L-1234"))
+	assert.NoError(t, lu("1234"))
+	assert.Error(t, lu("12345"))
+}
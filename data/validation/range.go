@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+)
+
+// NewNumberRange returns a new validator that checks the text is a number between min and max, inclusive.
+// The message is returned when the text is not a number or is out of range, it may use ValuePlaceholder,
+// MinPlaceholder and MaxPlaceholder. An empty message uses the default set for MessageNumberRange.
+// Empty text is accepted if includeEmpty is true, otherwise it fails as it is not a number.
+func NewNumberRange(min, max float64, includeEmpty bool, msg string) fyne.StringValidator {
+	msg = messageOrDefault(msg, MessageNumberRange)
+	return func(text string) error {
+		if text == "" && includeEmpty {
+			return nil
+		}
+		n, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsNaN(n) || n < min || n > max {
+			return newMessageError(msg, text,
+				MinPlaceholder, strconv.FormatFloat(min, 'g', -1, 64),
+				MaxPlaceholder, strconv.FormatFloat(max, 'g', -1, 64))
+		}
+		return nil
+	}
+}
+
+// NewLength returns a new validator that checks the text has between min and max characters, inclusive.
+// The message is returned when the text is too short or too long, it may use ValuePlaceholder,
+// MinPlaceholder and MaxPlaceholder. An empty message uses the default set for MessageLength.
+// Empty text is accepted if includeEmpty is true, otherwise it fails if min is greater than 0.
+func NewLength(min, max int, includeEmpty bool, msg string) fyne.StringValidator {
+	msg = messageOrDefault(msg, MessageLength)
+	return func(text string) error {
+		if text == "" && includeEmpty {
+			return nil
+		}
+		if n := utf8.RuneCountInString(text); n < min || n > max {
+			return newMessageError(msg, text,
+				MinPlaceholder, strconv.Itoa(min),
+				MaxPlaceholder, strconv.Itoa(max))
+		}
+		return nil
+	}
+}
+
+// AllowEmpty returns a validator that accepts empty text and otherwise runs the given validator.
+// This is useful for optional fields.
+func AllowEmpty(validator fyne.StringValidator) fyne.StringValidator {
+	return func(text string) error {
+		if text == "" {
+			return nil
+		}
+		return validator(text)
+	}
+}
+
+func messageOrDefault(msg string, key MessageKey) string {
+	if msg != "" {
+		return msg
+	}
+	return DefaultMessage(key)
+}
@@ -0,0 +1,60 @@
+package validation_test
+
+import (
+	"testing"
+
+	"fyne.io/x/fyne/data/validation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberRange(t *testing.T) {
+	r := validation.NewNumberRange(1, 100, false, "")
+
+	assert.NoError(t, r("1"))
+	assert.NoError(t, r("42.5"))
+	assert.NoError(t, r("100"))
+	assert.EqualError(t, r("0"), "must be a number between 1 and 100")
+	assert.Error(t, r("101"))
+	assert.Error(t, r("abc"))
+	assert.Error(t, r("NaN"))
+	assert.Error(t, r(""))
+
+	r = validation.NewNumberRange(1, 10, false, "{value} n'est pas entre {min} et {max}")
+	assert.EqualError(t, r("11"), "11 n'est pas entre 1 et 10")
+
+	r = validation.NewNumberRange(1, 100, true, "")
+	assert.NoError(t, r(""))
+	assert.NoError(t, r("42"))
+	assert.Error(t, r("0"))
+}
+
+func TestLength(t *testing.T) {
+	l := validation.NewLength(2, 4, false, "")
+
+	assert.NoError(t, l("ab"))
+	assert.NoError(t, l("äöüß"))
+	assert.EqualError(t, l("a"), "must be between 2 and 4 characters long")
+	assert.Error(t, l("abcde"))
+	assert.Error(t, l(""))
+
+	// the default message is taken when the validator is created
+	defaultMessage := validation.DefaultMessage(validation.MessageLength)
+	defer validation.SetDefaultMessage(validation.MessageLength, defaultMessage)
+	validation.SetDefaultMessage(validation.MessageLength, "{min} à {max} caractères")
+	assert.EqualError(t, l("a"), "must be between 2 and 4 characters long")
+	assert.EqualError(t, validation.NewLength(2, 4, false, "")("a"), "2 à 4 caractères")
+
+	l = validation.NewLength(2, 4, true, "")
+	assert.NoError(t, l(""))
+	assert.NoError(t, l("abc"))
+	assert.Error(t, l("a"))
+}
+
+func TestAllowEmpty(t *testing.T) {
+	l := validation.AllowEmpty(validation.NewLength(2, 4, false, ""))
+
+	assert.NoError(t, l(""))
+	assert.NoError(t, l("abc"))
+	assert.Error(t, l("a"))
+}
@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// Severity is how serious a problem found by a validator created with NewWithSeverity is.
+type Severity int
+
+const (
+	// SeverityNone means that the text is valid.
+	SeverityNone Severity = iota
+	// SeverityWarning means that the text is accepted but the user should be told about a problem,
+	// such as a weak password.
+	SeverityWarning
+	// SeverityError means that the text is invalid.
+	SeverityError
+)
+
+// Importance returns the importance to give a widget, such as a label showing the message, so that warnings and
+// errors are shown in their theme colors.
+func (s Severity) Importance() widget.Importance {
+	switch s {
+	case SeverityWarning:
+		return widget.WarningImportance
+	case SeverityError:
+		return widget.DangerImportance
+	default:
+		return widget.MediumImportance
+	}
+}
+
+// Issue is a problem found by a validator created with NewWithSeverity.
+type Issue struct {
+	// Severity is how serious the problem is.
+	Severity Severity
+	// Message explains the problem to the user.
+	Message string
+}
+
+// Error returns the message of the issue.
+func (i *Issue) Error() string {
+	return i.Message
+}
+
+// SeverityValidator runs a check that may find an error, which fails the validation, or a warning, which does not.
+// Use its Validate method as the validator of a widget, so that a form can still be submitted with warnings,
+// and show the warnings from OnValidated.
+type SeverityValidator struct {
+	// OnValidated is called by Validate with the result of each check, including the warnings that pass the
+	// validation. The severity is SeverityNone and the message is empty when there is no problem.
+	OnValidated func(text string, severity Severity, message string)
+
+	check func(string) (Severity, string)
+
+	lock     sync.Mutex
+	severity Severity
+	message  string
+}
+
+// NewWithSeverity returns a validator that calls check to find how serious any problem with the text is,
+// along with a message explaining it.
+//
+// Example:
+//
+//	v := validation.NewWithSeverity(checkPassword)
+//	entry.Validator = v.Validate
+//	v.OnValidated = func(_ string, severity validation.Severity, message string) {
+//	    hint.Importance = severity.Importance()
+//	    hint.SetText(message)
+//	}
+func NewWithSeverity(check func(string) (Severity, string)) *SeverityValidator {
+	return &SeverityValidator{check: check}
+}
+
+// Validate checks the text and returns an *Issue if it has an error. Warnings return nil, so that the widget is
+// still valid, and are reported to OnValidated and by Result.
+// It has the signature of fyne.StringValidator so that it can be assigned to a widget.
+func (v *SeverityValidator) Validate(text string) error {
+	severity, message := v.check(text)
+	if severity == SeverityNone {
+		message = ""
+	}
+
+	v.lock.Lock()
+	v.severity, v.message = severity, message
+	onValidated := v.OnValidated
+	v.lock.Unlock()
+
+	if onValidated != nil {
+		onValidated(text, severity, message)
+	}
+	if severity == SeverityError {
+		return &Issue{Severity: severity, Message: message}
+	}
+	return nil
+}
+
+// Result returns the severity and message found by the last call to Validate.
+func (v *SeverityValidator) Result() (Severity, string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.severity, v.message
+}
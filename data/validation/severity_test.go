@@ -0,0 +1,58 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"fyne.io/x/fyne/data/validation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithSeverity(t *testing.T) {
+	v := validation.NewWithSeverity(func(text string) (validation.Severity, string) {
+		switch {
+		case text == "":
+			return validation.SeverityError, "required"
+		case len(text) < 8:
+			return validation.SeverityWarning, "weak password"
+		default:
+			return validation.SeverityNone, "ignored"
+		}
+	})
+	var reported []validation.Severity
+	v.OnValidated = func(_ string, severity validation.Severity, _ string) {
+		reported = append(reported, severity)
+	}
+
+	err := v.Validate("")
+	var issue *validation.Issue
+	assert.True(t, errors.As(err, &issue))
+	assert.Equal(t, validation.SeverityError, issue.Severity)
+	assert.Equal(t, "required", err.Error())
+
+	// warnings do not fail the validation
+	assert.NoError(t, v.Validate("secret"))
+	severity, message := v.Result()
+	assert.Equal(t, validation.SeverityWarning, severity)
+	assert.Equal(t, "weak password", message)
+	assert.Equal(t, widget.WarningImportance, severity.Importance())
+
+	assert.NoError(t, v.Validate("correct horse"))
+	severity, message = v.Result()
+	assert.Equal(t, validation.SeverityNone, severity)
+	assert.Empty(t, message)
+	assert.Equal(t, []validation.Severity{validation.SeverityError, validation.SeverityWarning, validation.SeverityNone}, reported)
+
+	// an entry with a warning is valid
+	test.NewApp()
+	entry := widget.NewEntry()
+	entry.Validator = v.Validate
+	entry.SetText("secret")
+	assert.NoError(t, entry.Validate())
+	entry.SetText("")
+	assert.Error(t, entry.Validate())
+}
@@ -2,6 +2,7 @@ package dialog
 
 import (
 	"image/color"
+	"net/url"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -12,6 +13,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// Link is a named hyperlink to show in an about dialog, like a website, license or source location.
+type Link struct {
+	Text string
+	URL  *url.URL
+}
+
 // NewAbout creates a parallax about dialog using the app metadata along with the
 // markdown content and links passed into this method.
 // You should call Show on the returned dialog to display it.
@@ -40,6 +47,29 @@ func ShowAbout(content string, links []*widget.Hyperlink, a fyne.App, w fyne.Win
 	d.Show()
 }
 
+// NewAboutInfo creates a parallax about dialog showing the given application name, version and icon
+// along with the links passed into this method, instead of reading the app metadata.
+// The dialog is centered on the parent window and can be dismissed using its OK button.
+// You should call Show on the returned dialog to display it.
+func NewAboutInfo(appName, version string, icon fyne.Resource, links []Link, parent fyne.Window) dialog.Dialog {
+	hyperlinks := make([]*widget.Hyperlink, len(links))
+	for i, l := range links {
+		hyperlinks[i] = widget.NewHyperlink(l.Text, l.URL)
+	}
+
+	d := dialog.NewCustom("About", "OK", aboutInfoContent("", hyperlinks, appName, version, icon), parent)
+	d.Resize(fyne.NewSize(400, 360))
+
+	return d
+}
+
+// ShowAboutInfo opens a parallax about dialog showing the given application name, version and icon
+// along with the links passed into this method.
+func ShowAboutInfo(appName, version string, icon fyne.Resource, links []Link, parent fyne.Window) {
+	d := NewAboutInfo(appName, version, icon, links, parent)
+	d.Show()
+}
+
 // ShowAboutWindow opens a parallax about window using the app metadata along with the
 // markdown content and links passed into this method.
 func ShowAboutWindow(content string, links []*widget.Hyperlink, a fyne.App) {
@@ -48,15 +78,20 @@ func ShowAboutWindow(content string, links []*widget.Hyperlink, a fyne.App) {
 }
 
 func aboutContent(content string, links []*widget.Hyperlink, a fyne.App) fyne.CanvasObject {
+	meta := a.Metadata()
+	return aboutInfoContent(content, links, meta.Name, meta.Version, meta.Icon)
+}
+
+func aboutInfoContent(content string, links []*widget.Hyperlink, name, version string, icon fyne.Resource) fyne.CanvasObject {
 	rich := widget.NewRichTextFromMarkdown(content)
 	footer := aboutFooter(links)
 
-	logo := canvas.NewImageFromResource(a.Metadata().Icon)
+	logo := canvas.NewImageFromResource(icon)
 	logo.FillMode = canvas.ImageFillContain
 	logo.SetMinSize(fyne.NewSize(128, 128))
 
 	appData := widget.NewRichTextFromMarkdown(
-		"## " + a.Metadata().Name + "\n**Version:** " + a.Metadata().Version)
+		"## " + name + "\n**Version:** " + version)
 	centerText(appData)
 	space := canvas.NewRectangle(color.Transparent)
 	space.SetMinSize(fyne.NewSquareSize(theme.Padding() * 4))
@@ -70,12 +105,12 @@ func aboutContent(content string, links []*widget.Hyperlink, a fyne.App) fyne.Ca
 	bgColor := withAlpha(theme.BackgroundColor(), 0xe0)
 	shadowColor := withAlpha(theme.BackgroundColor(), 0x33)
 
-	underlay := canvas.NewImageFromResource(a.Metadata().Icon)
+	underlay := canvas.NewImageFromResource(icon)
 	bg := canvas.NewRectangle(bgColor)
 	underlayer := underLayout{}
 	slideBG := container.New(underlayer, underlay)
 	footerBG := canvas.NewRectangle(shadowColor)
-	watchTheme(bg, footerBG, a)
+	watchTheme(bg, footerBG)
 
 	underlay.Resize(fyne.NewSize(512, 512))
 	scroll.OnScrolled = func(p fyne.Position) {
@@ -112,7 +147,7 @@ func centerText(rich *widget.RichText) {
 	}
 }
 
-func watchTheme(bg, footer *canvas.Rectangle, a fyne.App) {
+func watchTheme(bg, footer *canvas.Rectangle) {
 	listen := make(chan fyne.Settings)
 	fyne.CurrentApp().Settings().AddChangeListener(listen)
 	go func() {
@@ -0,0 +1,77 @@
+package dialog
+
+import (
+	"net/url"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAboutInfo(t *testing.T) {
+	win := test.NewWindow(nil)
+	win.Resize(fyne.NewSize(600, 600))
+	defer win.Close()
+
+	site, _ := url.Parse("https://fyne.io")
+	source, _ := url.Parse("https://github.com/fyne-io/fyne-x")
+	d := NewAboutInfo("Demo", "1.2.3", theme.FyneLogo(), []Link{{"Website", site}, {"Source", source}}, win)
+	assert.Nil(t, win.Canvas().Overlays().Top())
+	d.Show()
+	popup := win.Canvas().Overlays().Top()
+	assert.NotNil(t, popup)
+
+	var texts []string
+	var links []*widget.Hyperlink
+	var icons int
+	for _, o := range test.LaidOutObjects(popup) {
+		switch obj := o.(type) {
+		case *widget.RichText:
+			texts = append(texts, obj.String())
+		case *widget.Hyperlink:
+			links = append(links, obj)
+		case *canvas.Image:
+			if obj.Resource == theme.FyneLogo() {
+				icons++
+			}
+		}
+	}
+	assert.Contains(t, texts, "DemoVersion: 1.2.3")
+	assert.Equal(t, 2, icons) // the logo and the parallax underlay
+	if assert.Len(t, links, 2) {
+		assert.Equal(t, "Website", links[0].Text)
+		assert.Equal(t, site, links[0].URL)
+		assert.Equal(t, "Source", links[1].Text)
+		assert.Equal(t, source, links[1].URL)
+	}
+
+	d.Hide()
+	assert.Nil(t, win.Canvas().Overlays().Top())
+}
+
+func TestShowAboutInfo(t *testing.T) {
+	win := test.NewWindow(nil)
+	win.Resize(fyne.NewSize(600, 600))
+	defer win.Close()
+
+	ShowAboutInfo("Demo", "1.2.3", theme.FyneLogo(), nil, win)
+	popup := win.Canvas().Overlays().Top()
+	if !assert.NotNil(t, popup) {
+		return
+	}
+
+	var ok *widget.Button
+	for _, o := range test.LaidOutObjects(popup) {
+		if b, isButton := o.(*widget.Button); isButton && b.Text == "OK" {
+			ok = b
+		}
+	}
+	if assert.NotNil(t, ok) {
+		test.Tap(ok)
+		assert.Nil(t, win.Canvas().Overlays().Top())
+	}
+}
@@ -0,0 +1,87 @@
+package dialog
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// BoundProgress is a dialog showing a progress bar that follows the value of a float binding.
+// The value is expected to be in the range 0.0 to 1.0.
+type BoundProgress struct {
+	*dialog.CustomDialog
+
+	autoDismiss bool
+	onCancelled func()
+	onClosed    func()
+	value       binding.Float
+	listener    binding.DataListener
+}
+
+// NewBoundProgress creates a progress dialog that updates its bar as the value binding changes.
+// By default the dialog has no buttons, use SetOnCancelled to allow the operation to be cancelled
+// and SetAutoDismiss to hide the dialog once the value reaches 1.0.
+// You should call Show on the returned dialog to display it.
+func NewBoundProgress(title, message string, value binding.Float, parent fyne.Window) *BoundProgress {
+	bar := widget.NewProgressBarWithData(value)
+	content := container.NewVBox(widget.NewLabelWithStyle(message, fyne.TextAlignCenter, fyne.TextStyle{}), bar)
+
+	p := &BoundProgress{CustomDialog: dialog.NewCustomWithoutButtons(title, content, parent), value: value}
+	p.listener = binding.NewDataListener(p.valueChanged)
+	p.CustomDialog.SetOnClosed(p.closed)
+	return p
+}
+
+// SetAutoDismiss sets whether the dialog should hide itself when the bound value reaches 1.0.
+func (p *BoundProgress) SetAutoDismiss(dismiss bool) {
+	p.autoDismiss = dismiss
+}
+
+// SetOnCancelled shows a Cancel button that hides the dialog and calls the passed function.
+// Passing nil removes the button.
+func (p *BoundProgress) SetOnCancelled(cancelled func()) {
+	p.onCancelled = cancelled
+	if cancelled == nil {
+		p.SetButtons(nil)
+		return
+	}
+
+	cancel := widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), func() {
+		p.Hide()
+		if p.onCancelled != nil {
+			p.onCancelled()
+		}
+	})
+	p.SetButtons([]fyne.CanvasObject{cancel})
+}
+
+// SetOnClosed sets a function to be called when the dialog is closed, whether it was cancelled or dismissed.
+func (p *BoundProgress) SetOnClosed(closed func()) {
+	p.onClosed = closed
+}
+
+// Show displays the dialog and starts tracking the bound value.
+func (p *BoundProgress) Show() {
+	p.value.AddListener(p.listener)
+	p.CustomDialog.Show()
+}
+
+func (p *BoundProgress) closed() {
+	p.value.RemoveListener(p.listener)
+	if p.onClosed != nil {
+		p.onClosed()
+	}
+}
+
+func (p *BoundProgress) valueChanged() {
+	if !p.autoDismiss {
+		return
+	}
+
+	if v, err := p.value.Get(); err == nil && v >= 1.0 {
+		p.Hide()
+	}
+}
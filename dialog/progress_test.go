@@ -0,0 +1,137 @@
+package dialog
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundProgress(t *testing.T) {
+	win := test.NewWindow(nil)
+	win.Resize(fyne.NewSize(600, 600))
+	defer win.Close()
+
+	value := binding.NewFloat()
+	p := NewBoundProgress("Working", "Please wait", value, win)
+	waitForListeners(t, value)
+	p.Show()
+	bar := findProgressBar(win)
+	if !assert.NotNil(t, bar) {
+		return
+	}
+	assert.Nil(t, findButton(win, "Cancel"))
+
+	// the bar follows the value, and the dialog stays open at 1.0 unless it dismisses itself
+	_ = value.Set(0.5)
+	waitForListeners(t, value)
+	assert.Equal(t, 0.5, bar.Value)
+	_ = value.Set(1)
+	waitForListeners(t, value)
+	assert.Equal(t, 1.0, bar.Value)
+	assert.NotNil(t, win.Canvas().Overlays().Top())
+	p.Hide()
+	assert.Nil(t, win.Canvas().Overlays().Top())
+}
+
+func TestBoundProgress_AutoDismiss(t *testing.T) {
+	win := test.NewWindow(nil)
+	win.Resize(fyne.NewSize(600, 600))
+	defer win.Close()
+
+	value := binding.NewFloat()
+	p := NewBoundProgress("Working", "Please wait", value, win)
+	waitForListeners(t, value)
+	p.SetAutoDismiss(true)
+	var closed int32
+	p.SetOnClosed(func() {
+		atomic.AddInt32(&closed, 1)
+	})
+	p.Show()
+
+	_ = value.Set(0.9)
+	waitForListeners(t, value)
+	assert.NotNil(t, win.Canvas().Overlays().Top())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&closed))
+
+	_ = value.Set(1)
+	waitForListeners(t, value)
+	assert.Nil(t, win.Canvas().Overlays().Top())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&closed))
+}
+
+func TestBoundProgress_Cancel(t *testing.T) {
+	win := test.NewWindow(nil)
+	win.Resize(fyne.NewSize(600, 600))
+	defer win.Close()
+
+	value := binding.NewFloat()
+	p := NewBoundProgress("Working", "Please wait", value, win)
+	waitForListeners(t, value)
+	cancelled, closed := false, false
+	p.SetOnCancelled(func() {
+		cancelled = true
+	})
+	p.SetOnClosed(func() {
+		closed = true
+	})
+	p.Show()
+
+	cancel := findButton(win, "Cancel")
+	if !assert.NotNil(t, cancel) {
+		return
+	}
+	test.Tap(cancel)
+	assert.True(t, cancelled)
+	assert.True(t, closed)
+	assert.Nil(t, win.Canvas().Overlays().Top())
+
+	// removing the callback removes the button
+	p.SetOnCancelled(nil)
+	p.Show()
+	assert.Nil(t, findButton(win, "Cancel"))
+	p.Hide()
+}
+
+func findButton(win fyne.Window, text string) *widget.Button {
+	top := win.Canvas().Overlays().Top()
+	if top == nil {
+		return nil
+	}
+	for _, o := range test.LaidOutObjects(top) {
+		if b, ok := o.(*widget.Button); ok && b.Text == text && b.Visible() {
+			return b
+		}
+	}
+	return nil
+}
+
+func findProgressBar(win fyne.Window) *widget.ProgressBar {
+	top := win.Canvas().Overlays().Top()
+	if top == nil {
+		return nil
+	}
+	for _, o := range test.LaidOutObjects(top) {
+		if bar, ok := o.(*widget.ProgressBar); ok {
+			return bar
+		}
+	}
+	return nil
+}
+
+// waitForListeners returns once the listeners of data have handled the changes made so far.
+// Listeners are notified in order on a single goroutine, so a listener added now is called after them.
+func waitForListeners(t *testing.T, data binding.DataItem) {
+	var called int32
+	listener := binding.NewDataListener(func() {
+		atomic.StoreInt32(&called, 1)
+	})
+	data.AddListener(listener)
+	defer data.RemoveListener(listener)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&called) == 1 }, time.Second, 10*time.Millisecond)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"sort"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -99,7 +100,53 @@ func newResponsiveConf(ratios ...float32) responsiveConfig {
 
 // ResponsiveLayout is the layout that will adapt objects with the responsive rules. See NewResponsiveLayout
 // for details.
-type ResponsiveLayout struct{}
+type ResponsiveLayout struct {
+	columns map[responsiveBreakpoint]int
+
+	customPadding              bool
+	horizontalPad, verticalPad float32
+}
+
+// WithPadding sets explicit gutters between the cells of the layout, replacing the theme padding.
+// The horizontal gutter is placed between the objects of a line and is removed from the width
+// available to them, the vertical gutter is placed between lines. The layout is returned to allow chaining.
+//
+// Example:
+//
+//	container := NewResponsiveLayout(card1, card2, card3)
+//	container.Layout.(*ResponsiveLayout).WithPadding(16, 16)
+func (resp *ResponsiveLayout) WithPadding(horizontal, vertical float32) *ResponsiveLayout {
+	resp.customPadding = true
+	resp.horizontalPad = horizontal
+	resp.verticalPad = vertical
+	return resp
+}
+
+// Breakpoints sets a fixed number of columns for each breakpoint, like the Bootstrap grid.
+// When set, every object is sized to fill one column and the ratios given to Responsive are ignored.
+// Objects flow into the next line once all the columns of a line are used.
+// The column counts must be > 0, the layout is returned to allow chaining.
+//
+// Example:
+//
+//	container := NewResponsiveLayout(label1, label2, label3)
+//	container.Layout.(*ResponsiveLayout).Breakpoints(1, 2, 3, 4)
+func (resp *ResponsiveLayout) Breakpoints(small, medium, large, xlarge int) *ResponsiveLayout {
+	for _, cols := range []int{small, medium, large, xlarge} {
+		if cols <= 0 {
+			message := "Responsive: column count must be > 0, got: %d"
+			panic(fmt.Errorf(message, cols))
+		}
+	}
+
+	resp.columns = map[responsiveBreakpoint]int{
+		SMALL:  small,
+		MEDIUM: medium,
+		LARGE:  large,
+		XLARGE: xlarge,
+	}
+	return resp
+}
 
 // Layout will place the size and place the objects following the configured reponsive rules.
 //
@@ -123,18 +170,28 @@ func (resp *ResponsiveLayout) Layout(objects []fyne.CanvasObject, containerSize
 	// to calculate the next pos.Y when a new line is needed
 	maxHeight := float32(0)
 
-	// objects in a line
+	// objects in a line, and the width they take with their offsets but without the padding between them
 	line := []fyne.CanvasObject{}
+	lineWidth := float32(0)
 
 	// cast windowSize.Width to responsiveBreakpoint (uint16)
 	ww := responsiveBreakpoint(window.Size().Width)
 
-	// For each object, place it at the right position (pos) and resize it.
-	for _, o := range objects {
-		if o == nil || !o.Visible() {
-			continue
-		}
+	bp := resp.breakpoint(ww)
+
+	// go to the next line once the objects of the current line are known
+	newLine := func() {
+		// we now know the number of object in a line, fix padding
+		resp.fixPaddingOnLine(line)
+		line = []fyne.CanvasObject{}
+		lineWidth = 0
+		pos.X = 0                             // back to left
+		pos.Y += maxHeight + resp.verticalPad // move to the next line
+		maxHeight = 0
+	}
 
+	// For each object, place it at the right position (pos) and resize it.
+	for _, o := range resp.ordered(objects, bp) {
 		// get tht configuration
 		ro, ok := o.(*responsiveWidget)
 		if !ok {
@@ -142,42 +199,90 @@ func (resp *ResponsiveLayout) Layout(objects []fyne.CanvasObject, containerSize
 		}
 		conf := ro.responsiveConfig
 
-		line = append(line, o) // add the container to the line
-		size := o.MinSize()    // get some informations
+		size := o.MinSize() // get some informations
+		offset := ro.offsetConfig[bp] * containerSize.Width
 
 		// adapt object witdh from the configuration
-		if ww <= SMALL {
-			size.Width = conf[SMALL] * containerSize.Width
-		} else if ww <= MEDIUM {
-			size.Width = conf[MEDIUM] * containerSize.Width
-		} else if ww <= LARGE {
-			size.Width = conf[LARGE] * containerSize.Width
+		if cols, ok := resp.columns[bp]; ok {
+			size.Width = containerSize.Width / float32(cols)
 		} else {
-			size.Width = conf[XLARGE] * containerSize.Width
+			size.Width = conf[bp] * containerSize.Width
 		}
 
+		// The leading empty space and the object do not fit in the rest of the line, so go to next line.
+		// The small tolerance allows for rounding errors when a line is exactly full.
+		if len(line) > 0 && lineWidth+offset+size.Width > containerSize.Width+0.5 {
+			newLine()
+		}
+		line = append(line, o) // add the container to the line
+		lineWidth += offset + size.Width
+
+		// leave the leading empty space
+		pos.X += offset
+
 		// place and resize the element
 		o.Resize(size)
 		o.Move(pos)
 
 		// next element X position
-		pos = pos.Add(fyne.NewPos(size.Width+theme.Padding(), 0))
+		pos = pos.Add(fyne.NewPos(size.Width+resp.horizontalPadding(), 0))
 
 		maxHeight = resp.maxFloat32(maxHeight, size.Height)
 
 		// Manage end of line, the next position overflows, so go to next line.
-		if pos.X >= containerSize.Width-theme.Padding() {
-			// we now know the number of object in a line, fix padding
-			resp.fixPaddingOnLine(line)
-			line = []fyne.CanvasObject{}
-			pos.X = 0          // back to left
-			pos.Y += maxHeight // move to the next line
-			maxHeight = 0
+		// The small tolerance allows for rounding errors when a line is exactly full.
+		if pos.X >= containerSize.Width-resp.horizontalPadding()-0.5 {
+			newLine()
 		}
 	}
 	resp.fixPaddingOnLine(line) // fix padding for the last line
 }
 
+// horizontalPadding returns the space between objects of a line.
+func (resp *ResponsiveLayout) horizontalPadding() float32 {
+	if resp.customPadding {
+		return resp.horizontalPad
+	}
+	return theme.Padding()
+}
+
+// ordered returns the visible objects sorted by their order for the given breakpoint.
+// Objects with the same order keep the order they were added in.
+func (resp *ResponsiveLayout) ordered(objects []fyne.CanvasObject, bp responsiveBreakpoint) []fyne.CanvasObject {
+	visible := []fyne.CanvasObject{}
+	for _, o := range objects {
+		if o != nil && o.Visible() {
+			visible = append(visible, o)
+		}
+	}
+
+	sort.SliceStable(visible, func(i, j int) bool {
+		return orderOf(visible[i], bp) < orderOf(visible[j], bp)
+	})
+	return visible
+}
+
+func orderOf(o fyne.CanvasObject, bp responsiveBreakpoint) int {
+	if ro, ok := o.(*responsiveWidget); ok {
+		return ro.orderConfig[bp]
+	}
+	return 0
+}
+
+// breakpoint returns the breakpoint that applies to the given window width.
+func (resp *ResponsiveLayout) breakpoint(width responsiveBreakpoint) responsiveBreakpoint {
+	switch {
+	case width <= SMALL:
+		return SMALL
+	case width <= MEDIUM:
+		return MEDIUM
+	case width <= LARGE:
+		return LARGE
+	default:
+		return XLARGE
+	}
+}
+
 // MinSize return the minimum size ot the layout.
 //
 // Implements: fyne.Layout
@@ -186,18 +291,29 @@ func (resp *ResponsiveLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
 		return fyne.NewSize(0, 0)
 	}
 
-	var h, w, maxHeight float32
-	currentY := objects[0].Position().Y
-
+	// objects may be reordered, so walk them line by line
+	sorted := []fyne.CanvasObject{}
 	for _, o := range objects {
-		if o == nil || !o.Visible() {
-			continue
+		if o != nil && o.Visible() {
+			sorted = append(sorted, o)
 		}
-		w = resp.maxFloat32(o.MinSize().Width, w) + theme.Padding()
+	}
+	if len(sorted) == 0 {
+		return fyne.NewSize(0, 0)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position().Y < sorted[j].Position().Y
+	})
+
+	var h, w, maxHeight float32
+	currentY := sorted[0].Position().Y
+
+	for _, o := range sorted {
+		w = resp.maxFloat32(o.MinSize().Width, w) + resp.horizontalPadding()
 		if o.Position().Y != currentY {
 			currentY = o.Position().Y
 			// new line, so we can add the maxHeight to h
-			h += maxHeight
+			h += maxHeight + resp.verticalPad
 
 			// drop the line
 			maxHeight = 0
@@ -213,6 +329,10 @@ func (resp *ResponsiveLayout) fixPaddingOnLine(line []fyne.CanvasObject) {
 	if len(line) <= 1 {
 		return
 	}
+	if resp.customPadding {
+		resp.applyGutters(line)
+		return
+	}
 	for i, o := range line {
 		s := o.Size()
 		s.Width -= theme.Padding() / float32(len(line)-1)
@@ -225,6 +345,21 @@ func (resp *ResponsiveLayout) fixPaddingOnLine(line []fyne.CanvasObject) {
 	}
 }
 
+// applyGutters shares the horizontal gutters of a line between its objects and moves them to keep the gutters.
+func (resp *ResponsiveLayout) applyGutters(line []fyne.CanvasObject) {
+	share := resp.horizontalPad * float32(len(line)-1) / float32(len(line))
+	for i, o := range line {
+		s := o.Size()
+		s.Width -= share
+		o.Resize(s)
+		if i > 0 {
+			p := o.Position()
+			p.X -= share * float32(i)
+			o.Move(p)
+		}
+	}
+}
+
 // math.Max only works with float64, so let's make our own
 func (resp *ResponsiveLayout) maxFloat32(a, b float32) float32 {
 	return float32(math.Max(float64(a), float64(b)))
@@ -260,6 +395,8 @@ type responsiveWidget struct {
 
 	render           fyne.CanvasObject
 	responsiveConfig responsiveConfig
+	offsetConfig     responsiveConfig
+	orderConfig      map[responsiveBreakpoint]int
 }
 
 var _ fyne.Widget = (*responsiveWidget)(nil)
@@ -278,6 +415,76 @@ func Responsive(object fyne.CanvasObject, breakpointRatio ...float32) fyne.Canva
 	return ro
 }
 
+// ResponsiveOrder sets the position of the object in the layout for each breakpoint, like Bootstrap order classes.
+// Objects are placed by ascending order, objects with the same order keep the order they were added in.
+// The default order is 0. The orders are passed in this order:
+//
+//	ResponsiveOrder(object, smallOrder, mediumOrder, largeOrder, xlargeOrder)
+//
+// They are set to previous value if a value is not passed.
+// If the object is not already responsive it is wrapped using Responsive with the default ratios.
+//
+// Example, to show a sidebar after the content on small screens but before it otherwise:
+//
+//	NewResponsiveLayout(
+//	    ResponsiveOrder(Responsive(sidebar, 1, .25), 1, 0),
+//	    Responsive(content, 1, .75),
+//	)
+func ResponsiveOrder(object fyne.CanvasObject, orders ...int) fyne.CanvasObject {
+	ro := asResponsive(object)
+	ro.orderConfig = map[responsiveBreakpoint]int{}
+	for index, bp := range []responsiveBreakpoint{SMALL, MEDIUM, LARGE, XLARGE} {
+		if len(orders) <= index {
+			if index == 0 {
+				orders = append(orders, 0)
+			} else {
+				orders = append(orders, orders[index-1])
+			}
+		}
+		ro.orderConfig[bp] = orders[index]
+	}
+	return ro
+}
+
+// ResponsiveOffset sets an empty space before the object for each breakpoint, like Bootstrap offset classes.
+// The offsets are ratios of the container width that must be 0 <= offset < 1, for example .25 skips one column
+// of a four column grid. They are passed in this order:
+//
+//	ResponsiveOffset(object, smallOffset, mediumOffset, largeOffset, xlargeOffset)
+//
+// They are set to previous value if a value is not passed, or 0 if there is no previous value.
+// An object whose offset and width do not fit in the rest of a line is placed, with its offset, on the next line.
+// If the object is not already responsive it is wrapped using Responsive with the default ratios.
+func ResponsiveOffset(object fyne.CanvasObject, offsets ...float32) fyne.CanvasObject {
+	for _, i := range offsets {
+		if i < 0 || i >= 1 {
+			message := "Responsive: offset must be >= 0 and < 1, got: %f"
+			panic(fmt.Errorf(message, i))
+		}
+	}
+
+	ro := asResponsive(object)
+	ro.offsetConfig = responsiveConfig{}
+	for index, bp := range []responsiveBreakpoint{SMALL, MEDIUM, LARGE, XLARGE} {
+		if len(offsets) <= index {
+			if index == 0 {
+				offsets = append(offsets, 0)
+			} else {
+				offsets = append(offsets, offsets[index-1])
+			}
+		}
+		ro.offsetConfig[bp] = offsets[index]
+	}
+	return ro
+}
+
+func asResponsive(object fyne.CanvasObject) *responsiveWidget {
+	if ro, ok := object.(*responsiveWidget); ok {
+		return ro
+	}
+	return Responsive(object).(*responsiveWidget)
+}
+
 func (ro *responsiveWidget) CreateRenderer() fyne.WidgetRenderer {
 	if ro.render == nil {
 		return nil
@@ -157,3 +157,112 @@ func TestResponsive_NoArgs(t *testing.T) {
 		}
 	}
 }
+
+// Check that fixed column counts override the object ratios.
+func TestResponsive_Breakpoints(t *testing.T) {
+	padding := theme.Padding()
+
+	// build
+	label1 := Responsive(widget.NewLabel("Hello World"), 1)
+	label2 := Responsive(widget.NewLabel("Hello World"), 1)
+	label3 := Responsive(widget.NewLabel("Hello World"), 1)
+	layout := NewResponsiveLayout(label1, label2, label3)
+	layout.Layout.(*ResponsiveLayout).Breakpoints(1, 2, 3, 3)
+
+	win := test.NewWindow(layout)
+	defer win.Close()
+
+	// small: one column, each label on its own line
+	w, h := float32(SMALL), float32(300)
+	win.Resize(fyne.NewSize(w, h))
+	assert.Equal(t, w-padding*2, label1.Size().Width)
+	assert.NotEqual(t, label1.Position().Y, label2.Position().Y)
+
+	// medium: two columns, the third label goes to the next line
+	w = float32(MEDIUM)
+	win.Resize(fyne.NewSize(w, h))
+	assert.Equal(t, label1.Position().Y, label2.Position().Y)
+	assert.NotEqual(t, label1.Position().Y, label3.Position().Y)
+
+	// large: three columns on the same line
+	w = float32(LARGE)
+	win.Resize(fyne.NewSize(w, h))
+	assert.Equal(t, label1.Position().Y, label3.Position().Y)
+
+	assert.Panics(t, func() {
+		layout.Layout.(*ResponsiveLayout).Breakpoints(1, 0, 3, 4)
+	})
+}
+
+// Check that explicit gutters are removed from the cell widths and placed between cells and lines.
+func TestResponsive_WithPadding(t *testing.T) {
+	// build
+	label1 := Responsive(widget.NewLabel("Hello World"), 1, .5)
+	label2 := Responsive(widget.NewLabel("Hello World"), 1, .5)
+	label3 := Responsive(widget.NewLabel("Hello World"), 1, .5)
+	layout := NewResponsiveLayout(label1, label2, label3)
+	layout.Layout.(*ResponsiveLayout).WithPadding(20, 10)
+
+	win := test.NewWindow(layout)
+	win.SetPadded(false)
+	defer win.Close()
+
+	w, h := float32(MEDIUM), float32(300)
+	win.Resize(fyne.NewSize(w, h))
+	assert.Equal(t, (w-20)/2, label1.Size().Width)
+	assert.Equal(t, (w-20)/2, label2.Size().Width)
+	assert.Equal(t, label1.Size().Width+20, label2.Position().X)
+	assert.Equal(t, label1.Position().Y+label1.Size().Height+10, label3.Position().Y)
+}
+
+// Check that objects are reordered when the breakpoint changes.
+func TestResponsive_Order(t *testing.T) {
+	sidebar := ResponsiveOrder(Responsive(widget.NewLabel("Sidebar"), 1, .25), 1, 0)
+	content := Responsive(widget.NewLabel("Content"), 1, .75)
+
+	win := test.NewWindow(NewResponsiveLayout(sidebar, content))
+	defer win.Close()
+
+	// small: the sidebar goes below the content
+	win.Resize(fyne.NewSize(float32(SMALL), 300))
+	assert.Less(t, content.Position().Y, sidebar.Position().Y)
+
+	// medium: the sidebar is placed before the content on the same line
+	win.Resize(fyne.NewSize(float32(MEDIUM), 300))
+	assert.Equal(t, content.Position().Y, sidebar.Position().Y)
+	assert.Less(t, sidebar.Position().X, content.Position().X)
+}
+
+// Check that an offset leaves an empty space before the object.
+func TestResponsive_Offset(t *testing.T) {
+	label := ResponsiveOffset(Responsive(widget.NewLabel("Hello World"), .5), .25)
+	layout := NewResponsiveLayout(label)
+
+	win := test.NewWindow(layout)
+	defer win.Close()
+	win.Resize(fyne.NewSize(float32(MEDIUM), 300))
+
+	assert.Equal(t, layout.Size().Width*.25, label.Position().X)
+	assert.Panics(t, func() {
+		ResponsiveOffset(label, 1)
+	})
+}
+
+// Check that an object goes to the next line when its offset does not fit in the rest of the line.
+func TestResponsive_OffsetWrap(t *testing.T) {
+	first := Responsive(widget.NewLabel("First"), .5)
+	second := ResponsiveOffset(Responsive(widget.NewLabel("Second"), .5), .25)
+	third := Responsive(widget.NewLabel("Third"), .25)
+	layout := NewResponsiveLayout(first, second, third)
+
+	win := test.NewWindow(layout)
+	defer win.Close()
+	win.Resize(fyne.NewSize(float32(MEDIUM), 300))
+
+	width := layout.Size().Width
+	assert.Equal(t, float32(0), first.Position().X)
+	assert.Greater(t, second.Position().Y, first.Position().Y)
+	assert.Equal(t, width*.25, second.Position().X)
+	assert.Equal(t, second.Position().Y, third.Position().Y)
+	assert.LessOrEqual(t, third.Position().X+third.Size().Width, width)
+}
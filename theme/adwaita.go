@@ -2,6 +2,7 @@ package theme
 
 import (
 	"image/color"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
@@ -13,15 +14,91 @@ var _ fyne.Theme = (*Adwaita)(nil)
 
 // Adwaita is a theme that follows the Adwaita theme. It provides a light and dark theme + icons.
 // See: https://gnome.pages.gitlab.gnome.org/libadwaita/doc/main/named-colors.html
-type Adwaita struct{}
+type Adwaita struct {
+	accent       color.Color
+	compact      bool
+	cornerRadius float32
+	customRadius bool
+	highContrast bool
+
+	colorOverrides map[fyne.ThemeColorName]color.Color
+	sizeOverrides  map[fyne.ThemeSizeName]float32
+}
+
+var (
+	systemAccent     color.Color
+	systemAccentOnce sync.Once
+)
 
 // AdwaitaTheme returns a new Adwaita theme.
+//
+// The accent color chosen in the desktop settings is read once, when the first Adwaita theme is created,
+// so that drawing does not wait for the desktop. The desktop is given a quarter of a second to answer, after which
+// the Adwaita blue is used. A change of the accent applies once the application restarts.
 func AdwaitaTheme() fyne.Theme {
+	readSystemAccent()
 	return &Adwaita{}
 }
 
+// AdwaitaHighContrast returns a new Adwaita theme using the GNOME high contrast palette.
+// Text is fully opaque and borders and separators are darker and thicker, to help low-vision users.
+// The primary color is that of the high contrast palette rather than the accent color of the desktop settings.
+// See: https://gnome.pages.gitlab.gnome.org/libadwaita/doc/main/styles-and-appearance.html#high-contrast
+func AdwaitaHighContrast() fyne.Theme {
+	return &Adwaita{highContrast: true}
+}
+
+// NewAdwaitaWithOverrides returns a new Adwaita theme that uses the given colors in place of the Adwaita ones.
+// The overrides apply to both the light and dark variants, every other color follows the variant as usual.
+// Sizes can be overridden too, using SetSizeOverrides.
+func NewAdwaitaWithOverrides(overrides map[fyne.ThemeColorName]color.Color) fyne.Theme {
+	readSystemAccent()
+	return &Adwaita{colorOverrides: overrides}
+}
+
+// SetAccentColor forces the color used for theme.ColorNamePrimary, ignoring the system accent color.
+// Passing nil restores the default behavior.
+func (a *Adwaita) SetAccentColor(c color.Color) {
+	a.accent = c
+}
+
+// SetCompact sets whether the theme uses a compact density, with half of the usual padding
+// around and inside widgets. This is useful for data-dense interfaces.
+func (a *Adwaita) SetCompact(compact bool) {
+	a.compact = compact
+}
+
+// SetCornerRadius sets the radius used for the corners of inputs, buttons and selections.
+// A radius of 0 gives square corners.
+func (a *Adwaita) SetCornerRadius(radius float32) {
+	a.cornerRadius = radius
+	a.customRadius = true
+}
+
+// SetSizeOverrides sets sizes that are used in place of the Adwaita ones, taking precedence over
+// the compact density, corner radius and high contrast settings. Passing nil removes the overrides.
+func (a *Adwaita) SetSizeOverrides(overrides map[fyne.ThemeSizeName]float32) {
+	a.sizeOverrides = overrides
+}
+
 // Color returns the named color for the current theme.
+// The primary color follows the accent color chosen in the desktop settings where it is available,
+// as it was when the theme was created.
 func (a *Adwaita) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if c, ok := a.colorOverrides[name]; ok {
+		return c
+	}
+	if name == theme.ColorNamePrimary {
+		if c := a.accentColor(); c != nil {
+			return c
+		}
+	}
+	if a.highContrast {
+		if c, ok := a.highContrastColor(name, variant); ok {
+			return c
+		}
+	}
+
 	switch variant {
 	case theme.VariantLight:
 		if c, ok := adwaitaLightScheme[name]; ok {
@@ -50,5 +127,92 @@ func (a *Adwaita) Icon(name fyne.ThemeIconName) fyne.Resource {
 
 // Size returns the size of the named resource for the current theme.
 func (a *Adwaita) Size(name fyne.ThemeSizeName) float32 {
+	if size, ok := a.sizeOverrides[name]; ok {
+		return size
+	}
+	switch name {
+	case theme.SizeNameInputRadius, theme.SizeNameSelectionRadius:
+		if a.customRadius {
+			return a.cornerRadius
+		}
+	case theme.SizeNamePadding, theme.SizeNameInnerPadding, theme.SizeNameLineSpacing:
+		if a.compact {
+			return theme.DefaultTheme().Size(name) / 2
+		}
+	}
+	if a.highContrast {
+		switch name {
+		case theme.SizeNameSeparatorThickness, theme.SizeNameInputBorder:
+			return 2
+		}
+	}
 	return theme.DefaultTheme().Size(name)
 }
+
+func (a *Adwaita) accentColor() color.Color {
+	if a.accent != nil {
+		return a.accent
+	}
+	if a.highContrast {
+		return nil
+	}
+
+	return readSystemAccent()
+}
+
+// readSystemAccent returns the accent color of the desktop settings, which is looked up only the first time
+func readSystemAccent() color.Color {
+	systemAccentOnce.Do(func() {
+		if c, ok := findSystemAccentColor(); ok {
+			systemAccent = c
+		}
+	})
+	return systemAccent
+}
+
+func (a *Adwaita) highContrastColor(name fyne.ThemeColorName, variant fyne.ThemeVariant) (color.Color, bool) {
+	var c color.Color
+	var ok bool
+	switch variant {
+	case theme.VariantLight:
+		c, ok = adwaitaHighContrastLightScheme[name]
+	case theme.VariantDark:
+		c, ok = adwaitaHighContrastDarkScheme[name]
+	}
+	return c, ok
+}
+
+// The high contrast schemes only list the colors that differ from the standard schemes.
+var adwaitaHighContrastLightScheme = map[fyne.ThemeColorName]color.Color{
+	theme.ColorNameBackground:      color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameButton:          color.NRGBA{R: 0xd6, G: 0xd6, B: 0xd6, A: 0xff},
+	theme.ColorNameDisabled:        color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x99},
+	theme.ColorNameForeground:      color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameHover:           color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x26},
+	theme.ColorNameHyperlink:       color.NRGBA{R: 0x1a, G: 0x5f, B: 0xb4, A: 0xff},
+	theme.ColorNameInputBackground: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameInputBorder:     color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x80},
+	theme.ColorNamePlaceHolder:     color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xb3},
+	theme.ColorNamePressed:         color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x40},
+	theme.ColorNamePrimary:         color.NRGBA{R: 0x1c, G: 0x71, B: 0xd8, A: 0xff},
+	theme.ColorNameScrollBar:       color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xb3},
+	theme.ColorNameSelection:       color.NRGBA{R: 0x1c, G: 0x71, B: 0xd8, A: 0x4d},
+	theme.ColorNameSeparator:       color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x80},
+}
+
+var adwaitaHighContrastDarkScheme = map[fyne.ThemeColorName]color.Color{
+	theme.ColorNameBackground:      color.NRGBA{R: 0x1e, G: 0x1e, B: 0x1e, A: 0xff},
+	theme.ColorNameButton:          color.NRGBA{R: 0x45, G: 0x45, B: 0x45, A: 0xff},
+	theme.ColorNameDisabled:        color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x99},
+	theme.ColorNameForeground:      color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameHover:           color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x26},
+	theme.ColorNameHyperlink:       color.NRGBA{R: 0x99, G: 0xc1, B: 0xf1, A: 0xff},
+	theme.ColorNameInputBackground: color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	theme.ColorNameInputBorder:     color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x80},
+	theme.ColorNamePlaceHolder:     color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xb3},
+	theme.ColorNamePressed:         color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x40},
+	theme.ColorNamePrimary:         color.NRGBA{R: 0x78, G: 0xae, B: 0xed, A: 0xff},
+	theme.ColorNameScrollBar:       color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xb3},
+	theme.ColorNameSelection:       color.NRGBA{R: 0x78, G: 0xae, B: 0xed, A: 0x4d},
+	theme.ColorNameSeparator:       color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x80},
+}
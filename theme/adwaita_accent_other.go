@@ -0,0 +1,11 @@
+//go:build js || wasm || android || !(linux || openbsd || freebsd || netbsd)
+// +build js wasm android !linux,!openbsd,!freebsd,!netbsd
+
+package theme
+
+import "image/color"
+
+// findSystemAccentColor is not supported on this platform.
+func findSystemAccentColor() (color.Color, bool) {
+	return nil, false
+}
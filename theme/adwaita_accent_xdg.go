@@ -0,0 +1,74 @@
+//go:build !js && !wasm && (linux || openbsd || freebsd || netbsd) && !android
+// +build !js
+// +build !wasm
+// +build linux openbsd freebsd netbsd
+// +build !android
+
+package theme
+
+import (
+	"context"
+	"image/color"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// accentLookupTimeout limits how long the settings portal is waited for, so that a slow or hung portal
+// does not hold up the start of the application
+const accentLookupTimeout = 250 * time.Millisecond
+
+// findSystemAccentColor reads the accent color chosen by the user from the freedesktop settings portal.
+func findSystemAccentColor() (color.Color, bool) {
+	conn, err := dbus.SessionBus() // shared connection, don't close
+	if err != nil {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), accentLookupTimeout)
+	defer cancel()
+	obj := conn.Object("org.freedesktop.portal.Desktop", "/org/freedesktop/portal/desktop")
+	call := obj.CallWithContext(ctx, "org.freedesktop.portal.Settings.ReadOne", dbus.FlagNoAutoStart,
+		"org.freedesktop.appearance", "accent-color")
+	if call.Err != nil {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		// older portals only provide the deprecated Read method
+		call = obj.CallWithContext(ctx, "org.freedesktop.portal.Settings.Read", dbus.FlagNoAutoStart,
+			"org.freedesktop.appearance", "accent-color")
+		if call.Err != nil {
+			// many desktops don't have this exported yet
+			return nil, false
+		}
+	}
+
+	var value dbus.Variant
+	if err = call.Store(&value); err != nil {
+		return nil, false
+	}
+	inner := value.Value()
+	for {
+		v, ok := inner.(dbus.Variant)
+		if !ok {
+			break
+		}
+		inner = v.Value()
+	}
+
+	// See: https://flatpak.github.io/xdg-desktop-portal/docs/doc-org.freedesktop.portal.Settings.html
+	// The accent is an RGB triplet of values between 0 and 1, values out of range mean it is not set.
+	rgb, ok := inner.([]interface{})
+	if !ok || len(rgb) != 3 {
+		return nil, false
+	}
+	var channels [3]uint8
+	for i, c := range rgb {
+		f, ok := c.(float64)
+		if !ok || f < 0 || f > 1 {
+			return nil, false
+		}
+		channels[i] = uint8(f*0xff + .5)
+	}
+	return color.NRGBA{R: channels[0], G: channels[1], B: channels[2], A: 0xff}, true
+}
@@ -31,6 +31,11 @@ var adwaitaIcons = map[fyne.ThemeIconName]fyne.Resource{
 		StaticContent: []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<svg height=\"16px\" viewBox=\"0 0 16 16\" width=\"16px\" xmlns=\"http://www.w3.org/2000/svg\">\n    <path d=\"m 1 11 c 0 -0.265625 0.105469 -0.519531 0.292969 -0.707031 l 6 -6 c 0.390625 -0.390625 1.023437 -0.390625 1.414062 0 l 6 6 c 0.1875 0.1875 0.292969 0.441406 0.292969 0.707031 s -0.105469 0.519531 -0.292969 0.707031 c -0.390625 0.390625 -1.023437 0.390625 -1.414062 0 l -5.292969 -5.292969 l -5.292969 5.292969 c -0.390625 0.390625 -1.023437 0.390625 -1.414062 0 c -0.1875 -0.1875 -0.292969 -0.441406 -0.292969 -0.707031 z m 0 0\" fill=\"#2e3436\"/>\n</svg>\n"),
 	}),
 
+	theme.IconNameBrokenImage: theme.NewThemedResource(&fyne.StaticResource{
+		StaticName:    "image-missing-symbolic.svg",
+		StaticContent: []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<svg height=\"16px\" viewBox=\"0 0 16 16\" width=\"16px\" xmlns=\"http://www.w3.org/2000/svg\">\n    <path d=\"m 3 1 c -1.105469 0 -2 0.894531 -2 2 v 10 c 0 1.105469 0.894531 2 2 2 h 10 c 1.105469 0 2 -0.894531 2 -2 v -10 c 0 -1.105469 -0.894531 -2 -2 -2 z m 0 2 h 10 v 10 h -10 z m 2.707031 1.292969 l -1.414062 1.414062 l 2.292969 2.292969 l -2.292969 2.292969 l 1.414062 1.414062 l 2.292969 -2.292969 l 2.292969 2.292969 l 1.414062 -1.414062 l -2.292969 -2.292969 l 2.292969 -2.292969 l -1.414062 -1.414062 l -2.292969 2.292969 z m 0 0\" fill=\"#2e3436\"/>\n</svg>\n"),
+	}),
+
 	theme.IconNameCancel: theme.NewThemedResource(&fyne.StaticResource{
 		StaticName:    "window-close-symbolic.svg",
 		StaticContent: []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<svg height=\"16px\" viewBox=\"0 0 16 16\" width=\"16px\" xmlns=\"http://www.w3.org/2000/svg\">\n    <path d=\"m 4 4 h 1 h 0.03125 c 0.253906 0.011719 0.511719 0.128906 0.6875 0.3125 l 2.28125 2.28125 l 2.3125 -2.28125 c 0.265625 -0.230469 0.445312 -0.304688 0.6875 -0.3125 h 1 v 1 c 0 0.285156 -0.035156 0.550781 -0.25 0.75 l -2.28125 2.28125 l 2.25 2.25 c 0.1875 0.1875 0.28125 0.453125 0.28125 0.71875 v 1 h -1 c -0.265625 0 -0.53125 -0.09375 -0.71875 -0.28125 l -2.28125 -2.28125 l -2.28125 2.28125 c -0.1875 0.1875 -0.453125 0.28125 -0.71875 0.28125 h -1 v -1 c 0 -0.265625 0.09375 -0.53125 0.28125 -0.71875 l 2.28125 -2.25 l -2.28125 -2.28125 c -0.210938 -0.195312 -0.304688 -0.46875 -0.28125 -0.75 z m 0 0\" fill=\"#2e3436\"/>\n</svg>\n"),
@@ -191,6 +196,11 @@ var adwaitaIcons = map[fyne.ThemeIconName]fyne.Resource{
 		StaticContent: []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<svg height=\"16px\" viewBox=\"0 0 16 16\" width=\"16px\" xmlns=\"http://www.w3.org/2000/svg\">\n    <path d=\"m 8 0 c -4.410156 0 -8 3.589844 -8 8 s 3.589844 8 8 8 s 8 -3.589844 8 -8 s -3.589844 -8 -8 -8 z m 0 2 c 3.332031 0 6 2.667969 6 6 s -2.667969 6 -6 6 s -6 -2.667969 -6 -6 s 2.667969 -6 6 -6 z m 0 1.875 c -0.621094 0 -1.125 0.503906 -1.125 1.125 s 0.503906 1.125 1.125 1.125 s 1.125 -0.503906 1.125 -1.125 s -0.503906 -1.125 -1.125 -1.125 z m -1.523438 3.125 c -0.265624 0.011719 -0.476562 0.230469 -0.476562 0.5 c 0 0.277344 0.222656 0.5 0.5 0.5 h 0.5 v 3 h -0.5 c -0.277344 0 -0.5 0.222656 -0.5 0.5 s 0.222656 0.5 0.5 0.5 h 3 c 0.277344 0 0.5 -0.222656 0.5 -0.5 s -0.222656 -0.5 -0.5 -0.5 h -0.5 v -4 h -2.5 c -0.007812 0 -0.015625 0 -0.023438 0 z m 0 0\" fill=\"#2e3436\"/>\n</svg>\n"),
 	}),
 
+	theme.IconNameHistory: theme.NewThemedResource(&fyne.StaticResource{
+		StaticName:    "document-open-recent-symbolic.svg",
+		StaticContent: []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<svg height=\"16px\" viewBox=\"0 0 16 16\" width=\"16px\" xmlns=\"http://www.w3.org/2000/svg\">\n    <path d=\"m 8 1 c -3.867188 0 -7 3.132812 -7 7 s 3.132812 7 7 7 s 7 -3.132812 7 -7 s -3.132812 -7 -7 -7 z m 0 2 c 2.761719 0 5 2.238281 5 5 s -2.238281 5 -5 5 s -5 -2.238281 -5 -5 s 2.238281 -5 5 -5 z m -1 1 v 4.414062 l 2.292969 2.292969 l 1.414062 -1.414062 l -1.707031 -1.707031 v -3.585938 z m 0 0\" fill=\"#2e3436\"/>\n</svg>\n"),
+	}),
+
 	theme.IconNameHome: theme.NewThemedResource(&fyne.StaticResource{
 		StaticName:    "user-home-symbolic.svg",
 		StaticContent: []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<svg height=\"16px\" viewBox=\"0 0 16 16\" width=\"16px\" xmlns=\"http://www.w3.org/2000/svg\">\n    <path d=\"m 12 16 c 1.660156 0 3 -1.339844 3 -3 v -6 c 0 -0.929688 -0.414062 -1.8125 -1.128906 -2.410156 l -4.460938 -3.71875 c -0.816406 -0.679688 -2.003906 -0.679688 -2.820312 0 l -4.460938 3.71875 c -0.714844 0.597656 -1.128906 1.480468 -1.128906 2.410156 v 6 c 0 1.660156 1.339844 3 3 3 z m -9 -3 v -6 c 0 -0.335938 0.148438 -0.65625 0.410156 -0.871094 l 4.460938 -3.71875 c 0.074218 -0.0625 0.183594 -0.0625 0.257812 0 l 4.460938 3.71875 c 0.261718 0.214844 0.410156 0.535156 0.410156 0.871094 v 6 c 0 0.546875 -0.453125 1 -1 1 h -8 c -0.546875 0 -1 -0.453125 -1 -1 z m 0 0\"/>\n    <path d=\"m 7 8 h 2 c 0.550781 0 1 0.449219 1 1 v 5 c 0 0.550781 -0.449219 1 -1 1 h -2 c -0.550781 0 -1 -0.449219 -1 -1 v -5 c 0 -0.550781 0.449219 -1 1 -1 z m 0 0\"/>\n</svg>\n"),
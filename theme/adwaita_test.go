@@ -0,0 +1,151 @@
+package theme
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdwaitaHighContrast(t *testing.T) {
+	test.NewApp()
+	normal := AdwaitaTheme()
+	contrast := AdwaitaHighContrast()
+
+	// the colors of the high contrast palette replace those of each variant
+	assert.Equal(t, adwaitaHighContrastLightScheme[theme.ColorNameForeground], contrast.Color(theme.ColorNameForeground, theme.VariantLight))
+	assert.Equal(t, adwaitaHighContrastDarkScheme[theme.ColorNameForeground], contrast.Color(theme.ColorNameForeground, theme.VariantDark))
+	assert.Equal(t, adwaitaHighContrastDarkScheme[theme.ColorNameSeparator], contrast.Color(theme.ColorNameSeparator, theme.VariantDark))
+	assert.NotEqual(t, normal.Color(theme.ColorNameSeparator, theme.VariantDark), contrast.Color(theme.ColorNameSeparator, theme.VariantDark))
+
+	// colors that are not in the palette are the usual Adwaita ones
+	assert.Equal(t, normal.Color(theme.ColorNameError, theme.VariantLight), contrast.Color(theme.ColorNameError, theme.VariantLight))
+	assert.Equal(t, normal.Color(theme.ColorNameShadow, theme.VariantDark), contrast.Color(theme.ColorNameShadow, theme.VariantDark))
+
+	// borders and separators are thicker, other sizes are unchanged
+	assert.Equal(t, float32(2), contrast.Size(theme.SizeNameSeparatorThickness))
+	assert.Equal(t, float32(2), contrast.Size(theme.SizeNameInputBorder))
+	assert.Equal(t, normal.Size(theme.SizeNamePadding), contrast.Size(theme.SizeNamePadding))
+	assert.Equal(t, normal.Size(theme.SizeNameText), contrast.Size(theme.SizeNameText))
+}
+
+func TestAdwaita_CompactAndCornerRadius(t *testing.T) {
+	test.NewApp()
+	a := AdwaitaTheme().(*Adwaita)
+	padding := theme.DefaultTheme().Size(theme.SizeNamePadding)
+	radius := theme.DefaultTheme().Size(theme.SizeNameInputRadius)
+	assert.Equal(t, padding, a.Size(theme.SizeNamePadding))
+	assert.Equal(t, radius, a.Size(theme.SizeNameInputRadius))
+
+	// a compact theme halves the padding and spacing
+	a.SetCompact(true)
+	assert.Equal(t, padding/2, a.Size(theme.SizeNamePadding))
+	assert.Equal(t, theme.DefaultTheme().Size(theme.SizeNameInnerPadding)/2, a.Size(theme.SizeNameInnerPadding))
+	assert.Equal(t, theme.DefaultTheme().Size(theme.SizeNameLineSpacing)/2, a.Size(theme.SizeNameLineSpacing))
+	assert.Equal(t, theme.DefaultTheme().Size(theme.SizeNameText), a.Size(theme.SizeNameText))
+	a.SetCompact(false)
+	assert.Equal(t, padding, a.Size(theme.SizeNamePadding))
+
+	// the corner radius applies to inputs and selections, and 0 gives square corners
+	a.SetCornerRadius(8)
+	assert.Equal(t, float32(8), a.Size(theme.SizeNameInputRadius))
+	assert.Equal(t, float32(8), a.Size(theme.SizeNameSelectionRadius))
+	assert.Equal(t, theme.DefaultTheme().Size(theme.SizeNameScrollBar), a.Size(theme.SizeNameScrollBar))
+	a.SetCornerRadius(0)
+	assert.Equal(t, float32(0), a.Size(theme.SizeNameInputRadius))
+	assert.Equal(t, float32(0), a.Size(theme.SizeNameSelectionRadius))
+}
+
+func TestNewAdwaitaWithOverrides(t *testing.T) {
+	test.NewApp()
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	green := color.NRGBA{G: 0xff, A: 0xff}
+	normal := AdwaitaTheme()
+	a := NewAdwaitaWithOverrides(map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameBackground: red,
+		theme.ColorNamePrimary:    green,
+	}).(*Adwaita)
+
+	// the overrides apply to both variants, and take precedence over the accent color
+	assert.Equal(t, red, a.Color(theme.ColorNameBackground, theme.VariantLight))
+	assert.Equal(t, red, a.Color(theme.ColorNameBackground, theme.VariantDark))
+	a.SetAccentColor(red)
+	assert.Equal(t, green, a.Color(theme.ColorNamePrimary, theme.VariantDark))
+
+	// other colors follow the variant as usual
+	assert.Equal(t, normal.Color(theme.ColorNameForeground, theme.VariantLight), a.Color(theme.ColorNameForeground, theme.VariantLight))
+	assert.Equal(t, normal.Color(theme.ColorNameForeground, theme.VariantDark), a.Color(theme.ColorNameForeground, theme.VariantDark))
+	assert.Equal(t, normal.Color(theme.ColorNameError, theme.VariantDark), a.Color(theme.ColorNameError, theme.VariantDark))
+}
+
+func TestAdwaita_SetSizeOverrides(t *testing.T) {
+	test.NewApp()
+	a := AdwaitaHighContrast().(*Adwaita)
+	a.SetCompact(true)
+	a.SetCornerRadius(8)
+	a.SetSizeOverrides(map[fyne.ThemeSizeName]float32{
+		theme.SizeNameText:               20,
+		theme.SizeNamePadding:            6,
+		theme.SizeNameInputRadius:        3,
+		theme.SizeNameSeparatorThickness: 4,
+	})
+
+	// the overrides take precedence over the compact density, corner radius and high contrast settings
+	assert.Equal(t, float32(20), a.Size(theme.SizeNameText))
+	assert.Equal(t, float32(6), a.Size(theme.SizeNamePadding))
+	assert.Equal(t, float32(3), a.Size(theme.SizeNameInputRadius))
+	assert.Equal(t, float32(4), a.Size(theme.SizeNameSeparatorThickness))
+
+	// other sizes follow the settings as usual
+	assert.Equal(t, theme.DefaultTheme().Size(theme.SizeNameInnerPadding)/2, a.Size(theme.SizeNameInnerPadding))
+	assert.Equal(t, float32(8), a.Size(theme.SizeNameSelectionRadius))
+	assert.Equal(t, float32(2), a.Size(theme.SizeNameInputBorder))
+	assert.Equal(t, theme.DefaultTheme().Size(theme.SizeNameScrollBar), a.Size(theme.SizeNameScrollBar))
+
+	// removing the overrides restores the settings
+	a.SetSizeOverrides(nil)
+	assert.Equal(t, theme.DefaultTheme().Size(theme.SizeNameText), a.Size(theme.SizeNameText))
+	assert.Equal(t, theme.DefaultTheme().Size(theme.SizeNamePadding)/2, a.Size(theme.SizeNamePadding))
+	assert.Equal(t, float32(8), a.Size(theme.SizeNameInputRadius))
+	assert.Equal(t, float32(2), a.Size(theme.SizeNameSeparatorThickness))
+}
+
+func TestAdwaita_Icon(t *testing.T) {
+	test.NewApp()
+	a := AdwaitaTheme()
+
+	for _, name := range []fyne.ThemeIconName{theme.IconNameHistory, theme.IconNameBrokenImage, theme.IconNameHome} {
+		icon := a.Icon(name)
+		assert.Equal(t, adwaitaIcons[name], icon, string(name))
+		assert.NotEqual(t, theme.DefaultTheme().Icon(name).Name(), icon.Name(), string(name))
+	}
+	assert.Equal(t, "foreground_document-open-recent-symbolic.svg", a.Icon(theme.IconNameHistory).Name())
+	assert.Equal(t, "foreground_image-missing-symbolic.svg", a.Icon(theme.IconNameBrokenImage).Name())
+
+	// icons without an Adwaita equivalent fall back to the default theme
+	assert.Equal(t, theme.DefaultTheme().Icon(theme.IconNameLogin).Name(), a.Icon(theme.IconNameLogin).Name())
+}
+
+func TestAdwaitaHighContrast_Primary(t *testing.T) {
+	test.NewApp()
+	readSystemAccent()
+	defer func(accent color.Color) {
+		systemAccent = accent
+	}(systemAccent)
+	orange := color.NRGBA{R: 0xe6, G: 0x61, A: 0xff}
+	systemAccent = orange
+
+	// the standard theme follows the accent of the desktop, the high contrast one keeps its own
+	assert.Equal(t, orange, AdwaitaTheme().Color(theme.ColorNamePrimary, theme.VariantLight))
+	contrast := AdwaitaHighContrast().(*Adwaita)
+	assert.Equal(t, adwaitaHighContrastLightScheme[theme.ColorNamePrimary], contrast.Color(theme.ColorNamePrimary, theme.VariantLight))
+	assert.Equal(t, adwaitaHighContrastDarkScheme[theme.ColorNamePrimary], contrast.Color(theme.ColorNamePrimary, theme.VariantDark))
+
+	// an accent set by the application still applies
+	green := color.NRGBA{G: 0x80, A: 0xff}
+	contrast.SetAccentColor(green)
+	assert.Equal(t, green, contrast.Color(theme.ColorNamePrimary, theme.VariantDark))
+}
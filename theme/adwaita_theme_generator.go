@@ -193,6 +193,7 @@ var (
 		"IconNameMailReplyAll":   "symbolic/actions/mail-reply-all-symbolic.svg",
 		"IconNameMailSend":       "symbolic/actions/mail-send-symbolic.svg",
 
+		"IconNameBrokenImage":       "symbolic/status/image-missing-symbolic.svg",
 		"IconNameMediaMusic":        "symbolic/mimetypes/audio-x-generic-symbolic.svg",
 		"IconNameMediaPhoto":        "symbolic/mimetypes/image-x-generic-symbolic.svg",
 		"IconNameMediaVideo":        "symbolic/mimetypes/video-x-generic-symbolic.svg",
@@ -223,7 +224,7 @@ var (
 		"IconNameFolderNew":       "symbolic/actions/folder-new-symbolic.svg",
 		"IconNameFolderOpen":      "symbolic/status/folder-open-symbolic.svg",
 		"IconNameHelp":            "symbolic/actions/help-about-symbolic.svg",
-		"IconNameHistory":         "",
+		"IconNameHistory":         "symbolic/actions/document-open-recent-symbolic.svg",
 		"IconNameHome":            "symbolic/places/user-home-symbolic.svg",
 		"IconNameSettings":        "symbolic/categories/applications-system-symbolic.svg",
 
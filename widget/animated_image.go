@@ -0,0 +1,386 @@
+package widget
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"golang.org/x/image/webp"
+)
+
+var (
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+	errUnknownFormat = errors.New("unsupported animated image format")
+	errInvalidAPNG   = errors.New("invalid animated PNG")
+	errInvalidWebP   = errors.New("invalid animated WebP")
+)
+
+// animation holds the fully composed frames of an animated image format other than GIF.
+type animation struct {
+	frames    []image.Image
+	delays    []time.Duration
+	loopCount int // 0 plays forever
+}
+
+// NewAnimatedImage creates a new widget loaded to show the specified animated image.
+// The format is detected from the content, GIF, animated PNG (APNG) and animated WebP are supported.
+// Still PNG and WebP images are shown as a single frame.
+// If there is an error loading the image it will be returned in the error value.
+func NewAnimatedImage(u fyne.URI) (*AnimatedGif, error) {
+	ret := newGif()
+
+	read, err := storage.Reader(u)
+	if err != nil {
+		return ret, err
+	}
+	defer read.Close()
+
+	buf := bufio.NewReader(read)
+	header, err := buf.Peek(12)
+	if err != nil {
+		return ret, err
+	}
+
+	var anim *animation
+	switch {
+	case bytes.HasPrefix(header, []byte("GIF8")):
+		return ret, ret.load(buf)
+	case bytes.HasPrefix(header, pngSignature):
+		anim, err = decodeAPNG(buf)
+	case bytes.HasPrefix(header, []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WEBP")):
+		anim, err = decodeAnimatedWebP(buf)
+	default:
+		err = errUnknownFormat
+	}
+	if err != nil {
+		return ret, err
+	}
+
+	ret.loadAnimation(anim)
+	return ret, nil
+}
+
+type pngChunk struct {
+	kind string
+	data []byte
+}
+
+type apngFrame struct {
+	width, height int
+	x, y          int
+	delay         time.Duration
+	dispose       byte
+	blend         byte
+	data          [][]byte
+}
+
+const (
+	apngDisposeBackground = 1
+	apngDisposePrevious   = 2
+	apngBlendOver         = 1
+)
+
+func decodeAPNG(r io.Reader) (*animation, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	raw := data
+	data = data[len(pngSignature):]
+
+	var (
+		ihdr               []byte
+		shared             []pngChunk
+		frames             []*apngFrame
+		frame              *apngFrame
+		plays              int
+		seenIDAT, animated bool
+	)
+	for len(data) >= 12 {
+		length := int(binary.BigEndian.Uint32(data))
+		if len(data) < length+12 {
+			return nil, errInvalidAPNG
+		}
+		kind := string(data[4:8])
+		body := data[8 : 8+length]
+		data = data[length+12:]
+
+		switch kind {
+		case "IHDR":
+			ihdr = body
+		case "acTL":
+			if len(body) < 8 {
+				return nil, errInvalidAPNG
+			}
+			animated = true
+			plays = int(binary.BigEndian.Uint32(body[4:]))
+		case "fcTL":
+			if len(body) < 26 {
+				return nil, errInvalidAPNG
+			}
+			frame = parseFrameControl(body)
+			frames = append(frames, frame)
+		case "IDAT":
+			seenIDAT = true
+			// the default image is only part of the animation if a frame control came first
+			if frame != nil {
+				frame.data = append(frame.data, body)
+			}
+		case "fdAT":
+			if frame == nil || len(body) < 4 {
+				return nil, errInvalidAPNG
+			}
+			frame.data = append(frame.data, body[4:])
+		case "IEND":
+		default:
+			if !seenIDAT {
+				shared = append(shared, pngChunk{kind: kind, data: body})
+			}
+		}
+	}
+	if len(ihdr) < 13 {
+		return nil, errInvalidAPNG
+	}
+
+	width, height := int(binary.BigEndian.Uint32(ihdr)), int(binary.BigEndian.Uint32(ihdr[4:]))
+	if !animated || len(frames) == 0 {
+		img, err := png.Decode(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		return &animation{frames: []image.Image{img}, delays: []time.Duration{0}, loopCount: 1}, nil
+	}
+
+	canvas := image.NewNRGBA(image.Rect(0, 0, width, height))
+	anim := &animation{loopCount: plays}
+	for i, f := range frames {
+		img, err := png.Decode(bytes.NewReader(rebuildPNG(ihdr, f.width, f.height, shared, f.data)))
+		if err != nil {
+			return nil, err
+		}
+
+		area := image.Rect(f.x, f.y, f.x+f.width, f.y+f.height)
+		var previous *image.NRGBA
+		if f.dispose == apngDisposePrevious && i > 0 {
+			previous = image.NewNRGBA(area)
+			draw.Draw(previous, area, canvas, area.Min, draw.Src)
+		}
+
+		op := draw.Src
+		if f.blend == apngBlendOver {
+			op = draw.Over
+		}
+		draw.Draw(canvas, area, img, image.Point{}, op)
+		anim.frames = append(anim.frames, cloneNRGBA(canvas))
+		anim.delays = append(anim.delays, f.delay)
+
+		switch {
+		case f.dispose == apngDisposeBackground, f.dispose == apngDisposePrevious && previous == nil:
+			draw.Draw(canvas, area, image.Transparent, image.Point{}, draw.Src)
+		case f.dispose == apngDisposePrevious:
+			draw.Draw(canvas, area, previous, area.Min, draw.Src)
+		}
+	}
+	return anim, nil
+}
+
+func parseFrameControl(body []byte) *apngFrame {
+	num, den := binary.BigEndian.Uint16(body[20:]), binary.BigEndian.Uint16(body[22:])
+	if den == 0 {
+		den = 100
+	}
+	return &apngFrame{
+		width:   int(binary.BigEndian.Uint32(body[4:])),
+		height:  int(binary.BigEndian.Uint32(body[8:])),
+		x:       int(binary.BigEndian.Uint32(body[12:])),
+		y:       int(binary.BigEndian.Uint32(body[16:])),
+		delay:   time.Second * time.Duration(num) / time.Duration(den),
+		dispose: body[24],
+		blend:   body[25],
+	}
+}
+
+// rebuildPNG creates a standalone PNG stream for a single frame so it can be decoded by image/png.
+func rebuildPNG(ihdr []byte, width, height int, shared []pngChunk, data [][]byte) []byte {
+	var out bytes.Buffer
+	out.Write(pngSignature)
+
+	header := append([]byte{}, ihdr...)
+	binary.BigEndian.PutUint32(header, uint32(width))
+	binary.BigEndian.PutUint32(header[4:], uint32(height))
+	writePNGChunk(&out, "IHDR", header)
+	for _, c := range shared {
+		writePNGChunk(&out, c.kind, c.data)
+	}
+	for _, d := range data {
+		writePNGChunk(&out, "IDAT", d)
+	}
+	writePNGChunk(&out, "IEND", nil)
+	return out.Bytes()
+}
+
+func writePNGChunk(w *bytes.Buffer, kind string, data []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	w.Write(length[:])
+
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(kind))
+	crc.Write(data)
+	w.WriteString(kind)
+	w.Write(data)
+
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	w.Write(sum[:])
+}
+
+const (
+	webpDisposeBackground = 1 << 0
+	webpNoBlend           = 1 << 1
+)
+
+func decodeAnimatedWebP(r io.Reader) (*animation, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 12 {
+		return nil, errInvalidWebP
+	}
+
+	var (
+		width, height int
+		anim          *animation
+		canvas        *image.NRGBA
+	)
+	for chunks := data[12:]; len(chunks) >= 8; {
+		kind := string(chunks[:4])
+		length := int(binary.LittleEndian.Uint32(chunks[4:]))
+		if len(chunks) < 8+length {
+			return nil, errInvalidWebP
+		}
+		body := chunks[8 : 8+length]
+		chunks = chunks[8+length:]
+		if length%2 == 1 && len(chunks) > 0 {
+			chunks = chunks[1:]
+		}
+
+		switch kind {
+		case "VP8X":
+			if len(body) < 10 {
+				return nil, errInvalidWebP
+			}
+			width, height = readUint24(body[4:])+1, readUint24(body[7:])+1
+		case "ANIM":
+			if len(body) < 6 {
+				return nil, errInvalidWebP
+			}
+			anim = &animation{loopCount: int(binary.LittleEndian.Uint16(body[4:]))}
+			canvas = image.NewNRGBA(image.Rect(0, 0, width, height))
+		case "ANMF":
+			if anim == nil || len(body) < 16 {
+				return nil, errInvalidWebP
+			}
+			if err := decodeWebPFrame(anim, canvas, body); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if anim == nil {
+		// not animated, decode as a still image
+		img, err := webp.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return &animation{frames: []image.Image{img}, delays: []time.Duration{0}, loopCount: 1}, nil
+	}
+	if len(anim.frames) == 0 {
+		return nil, errInvalidWebP
+	}
+	return anim, nil
+}
+
+func decodeWebPFrame(anim *animation, canvas *image.NRGBA, body []byte) error {
+	x, y := readUint24(body)*2, readUint24(body[3:])*2
+	w, h := readUint24(body[6:])+1, readUint24(body[9:])+1
+	delay := time.Millisecond * time.Duration(readUint24(body[12:]))
+	flags := body[15]
+
+	img, err := webp.Decode(bytes.NewReader(wrapWebPFrame(body[16:], w, h)))
+	if err != nil {
+		return err
+	}
+
+	area := image.Rect(x, y, x+w, y+h)
+	op := draw.Over
+	if flags&webpNoBlend != 0 {
+		op = draw.Src
+	}
+	draw.Draw(canvas, area, img, image.Point{}, op)
+	anim.frames = append(anim.frames, cloneNRGBA(canvas))
+	anim.delays = append(anim.delays, delay)
+
+	if flags&webpDisposeBackground != 0 {
+		draw.Draw(canvas, area, image.Transparent, image.Point{}, draw.Src)
+	}
+	return nil
+}
+
+// wrapWebPFrame creates a standalone WebP stream from the content of an ANMF chunk so it can be decoded.
+func wrapWebPFrame(frame []byte, width, height int) []byte {
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+	if bytes.HasPrefix(frame, []byte("ALPH")) {
+		header := make([]byte, 10)
+		header[0] = 0x10 // alpha flag
+		putUint24(header[4:], width-1)
+		putUint24(header[7:], height-1)
+		writeWebPChunk(&body, "VP8X", header)
+	}
+	body.Write(frame)
+
+	var out bytes.Buffer
+	out.WriteString("RIFF")
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(body.Len()))
+	out.Write(size[:])
+	out.Write(body.Bytes())
+	return out.Bytes()
+}
+
+func writeWebPChunk(w *bytes.Buffer, kind string, data []byte) {
+	w.WriteString(kind)
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(data)))
+	w.Write(size[:])
+	w.Write(data)
+	if len(data)%2 == 1 {
+		w.WriteByte(0)
+	}
+}
+
+func cloneNRGBA(src *image.NRGBA) *image.NRGBA {
+	dst := image.NewNRGBA(src.Bounds())
+	copy(dst.Pix, src.Pix)
+	return dst
+}
+
+func readUint24(b []byte) int {
+	return int(b[0]) | int(b[1])<<8 | int(b[2])<<16
+}
+
+func putUint24(b []byte, v int) {
+	b[0], b[1], b[2] = byte(v), byte(v>>8), byte(v>>16)
+}
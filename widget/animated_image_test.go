@@ -0,0 +1,118 @@
+package widget
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2/storage"
+)
+
+func TestNewAnimatedImage_GIF(t *testing.T) {
+	gif, err := NewAnimatedImage(storage.NewFileURI("./testdata/gif/earth.gif"))
+	assert.Nil(t, err)
+	assert.NotNil(t, gif.src)
+	assert.Nil(t, gif.frames)
+}
+
+func TestNewAnimatedImage_APNG(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apng")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.png")
+	assert.Nil(t, ioutil.WriteFile(path, createAPNG(t), 0644))
+
+	anim, err := NewAnimatedImage(storage.NewFileURI(path))
+	assert.Nil(t, err)
+	assert.Nil(t, anim.src)
+	assert.Equal(t, 2, anim.frameCount())
+	assert.Equal(t, 3, anim.frames.loopCount)
+	assert.Equal(t, 100*time.Millisecond, anim.frameDelay(0))
+	assert.Equal(t, 50*time.Millisecond, anim.frameDelay(1))
+
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+	first := anim.frames.frames[0]
+	assert.Equal(t, red, first.At(0, 0))
+	assert.Equal(t, red, first.At(3, 3))
+	second := anim.frames.frames[1]
+	assert.Equal(t, red, second.At(0, 0))
+	assert.Equal(t, blue, second.At(3, 3))
+}
+
+func TestNewAnimatedImage_Unknown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "anim")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.txt")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("not an image file"), 0644))
+
+	_, err = NewAnimatedImage(storage.NewFileURI(path))
+	assert.Equal(t, errUnknownFormat, err)
+}
+
+// createAPNG returns a 4x4 animation of a red frame followed by a 2x2 blue frame in the bottom right corner.
+func createAPNG(t *testing.T) []byte {
+	full := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := 0; i < len(full.Pix); i += 4 {
+		full.Pix[i], full.Pix[i+3] = 0xff, 0xff
+	}
+	part := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for i := 0; i < len(part.Pix); i += 4 {
+		part.Pix[i+2], part.Pix[i+3] = 0xff, 0xff
+	}
+
+	ihdr, firstData := encodePNGChunks(t, full)
+	_, secondData := encodePNGChunks(t, part)
+
+	var out bytes.Buffer
+	out.Write(pngSignature)
+	writePNGChunk(&out, "IHDR", ihdr)
+	writePNGChunk(&out, "acTL", []byte{0, 0, 0, 2, 0, 0, 0, 3})
+	writePNGChunk(&out, "fcTL", frameControl(0, 4, 4, 0, 0, 1, 10))
+	writePNGChunk(&out, "IDAT", firstData)
+	writePNGChunk(&out, "fcTL", frameControl(1, 2, 2, 2, 2, 1, 20))
+	writePNGChunk(&out, "fdAT", append([]byte{0, 0, 0, 2}, secondData...))
+	writePNGChunk(&out, "IEND", nil)
+	return out.Bytes()
+}
+
+func encodePNGChunks(t *testing.T, img image.Image) (ihdr, idat []byte) {
+	var buf bytes.Buffer
+	assert.Nil(t, png.Encode(&buf, img))
+
+	data := buf.Bytes()[len(pngSignature):]
+	for len(data) >= 12 {
+		length := binary.BigEndian.Uint32(data)
+		switch string(data[4:8]) {
+		case "IHDR":
+			ihdr = data[8 : 8+length]
+		case "IDAT":
+			idat = append(idat, data[8:8+length]...)
+		}
+		data = data[12+length:]
+	}
+	return ihdr, idat
+}
+
+func frameControl(seq, w, h, x, y uint32, num, den uint16) []byte {
+	body := make([]byte, 26)
+	binary.BigEndian.PutUint32(body, seq)
+	binary.BigEndian.PutUint32(body[4:], w)
+	binary.BigEndian.PutUint32(body[8:], h)
+	binary.BigEndian.PutUint32(body[12:], x)
+	binary.BigEndian.PutUint32(body[16:], y)
+	binary.BigEndian.PutUint16(body[20:], num)
+	binary.BigEndian.PutUint16(body[22:], den)
+	body[25] = apngBlendOver
+	return body
+}
@@ -1,12 +1,14 @@
 package widget
 
 import (
+	"image/color"
 	"math"
 	"strconv"
 	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
@@ -15,14 +17,22 @@ import (
 
 // Declare conformity with Layout interface
 var _ fyne.Layout = (*calendarLayout)(nil)
+var _ fyne.Layout = (*calendarDotsLayout)(nil)
+
+// Declare conformity with Focusable interface
+var _ fyne.Focusable = (*Calendar)(nil)
 
 const (
 	daysPerWeek      = 7
 	maxWeeksPerMonth = 6
+
+	calendarDotSize float32 = 5
 )
 
 type calendarLayout struct {
 	cellSize fyne.Size
+	// decorated is set when the cells need room for the day decorations beneath the day number
+	decorated bool
 }
 
 func newCalendarLayout() fyne.Layout {
@@ -89,10 +99,32 @@ func (g *calendarLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 func (g *calendarLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
 	pad := theme.Padding()
 	largestMin := widget.NewLabel("22").MinSize()
+	if g.decorated {
+		largestMin.Height += calendarDotSize
+	}
 	return fyne.NewSize(largestMin.Width*daysPerWeek+pad*(daysPerWeek-1),
 		largestMin.Height*maxWeeksPerMonth+pad*(maxWeeksPerMonth-1))
 }
 
+// calendarDotsLayout places a row of dots centered along the bottom of a calendar cell
+type calendarDotsLayout struct{}
+
+func (d *calendarDotsLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	gap := calendarDotSize / 2
+	width := float32(len(objects))*(calendarDotSize+gap) - gap
+	x := (size.Width - width) / 2
+	y := size.Height - calendarDotSize - theme.Padding()
+	for _, dot := range objects {
+		dot.Move(fyne.NewPos(x, y))
+		dot.Resize(fyne.NewSize(calendarDotSize, calendarDotSize))
+		x += calendarDotSize + gap
+	}
+}
+
+func (d *calendarDotsLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
 // Calendar creates a new date time picker which returns a time object
 type Calendar struct {
 	widget.BaseWidget
@@ -102,9 +134,47 @@ type Calendar struct {
 	monthNext     *widget.Button
 	monthLabel    *widget.Label
 
-	dates *fyne.Container
+	dates       *fyne.Container
+	decorations *fyne.Container
+	marks       *fyne.Container
+	focus       *fyne.Container
+
+	// focusedDay is the day of the month that is outlined while the calendar has the keyboard focus
+	focusedDay int
+	focused    bool
+
+	onSelected   func(time.Time)
+	dayDecorator func(time.Time) []color.Color
+	markedDates  map[time.Time]bool
+	markColor    color.Color
+}
+
+// SetMarkedDates sets the days of the month that are highlighted with the color behind the day number,
+// such as deadlines. Only the year, month and day of the dates are used. Marking is purely visual,
+// the days can still be tapped and selected. Passing no dates removes the highlights.
+func (c *Calendar) SetMarkedDates(dates []time.Time, col color.Color) {
+	c.markedDates = make(map[time.Time]bool, len(dates))
+	for _, date := range dates {
+		c.markedDates[dayOf(date)] = true
+	}
+	c.markColor = col
+	if c.dates == nil {
+		return
+	}
+
+	c.updateDates()
+}
+
+// SetDayDecorator sets the function used to decorate the days of the month. It returns the colors of the dots
+// that are drawn beneath the day number, which may be none. Passing nil removes the decorations.
+func (c *Calendar) SetDayDecorator(decorator func(time.Time) []color.Color) {
+	c.dayDecorator = decorator
+	if c.dates == nil {
+		return
+	}
 
-	onSelected func(time.Time)
+	c.dates.Layout.(*calendarLayout).decorated = decorator != nil
+	c.updateDates()
 }
 
 func (c *Calendar) daysOfMonth() []fyne.CanvasObject {
@@ -140,6 +210,56 @@ func (c *Calendar) daysOfMonth() []fyne.CanvasObject {
 	return buttons
 }
 
+// dayDecorations returns an object for each one returned by calendarObjects, holding the dots for the days
+func (c *Calendar) dayDecorations() []fyne.CanvasObject {
+	start := time.Date(c.currentTime.Year(), c.currentTime.Month(), 1, 0, 0, 0, 0, c.currentTime.Location())
+	cells := leadingCells(start)
+	for d := start; d.Month() == start.Month(); d = d.AddDate(0, 0, 1) {
+		dots := container.New(&calendarDotsLayout{})
+		if c.dayDecorator != nil {
+			for _, col := range c.dayDecorator(c.dateForButton(d.Day())) {
+				dots.Add(canvas.NewCircle(col))
+			}
+		}
+		cells = append(cells, dots)
+	}
+
+	return cells
+}
+
+// dayMarks returns an object for each one returned by calendarObjects, holding the highlight of marked days
+func (c *Calendar) dayMarks() []fyne.CanvasObject {
+	start := time.Date(c.currentTime.Year(), c.currentTime.Month(), 1, 0, 0, 0, 0, c.currentTime.Location())
+	cells := leadingCells(start)
+	for d := start; d.Month() == start.Month(); d = d.AddDate(0, 0, 1) {
+		mark := canvas.NewRectangle(c.markColor)
+		mark.CornerRadius = theme.InputRadiusSize()
+		if !c.markedDates[dayOf(d)] {
+			mark.Hide()
+		}
+		cells = append(cells, mark)
+	}
+
+	return cells
+}
+
+// dayFocus returns an object for each one returned by calendarObjects, outlining the focused day
+func (c *Calendar) dayFocus() []fyne.CanvasObject {
+	start := time.Date(c.currentTime.Year(), c.currentTime.Month(), 1, 0, 0, 0, 0, c.currentTime.Location())
+	cells := leadingCells(start)
+	for d := start; d.Month() == start.Month(); d = d.AddDate(0, 0, 1) {
+		outline := canvas.NewRectangle(color.Transparent)
+		outline.CornerRadius = theme.InputRadiusSize()
+		if c.focused && d.Day() == c.focusedDay {
+			outline.StrokeColor = theme.FocusColor()
+			outline.StrokeWidth = theme.InputBorderSize() * 2
+		}
+		cells = append(cells, outline)
+	}
+
+	return cells
+}
+
 func (c *Calendar) dateForButton(dayNum int) time.Time {
 	oldName, off := c.currentTime.Zone()
 	return time.Date(c.currentTime.Year(), c.currentTime.Month(), dayNum, c.currentTime.Hour(), c.currentTime.Minute(), 0, 0, time.FixedZone(oldName, off)).In(c.currentTime.Location())
@@ -174,14 +294,14 @@ func (c *Calendar) CreateRenderer() fyne.WidgetRenderer {
 		// Dates are 'normalised', forcing date to start from the start of the month ensures move from March to February
 		c.currentTime = time.Date(c.currentTime.Year(), c.currentTime.Month(), 1, 0, 0, 0, 0, c.currentTime.Location())
 		c.monthLabel.SetText(c.monthYear())
-		c.dates.Objects = c.calendarObjects()
+		c.updateDates()
 	})
 	c.monthPrevious.Importance = widget.LowImportance
 
 	c.monthNext = widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() {
 		c.currentTime = c.currentTime.AddDate(0, 1, 0)
 		c.monthLabel.SetText(c.monthYear())
-		c.dates.Objects = c.calendarObjects()
+		c.updateDates()
 	})
 	c.monthNext.Importance = widget.LowImportance
 
@@ -191,12 +311,133 @@ func (c *Calendar) CreateRenderer() fyne.WidgetRenderer {
 		c.monthPrevious, c.monthNext, container.NewCenter(c.monthLabel))
 
 	c.dates = container.New(newCalendarLayout(), c.calendarObjects()...)
+	c.dates.Layout.(*calendarLayout).decorated = c.dayDecorator != nil
+	c.decorations = container.New(newCalendarLayout(), c.dayDecorations()...)
+	c.marks = container.New(newCalendarLayout(), c.dayMarks()...)
+	c.focus = container.New(newCalendarLayout(), c.dayFocus()...)
 
-	dateContainer := container.NewBorder(nav, nil, nil, nil, c.dates)
+	dateContainer := container.NewBorder(nav, nil, nil, nil, container.NewStack(c.marks, c.dates, c.decorations, c.focus))
 
 	return widget.NewSimpleRenderer(dateContainer)
 }
 
+func (c *Calendar) updateDates() {
+	c.dates.Objects = c.calendarObjects()
+	c.decorations.Objects = c.dayDecorations()
+	c.marks.Objects = c.dayMarks()
+	c.focus.Objects = c.dayFocus()
+	c.dates.Refresh()
+	c.decorations.Refresh()
+	c.marks.Refresh()
+	c.focus.Refresh()
+}
+
+// FocusGained outlines the day that the keyboard moves between, which starts as the day of the current time.
+//
+// Implements: fyne.Focusable
+func (c *Calendar) FocusGained() {
+	c.focused = true
+	if c.focusedDay == 0 {
+		c.focusedDay = c.currentTime.Day()
+	}
+	c.refreshFocus()
+}
+
+// FocusLost removes the outline of the focused day.
+//
+// Implements: fyne.Focusable
+func (c *Calendar) FocusLost() {
+	c.focused = false
+	c.refreshFocus()
+}
+
+// TypedKey moves the focused day with the arrow keys, a day at a time to the left and right and a week at a
+// time up and down, showing the previous or next month when the day is in it. Page Up and Page Down show the
+// previous and next month, and Enter or Return selects the focused day.
+//
+// Implements: fyne.Focusable
+func (c *Calendar) TypedKey(ev *fyne.KeyEvent) {
+	switch ev.Name {
+	case fyne.KeyLeft:
+		c.moveFocus(0, -1)
+	case fyne.KeyRight:
+		c.moveFocus(0, 1)
+	case fyne.KeyUp:
+		c.moveFocus(0, -daysPerWeek)
+	case fyne.KeyDown:
+		c.moveFocus(0, daysPerWeek)
+	case fyne.KeyPageUp:
+		c.moveFocus(-1, 0)
+	case fyne.KeyPageDown:
+		c.moveFocus(1, 0)
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if c.focusedDay > 0 && c.onSelected != nil {
+			c.onSelected(c.dateForButton(c.focusedDay))
+		}
+	}
+}
+
+// TypedRune is ignored, the calendar is navigated with TypedKey.
+//
+// Implements: fyne.Focusable
+func (c *Calendar) TypedRune(_ rune) {
+}
+
+// moveFocus moves the focused day by the number of months and days, showing the month it is in. When moving by
+// months, the day is kept if the month has it and is otherwise the last day of the month.
+func (c *Calendar) moveFocus(months, days int) {
+	if c.focusedDay == 0 {
+		c.focusedDay = c.currentTime.Day()
+	}
+	loc := c.currentTime.Location()
+	target := time.Date(c.currentTime.Year(), c.currentTime.Month()+time.Month(months), 1, 0, 0, 0, 0, loc)
+	lastDay := target.AddDate(0, 1, -1).Day()
+	day := c.focusedDay
+	if day > lastDay {
+		day = lastDay
+	}
+	target = target.AddDate(0, 0, day-1+days)
+
+	if target.Year() != c.currentTime.Year() || target.Month() != c.currentTime.Month() {
+		c.currentTime = time.Date(target.Year(), target.Month(), 1, c.currentTime.Hour(), c.currentTime.Minute(), 0, 0, loc)
+		c.focusedDay = target.Day()
+		if c.monthLabel != nil {
+			c.monthLabel.SetText(c.monthYear())
+			c.updateDates()
+		}
+		return
+	}
+	c.focusedDay = target.Day()
+	c.refreshFocus()
+}
+
+// refreshFocus updates the outline of the focused day
+func (c *Calendar) refreshFocus() {
+	if c.focus == nil {
+		return
+	}
+	c.focus.Objects = c.dayFocus()
+	c.focus.Refresh()
+}
+
+// leadingCells returns spacers for the column headings and the days before the start of the month
+func leadingCells(start time.Time) []fyne.CanvasObject {
+	cells := []fyne.CanvasObject{}
+	dayIndex := int(start.Weekday())
+	if dayIndex == 0 {
+		dayIndex += daysPerWeek
+	}
+	for i := 0; i < daysPerWeek+dayIndex-1; i++ {
+		cells = append(cells, layout.NewSpacer())
+	}
+	return cells
+}
+
+// dayOf returns the date without the time of day, for comparing days
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
 // NewCalendar creates a calendar instance
 func NewCalendar(cT time.Time, onSelected func(time.Time)) *Calendar {
 	c := &Calendar{
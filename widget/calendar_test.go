@@ -1,6 +1,7 @@
 package widget
 
 import (
+	"image/color"
 	"strconv"
 	"testing"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/test"
 	"fyne.io/fyne/v2/widget"
 )
@@ -93,3 +95,106 @@ func firstDateButton(c *fyne.Container) *widget.Button {
 
 	return nil
 }
+
+func TestCalendar_SetDayDecorator(t *testing.T) {
+	date := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+	c := NewCalendar(date, func(time.Time) {})
+	_ = test.WidgetRenderer(c) // and render
+	base := c.MinSize()
+
+	c.SetDayDecorator(func(day time.Time) []color.Color {
+		switch day.Day() {
+		case 1:
+			return []color.Color{color.Black}
+		case 2:
+			return []color.Color{color.Black, color.White}
+		}
+		return nil
+	})
+	assert.Greater(t, c.MinSize().Height, base.Height)
+	assert.Equal(t, len(c.dates.Objects), len(c.decorations.Objects))
+
+	first := len(c.dates.Objects) - 29 // February 2024 has 29 days
+	assert.Len(t, c.decorations.Objects[first].(*fyne.Container).Objects, 1)
+	assert.Len(t, c.decorations.Objects[first+1].(*fyne.Container).Objects, 2)
+	assert.Empty(t, c.decorations.Objects[first+2].(*fyne.Container).Objects)
+
+	test.Tap(c.monthNext)
+	assert.Len(t, c.decorations.Objects[len(c.dates.Objects)-31].(*fyne.Container).Objects, 1)
+}
+
+func TestCalendar_SetMarkedDates(t *testing.T) {
+	date := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+	var selected time.Time
+	c := NewCalendar(date, func(t time.Time) {
+		selected = t
+	})
+	c.SetMarkedDates([]time.Time{
+		time.Date(2024, time.February, 2, 15, 30, 0, 0, time.UTC),
+		time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+	}, color.Black)
+	_ = test.WidgetRenderer(c) // and render
+	assert.Equal(t, len(c.dates.Objects), len(c.marks.Objects))
+
+	first := len(c.dates.Objects) - 29 // February 2024 has 29 days
+	assert.False(t, c.marks.Objects[first].Visible())
+	assert.True(t, c.marks.Objects[first+1].Visible())
+	assert.Equal(t, color.Black, c.marks.Objects[first+1].(*canvas.Rectangle).FillColor)
+
+	// marked days can still be selected
+	test.Tap(c.dates.Objects[first+1].(*widget.Button))
+	assert.Equal(t, 2, selected.Day())
+
+	test.Tap(c.monthNext)
+	first = len(c.dates.Objects) - 31
+	assert.True(t, c.marks.Objects[first].Visible())
+
+	c.SetMarkedDates(nil, nil)
+	assert.False(t, c.marks.Objects[first].Visible())
+}
+
+func TestCalendar_Keyboard(t *testing.T) {
+	date := time.Date(2024, time.January, 30, 10, 15, 0, 0, time.UTC)
+	var selected time.Time
+	c := NewCalendar(date, func(t time.Time) {
+		selected = t
+	})
+	_ = test.WidgetRenderer(c) // and render
+	outline := func(day int) *canvas.Rectangle {
+		daysInMonth := time.Date(c.currentTime.Year(), c.currentTime.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		return c.focus.Objects[len(c.focus.Objects)-daysInMonth+day-1].(*canvas.Rectangle)
+	}
+
+	c.FocusGained()
+	assert.Equal(t, 30, c.focusedDay)
+	assert.NotNil(t, outline(30).StrokeColor)
+	assert.Nil(t, outline(29).StrokeColor)
+
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	assert.Equal(t, 29, c.focusedDay)
+	assert.NotNil(t, outline(29).StrokeColor)
+	assert.Nil(t, outline(30).StrokeColor)
+
+	// moving past the end of the month shows the next one
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	assert.Equal(t, "February 2024", c.monthLabel.Text)
+	assert.Equal(t, 5, c.focusedDay)
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	assert.Equal(t, "January 2024", c.monthLabel.Text)
+	assert.Equal(t, 29, c.focusedDay)
+
+	// paging keeps the day where the month has it
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageDown})
+	assert.Equal(t, "February 2024", c.monthLabel.Text)
+	assert.Equal(t, 29, c.focusedDay)
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageUp})
+	assert.Equal(t, "February 2024", c.monthLabel.Text)
+	assert.Equal(t, 1, c.focusedDay)
+
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, time.Date(2024, time.February, 1, 10, 15, 0, 0, time.UTC), selected.UTC())
+
+	c.FocusLost()
+	assert.Nil(t, outline(1).StrokeColor)
+}
@@ -0,0 +1,92 @@
+package widget
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// completionChip shows a value committed in a multi-value CompletionEntry, with an icon to remove it.
+type completionChip struct {
+	widget.BaseWidget
+
+	text     string
+	onRemove func()
+}
+
+func newCompletionChip(text string, onRemove func()) *completionChip {
+	c := &completionChip{text: text, onRemove: onRemove}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *completionChip) CreateRenderer() fyne.WidgetRenderer {
+	bg := canvas.NewRectangle(theme.HoverColor())
+	bg.CornerRadius = theme.InputRadiusSize()
+	text := canvas.NewText(c.text, theme.ForegroundColor())
+	text.TextSize = theme.CaptionTextSize()
+	remove := newCompletionChipRemove(c.onRemove)
+	return &completionChipRenderer{chip: c, bg: bg, text: text, remove: remove}
+}
+
+type completionChipRenderer struct {
+	chip   *completionChip
+	bg     *canvas.Rectangle
+	text   *canvas.Text
+	remove *completionChipRemove
+}
+
+func (r *completionChipRenderer) Destroy() {
+}
+
+func (r *completionChipRenderer) Layout(size fyne.Size) {
+	pad := theme.Padding()
+	r.bg.Resize(size)
+	textSize := r.text.MinSize()
+	r.text.Move(fyne.NewPos(pad*2, (size.Height-textSize.Height)/2))
+	r.text.Resize(textSize)
+	iconSize := theme.CaptionTextSize()
+	r.remove.Move(fyne.NewPos(size.Width-pad-iconSize, (size.Height-iconSize)/2))
+	r.remove.Resize(fyne.NewSize(iconSize, iconSize))
+}
+
+func (r *completionChipRenderer) MinSize() fyne.Size {
+	pad := theme.Padding()
+	textSize := r.text.MinSize()
+	return fyne.NewSize(textSize.Width+theme.CaptionTextSize()+pad*4, textSize.Height+pad)
+}
+
+func (r *completionChipRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.bg, r.text, r.remove}
+}
+
+func (r *completionChipRenderer) Refresh() {
+	r.bg.FillColor = theme.HoverColor()
+	r.bg.CornerRadius = theme.InputRadiusSize()
+	r.text.Text = r.chip.text
+	r.text.Color = theme.ForegroundColor()
+	r.text.TextSize = theme.CaptionTextSize()
+	r.bg.Refresh()
+	r.text.Refresh()
+	r.remove.Refresh()
+}
+
+// completionChipRemove is a tappable icon that removes the chip it belongs to.
+type completionChipRemove struct {
+	widget.Icon
+	onTapped func()
+}
+
+func newCompletionChipRemove(onTapped func()) *completionChipRemove {
+	r := &completionChipRemove{onTapped: onTapped}
+	r.ExtendBaseWidget(r)
+	r.SetResource(theme.CancelIcon())
+	return r
+}
+
+func (r *completionChipRemove) Tapped(*fyne.PointEvent) {
+	if f := r.onTapped; f != nil {
+		f()
+	}
+}
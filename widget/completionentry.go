@@ -1,11 +1,28 @@
 package widget
 
 import (
+	"strings"
+	"unicode"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+
+	"golang.org/x/text/unicode/norm"
 )
 
+// multiValueDelimiter commits the typed text as a value when typed in a multi-value CompletionEntry
+const multiValueDelimiter = ','
+
+// defaultMaxHistory is the number of accepted values remembered by a new CompletionEntry
+const defaultMaxHistory = 10
+
+// Declare conformity with interfaces
+var _ fyne.Draggable = (*CompletionEntry)(nil)
+var _ desktop.Mouseable = (*CompletionEntry)(nil)
+
 // CompletionEntry is an Entry with options displayed in a PopUpMenu.
 type CompletionEntry struct {
 	widget.Entry
@@ -14,18 +31,60 @@ type CompletionEntry struct {
 	Options       []string
 	pause         bool
 	itemHeight    float32
+	groups        []CompletionGroup
+	multiValue    bool
+	values        []string
+	chips         *fyne.Container
+	history       []string
+	maxHistory    int
+	minLength     int
+	matching      completionMatching
 
+	// CustomCreate creates the objects of the completion menu, by default a RichText that highlights the typed
+	// text, or a Label if only CustomUpdate is set.
 	CustomCreate func() fyne.CanvasObject
+	// CustomUpdate updates an object created by CustomCreate, or a Label if CustomCreate is not set,
+	// to show the row with the id.
 	CustomUpdate func(id widget.ListItemID, object fyne.CanvasObject)
 }
 
+// CompletionGroup is a set of options shown together in the completion menu under a header with the title.
+type CompletionGroup struct {
+	Title string
+	Items []string
+}
+
 // NewCompletionEntry creates a new CompletionEntry which creates a popup menu that responds to keystrokes to navigate through the items without losing the editing ability of the text input.
 func NewCompletionEntry(options []string) *CompletionEntry {
-	c := &CompletionEntry{Options: options}
+	c := &CompletionEntry{Options: options, maxHistory: defaultMaxHistory}
 	c.ExtendBaseWidget(c)
 	return c
 }
 
+// CreateRenderer returns the renderer of the Entry with the chips of a multi-value entry added before it.
+func (c *CompletionEntry) CreateRenderer() fyne.WidgetRenderer {
+	c.ExtendBaseWidget(c)
+	c.chips = container.NewHBox()
+	c.fillChips()
+	entry := c.Entry.CreateRenderer()
+	return &completionEntryRenderer{WidgetRenderer: entry, entry: c, content: container.NewWithoutLayout(entry.Objects()...)}
+}
+
+// Dragged adjusts the position of the event for the chips before passing it to the Entry.
+func (c *CompletionEntry) Dragged(d *fyne.DragEvent) {
+	event := *d
+	event.Position = event.Position.SubtractXY(c.chipsWidth(), 0)
+	c.Entry.Dragged(&event)
+}
+
+// FocusGained shows the history when the entry is focused while empty.
+//
+// Implements: fyne.Focusable
+func (c *CompletionEntry) FocusGained() {
+	c.Entry.FocusGained()
+	c.showHistory()
+}
+
 // HideCompletion hides the completion menu.
 func (c *CompletionEntry) HideCompletion() {
 	if c.popupMenu != nil {
@@ -33,6 +92,30 @@ func (c *CompletionEntry) HideCompletion() {
 	}
 }
 
+// IsCompletionShown returns whether the completion menu is currently displayed.
+func (c *CompletionEntry) IsCompletionShown() bool {
+	return c.popupMenu != nil && c.popupMenu.Visible()
+}
+
+// History returns a copy of the recently accepted values, most recent first.
+func (c *CompletionEntry) History() []string {
+	return append([]string(nil), c.history...)
+}
+
+// MouseDown adjusts the position of the event for the chips before passing it to the Entry.
+func (c *CompletionEntry) MouseDown(m *desktop.MouseEvent) {
+	event := *m
+	event.Position = event.Position.SubtractXY(c.chipsWidth(), 0)
+	c.Entry.MouseDown(&event)
+}
+
+// MouseUp adjusts the position of the event for the chips before passing it to the Entry.
+func (c *CompletionEntry) MouseUp(m *desktop.MouseEvent) {
+	event := *m
+	event.Position = event.Position.SubtractXY(c.chipsWidth(), 0)
+	c.Entry.MouseUp(&event)
+}
+
 // Move changes the relative position of the select entry.
 //
 // Implements: fyne.Widget
@@ -47,35 +130,127 @@ func (c *CompletionEntry) Move(pos fyne.Position) {
 // Refresh the list to update the options to display.
 func (c *CompletionEntry) Refresh() {
 	c.Entry.Refresh()
+	if !c.MinLengthReached() {
+		c.HideCompletion()
+	}
 	if c.navigableList != nil {
-		c.navigableList.SetOptions(c.Options)
+		c.navigableList.query = c.Text
+		c.navigableList.matching = c.matching
+		c.navigableList.setRows(c.rows())
+	}
+}
+
+// Matches returns true if the option matches the typed text in the way the completion menu highlights it: the option
+// contains the text, or else the characters of the text in the same order. It follows SetCaseSensitive and
+// SetAccentInsensitive, so an OnChanged handler can use it to filter the options consistently with the menu.
+func (c *CompletionEntry) Matches(option string) bool {
+	return matchedRunes([]rune(option), []rune(c.Text), c.matching) != nil
+}
+
+// SetAccentInsensitive sets whether accents and other diacritics are ignored when matching the typed text against
+// the options, so that "cafe" matches "café". Both are compared without their combining marks after decomposing
+// each character. The default is false, which compares accented characters as they are.
+func (c *CompletionEntry) SetAccentInsensitive(insensitive bool) {
+	c.matching.accentInsensitive = insensitive
+	c.Refresh()
+}
+
+// SetCaseSensitive sets whether the case of the typed text must match the options.
+// The default is false, which ignores case.
+func (c *CompletionEntry) SetCaseSensitive(sensitive bool) {
+	c.matching.caseSensitive = sensitive
+	c.Refresh()
+}
+
+// SetGroupedOptions sets the completion list to the items of the groups and updates the view.
+// The items of each group are shown below a header with the group's title, which cannot be selected.
+// The Options are set to all of the items so that the completion is shown if any group has items.
+// When CustomUpdate is used it is called for the header rows too, with the IDs counting the headers.
+func (c *CompletionEntry) SetGroupedOptions(groups []CompletionGroup) {
+	c.groups = groups
+	c.Options = nil
+	for _, group := range groups {
+		c.Options = append(c.Options, group.Items...)
+	}
+	c.Refresh()
+}
+
+// SetHistory sets the recently accepted values, most recent first, such as a history saved to the preferences.
+// The history is shown as the completion when the entry is empty and focused, or when its text is deleted.
+func (c *CompletionEntry) SetHistory(history []string) {
+	c.history = nil
+	for i := len(history) - 1; i >= 0; i-- {
+		c.addHistory(history[i])
+	}
+}
+
+// SetMaxHistory sets how many accepted values are remembered, 10 by default. A value of 0 or less disables the history.
+func (c *CompletionEntry) SetMaxHistory(n int) {
+	c.maxHistory = n
+	c.trimHistory()
+}
+
+// SetMinLength sets how many characters must be typed before the completion is shown.
+// While the text is shorter, ShowCompletion and refreshing the options hide the menu instead, and the history is not
+// shown, so an OnChanged handler that fetches the options can check MinLengthReached to skip the request.
+// The default of 0 shows the completion for any text.
+func (c *CompletionEntry) SetMinLength(n int) {
+	c.minLength = n
+	if !c.MinLengthReached() {
+		c.HideCompletion()
 	}
 }
 
+// MinLengthReached returns true if the text is long enough for the completion to be shown, see SetMinLength.
+func (c *CompletionEntry) MinLengthReached() bool {
+	return len([]rune(c.Text)) >= c.minLength
+}
+
+// SetMultiValue sets whether the entry collects multiple values, like a tag editor.
+// In multi-value mode a selected option, or the typed text when a comma or Enter is typed, is added to the values
+// and shown as a removable chip before the text, which is then cleared.
+// Backspace in an empty entry removes the last value.
+func (c *CompletionEntry) SetMultiValue(multi bool) {
+	c.multiValue = multi
+	c.updateChips()
+}
+
 // SetOptions set the completion list with itemList and update the view.
 func (c *CompletionEntry) SetOptions(itemList []string) {
+	c.groups = nil
 	c.Options = itemList
 	c.Refresh()
 }
 
-// ShowCompletion displays the completion menu
+// ShowCompletion displays the completion menu, matching the options against the current text.
+// It can be called while the entry is not focused, for example from a button revealing the options,
+// and does nothing if the entry is not shown in a window.
 func (c *CompletionEntry) ShowCompletion() {
 	if c.pause {
 		return
 	}
-	if len(c.Options) == 0 {
+	if len(c.Options) == 0 || !c.MinLengthReached() {
 		c.HideCompletion()
 		return
 	}
 
+	c.showRows(c.rows())
+}
+
+// showRows displays the completion menu with the rows, which may be the options or the history
+func (c *CompletionEntry) showRows(rows []string, headers []bool) {
+	holder := fyne.CurrentApp().Driver().CanvasForObject(c)
+	if holder == nil {
+		return
+	}
 	if c.navigableList == nil {
-		c.navigableList = newNavigableList(c.Options, &c.Entry, c.setTextFromMenu, c.HideCompletion,
+		c.navigableList = newNavigableList(rows, c, c.setTextFromMenu, c.HideCompletion,
 			c.CustomCreate, c.CustomUpdate)
-	} else {
-		c.navigableList.UnselectAll()
-		c.navigableList.selected = -1
 	}
-	holder := fyne.CurrentApp().Driver().CanvasForObject(c)
+	c.navigableList.UnselectAll()
+	c.navigableList.query = c.Text
+	c.navigableList.matching = c.matching
+	c.navigableList.setRows(rows, headers)
 
 	if c.popupMenu == nil {
 		c.popupMenu = widget.NewPopUp(c.navigableList, holder)
@@ -85,6 +260,145 @@ func (c *CompletionEntry) ShowCompletion() {
 	holder.Focus(c.navigableList)
 }
 
+// Tapped adjusts the position of the event for the chips before passing it to the Entry.
+func (c *CompletionEntry) Tapped(ev *fyne.PointEvent) {
+	event := *ev
+	event.Position = event.Position.SubtractXY(c.chipsWidth(), 0)
+	c.Entry.Tapped(&event)
+}
+
+// TypedKey commits the text as a value when Enter is pressed in multi-value mode,
+// and removes the last value when Backspace is pressed in an empty entry.
+//
+// Implements: fyne.Focusable
+func (c *CompletionEntry) TypedKey(event *fyne.KeyEvent) {
+	if c.multiValue {
+		switch event.Name {
+		case fyne.KeyReturn, fyne.KeyEnter:
+			if c.commitText() {
+				return
+			}
+		case fyne.KeyBackspace:
+			if c.Text == "" && len(c.values) > 0 {
+				c.removeValue(len(c.values) - 1)
+				return
+			}
+		}
+	}
+	c.Entry.TypedKey(event)
+	if event.Name == fyne.KeyBackspace || event.Name == fyne.KeyDelete {
+		c.showHistory()
+	}
+}
+
+// TypedRune commits the text as a value when a comma is typed in multi-value mode.
+//
+// Implements: fyne.Focusable
+func (c *CompletionEntry) TypedRune(r rune) {
+	if c.multiValue && r == multiValueDelimiter {
+		c.commitText()
+		return
+	}
+	c.Entry.TypedRune(r)
+}
+
+// Values returns the values collected in multi-value mode.
+func (c *CompletionEntry) Values() []string {
+	return c.values
+}
+
+// addHistory moves the value to the start of the history, dropping the oldest values beyond the maximum
+func (c *CompletionEntry) addHistory(value string) {
+	if value == "" {
+		return
+	}
+	for i, item := range c.history {
+		if item == value {
+			c.history = append(c.history[:i], c.history[i+1:]...)
+			break
+		}
+	}
+	c.history = append([]string{value}, c.history...)
+	c.trimHistory()
+}
+
+// addValue adds the value as a chip and clears the text without showing the completion
+func (c *CompletionEntry) addValue(value string) {
+	c.values = append(c.values, value)
+	c.addHistory(value)
+	c.updateChips()
+	c.pause = true
+	c.Entry.SetText("")
+	c.pause = false
+}
+
+// chipsWidth returns the space taken by the chips before the Entry
+func (c *CompletionEntry) chipsWidth() float32 {
+	if c.chips == nil || !c.chips.Visible() {
+		return 0
+	}
+	return c.chips.MinSize().Width + theme.InnerPadding()
+}
+
+// commitText adds the trimmed text as a value, returning false if there is no text to add
+func (c *CompletionEntry) commitText() bool {
+	text := strings.TrimSpace(c.Text)
+	if text == "" {
+		return false
+	}
+	c.HideCompletion()
+	c.addValue(text)
+	return true
+}
+
+// showHistory displays the history as the completion if the entry is empty and no minimum length is set
+func (c *CompletionEntry) showHistory() {
+	if c.pause || c.Text != "" || len(c.history) == 0 || !c.MinLengthReached() {
+		return
+	}
+	c.showRows(c.history, nil)
+}
+
+// trimHistory drops the oldest values beyond the maximum
+func (c *CompletionEntry) trimHistory() {
+	if c.maxHistory <= 0 {
+		c.history = nil
+	} else if len(c.history) > c.maxHistory {
+		c.history = c.history[:c.maxHistory]
+	}
+}
+
+func (c *CompletionEntry) removeValue(index int) {
+	c.values = append(c.values[:index], c.values[index+1:]...)
+	c.updateChips()
+}
+
+// fillChips creates a chip for each value, the chips are only shown in multi-value mode
+func (c *CompletionEntry) fillChips() {
+	objects := make([]fyne.CanvasObject, len(c.values))
+	for i, value := range c.values {
+		index := i
+		objects[i] = newCompletionChip(value, func() {
+			c.removeValue(index)
+		})
+	}
+	c.chips.Objects = objects
+	if c.multiValue && len(c.values) > 0 {
+		c.chips.Show()
+	} else {
+		c.chips.Hide()
+	}
+}
+
+// updateChips refreshes the chips once the entry has been rendered
+func (c *CompletionEntry) updateChips() {
+	if c.chips == nil {
+		return
+	}
+	c.fillChips()
+	c.Refresh()
+}
+
 // calculate the max size to make the popup to cover everything below the entry
 func (c *CompletionEntry) maxSize() fyne.Size {
 	cnv := fyne.CurrentApp().Driver().CanvasForObject(c)
@@ -94,7 +408,7 @@ func (c *CompletionEntry) maxSize() fyne.Size {
 		c.itemHeight = c.navigableList.CreateItem().MinSize().Height
 	}
 
-	listheight := float32(len(c.Options))*(c.itemHeight+2*theme.Padding()+theme.SeparatorThicknessSize()) + 2*theme.Padding()
+	listheight := float32(len(c.navigableList.items))*(c.itemHeight+2*theme.Padding()+theme.SeparatorThicknessSize()) + 2*theme.Padding()
 	canvasSize := cnv.Size()
 	entrySize := c.Size()
 	if canvasSize.Height > listheight {
@@ -112,8 +426,33 @@ func (c *CompletionEntry) popUpPos() fyne.Position {
 	return entryPos.Add(fyne.NewPos(0, c.Size().Height))
 }
 
+// rows returns the text of each row in the completion menu and, if the options are grouped, which rows are headers
+func (c *CompletionEntry) rows() ([]string, []bool) {
+	if c.groups == nil {
+		return c.Options, nil
+	}
+
+	rows := []string{}
+	headers := []bool{}
+	for _, group := range c.groups {
+		rows = append(rows, group.Title)
+		headers = append(headers, true)
+		for _, item := range group.Items {
+			rows = append(rows, item)
+			headers = append(headers, false)
+		}
+	}
+	return rows, headers
+}
+
 // Prevent the menu to open when the user validate value from the menu.
 func (c *CompletionEntry) setTextFromMenu(s string) {
+	if c.multiValue {
+		c.addValue(s)
+		c.popupMenu.Hide()
+		return
+	}
+	c.addHistory(s)
 	c.pause = true
 	c.Entry.SetText(s)
 	c.Entry.CursorColumn = len([]rune(s))
@@ -122,20 +461,66 @@ func (c *CompletionEntry) setTextFromMenu(s string) {
 	c.popupMenu.Hide()
 }
 
+// completionEntryRenderer draws the chips of a multi-value entry before the Entry.
+type completionEntryRenderer struct {
+	fyne.WidgetRenderer
+	entry *CompletionEntry
+	// content holds the objects of the Entry, laid out in the space after the chips
+	content *fyne.Container
+}
+
+func (r *completionEntryRenderer) Layout(size fyne.Size) {
+	width := r.entry.chipsWidth()
+	entrySize := size.SubtractWidthHeight(width, 0)
+	r.WidgetRenderer.Layout(entrySize)
+	r.content.Objects = r.WidgetRenderer.Objects()
+	r.content.Move(fyne.NewPos(width, 0))
+	r.content.Resize(entrySize)
+	if width == 0 {
+		return
+	}
+
+	chipsSize := r.entry.chips.MinSize()
+	r.entry.chips.Move(fyne.NewPos(0, (size.Height-chipsSize.Height)/2))
+	r.entry.chips.Resize(chipsSize)
+}
+
+func (r *completionEntryRenderer) MinSize() fyne.Size {
+	return r.WidgetRenderer.MinSize().AddWidthHeight(r.entry.chipsWidth(), 0)
+}
+
+func (r *completionEntryRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.entry.chips, r.content}
+}
+
+func (r *completionEntryRenderer) Refresh() {
+	r.WidgetRenderer.Refresh()
+	r.Layout(r.entry.Size())
+}
+
+// navigableListEntry is the part of the entry that receives the keys typed while the list has the focus
+type navigableListEntry interface {
+	TypedKey(*fyne.KeyEvent)
+	TypedRune(rune)
+}
+
 type navigableList struct {
 	widget.List
-	entry           *widget.Entry
+	entry           navigableListEntry
 	selected        int
 	setTextFromMenu func(string)
 	hide            func()
 	navigating      bool
 	items           []string
+	headers         []bool
+	query           string // the typed text, which is highlighted in the items
+	matching        completionMatching
 
 	customCreate func() fyne.CanvasObject
 	customUpdate func(id widget.ListItemID, object fyne.CanvasObject)
 }
 
-func newNavigableList(items []string, entry *widget.Entry, setTextFromMenu func(string), hide func(),
+func newNavigableList(items []string, entry navigableListEntry, setTextFromMenu func(string), hide func(),
 	create func() fyne.CanvasObject, update func(id widget.ListItemID, object fyne.CanvasObject)) *navigableList {
 	n := &navigableList{
 		entry:           entry,
@@ -155,16 +540,30 @@ func newNavigableList(items []string, entry *widget.Entry, setTextFromMenu func(
 			if fn := n.customCreate; fn != nil {
 				return fn()
 			}
-			return widget.NewLabel("")
+			if n.customUpdate != nil {
+				// a custom update may expect the Label that the items used to be
+				return widget.NewLabel("")
+			}
+			return widget.NewRichText()
 		},
 		UpdateItem: func(i widget.ListItemID, o fyne.CanvasObject) {
 			if fn := n.customUpdate; fn != nil {
 				fn(i, o)
 				return
 			}
-			o.(*widget.Label).SetText(n.items[i])
+			text := o.(*widget.RichText)
+			if n.isHeader(i) {
+				text.Segments = []widget.RichTextSegment{&widget.TextSegment{Text: n.items[i], Style: widget.RichTextStyleStrong}}
+			} else {
+				text.Segments = highlightMatch(n.items[i], n.query, n.matching)
+			}
+			text.Refresh()
 		},
 		OnSelected: func(id widget.ListItemID) {
+			if n.isHeader(id) {
+				n.Unselect(id)
+				return
+			}
 			if !n.navigating && id > -1 {
 				setTextFromMenu(n.items[id])
 			}
@@ -175,6 +574,97 @@ func newNavigableList(items []string, entry *widget.Entry, setTextFromMenu func(
 	return n
 }
 
+// completionMatching are the options for comparing the typed text with the items of the completion
+type completionMatching struct {
+	caseSensitive     bool
+	accentInsensitive bool
+}
+
+// fold returns the form of the rune that is compared, without its case or accents unless they are significant
+func (m completionMatching) fold(r rune) rune {
+	if m.accentInsensitive {
+		r = stripAccent(r)
+	}
+	if !m.caseSensitive {
+		r = unicode.ToLower(r)
+	}
+	return r
+}
+
+// stripAccent returns the base character of the rune, which is the rune itself if it has no diacritics
+func stripAccent(r rune) rune {
+	for _, d := range norm.NFD.String(string(r)) {
+		if !unicode.Is(unicode.Mn, d) {
+			return d
+		}
+	}
+	return r
+}
+
+// highlightMatch returns the segments of the item with the characters that match the query shown in bold in the
+// primary color. The query is matched as a part of the item if it contains the query, or else as characters
+// appearing in the same order in the item, so the items of any kind of filtering show why they match.
+func highlightMatch(item, query string, matching completionMatching) []widget.RichTextSegment {
+	matched := matchedRunes([]rune(item), []rune(query), matching)
+	if matched == nil {
+		return []widget.RichTextSegment{&widget.TextSegment{Text: item, Style: widget.RichTextStyleInline}}
+	}
+
+	highlight := widget.RichTextStyleInline
+	highlight.ColorName = theme.ColorNamePrimary
+	highlight.TextStyle.Bold = true
+	runes := []rune(item)
+	segments := []widget.RichTextSegment{}
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && matched[i] == matched[start] {
+			continue
+		}
+		style := widget.RichTextStyleInline
+		if matched[start] {
+			style = highlight
+		}
+		segments = append(segments, &widget.TextSegment{Text: string(runes[start:i]), Style: style})
+		start = i
+	}
+	return segments
+}
+
+// matchedRunes returns which runes of the item match the query, or nil if the item does not match it
+func matchedRunes(item, query []rune, matching completionMatching) []bool {
+	if len(query) == 0 || len(query) > len(item) {
+		return nil
+	}
+	matched := make([]bool, len(item))
+	for start := 0; start+len(query) <= len(item); start++ {
+		found := true
+		for i, r := range query {
+			if matching.fold(item[start+i]) != matching.fold(r) {
+				found = false
+				break
+			}
+		}
+		if found {
+			for i := range query {
+				matched[start+i] = true
+			}
+			return matched
+		}
+	}
+
+	next := 0
+	for i, r := range item {
+		if next < len(query) && matching.fold(r) == matching.fold(query[next]) {
+			matched[i] = true
+			next++
+		}
+	}
+	if next < len(query) {
+		return nil
+	}
+	return matched
+}
+
 // Implements: fyne.Focusable
 func (n *navigableList) FocusGained() {
 }
@@ -184,8 +674,40 @@ func (n *navigableList) FocusLost() {
 }
 
 func (n *navigableList) SetOptions(items []string) {
+	n.setRows(items, nil)
+}
+
+// isHeader returns true if the row is a group header, which cannot be selected
+func (n *navigableList) isHeader(id widget.ListItemID) bool {
+	return id >= 0 && id < len(n.headers) && n.headers[id]
+}
+
+// moveSelection moves the selection by one row in the direction, wrapping around and skipping headers
+func (n *navigableList) moveSelection(direction int) {
+	count := len(n.items)
+	if count == 0 {
+		return
+	}
+
+	next := n.selected
+	if next < 0 && direction < 0 {
+		next = 0
+	}
+	for i := 0; i < count; i++ {
+		next = (next + direction + count) % count
+		if !n.isHeader(next) {
+			n.selected = next
+			n.navigating = true
+			n.Select(n.selected)
+			return
+		}
+	}
+}
+
+func (n *navigableList) setRows(items []string, headers []bool) {
 	n.Unselect(n.selected)
 	n.items = items
+	n.headers = headers
 	n.Refresh()
 	n.selected = -1
 }
@@ -193,22 +715,10 @@ func (n *navigableList) SetOptions(items []string) {
 func (n *navigableList) TypedKey(event *fyne.KeyEvent) {
 	switch event.Name {
 	case fyne.KeyDown:
-		if n.selected < len(n.items)-1 {
-			n.selected++
-		} else {
-			n.selected = 0
-		}
-		n.navigating = true
-		n.Select(n.selected)
+		n.moveSelection(1)
 
 	case fyne.KeyUp:
-		if n.selected > 0 {
-			n.selected--
-		} else {
-			n.selected = len(n.items) - 1
-		}
-		n.navigating = true
-		n.Select(n.selected)
+		n.moveSelection(-1)
 	case fyne.KeyReturn, fyne.KeyEnter:
 		if n.selected == -1 { // so the user want to submit the entry
 			n.hide()
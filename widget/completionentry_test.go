@@ -1,9 +1,11 @@
 package widget
 
 import (
+	"strings"
 	"testing"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/test"
 	"fyne.io/fyne/v2/widget"
 	"github.com/stretchr/testify/assert"
@@ -53,6 +55,23 @@ func TestCompletionEntry_Custom(t *testing.T) {
 	assert.Equal(t, "bar", item1.(*widget.Check).Text) // ensure the item is a Check not Label
 }
 
+// Check that a custom update without a custom create is given labels
+func TestCompletionEntry_CustomUpdate(t *testing.T) {
+	entry := createEntry()
+	entry.CustomUpdate = func(id widget.ListItemID, o fyne.CanvasObject) {
+		o.(*widget.Label).SetText(strings.ToUpper(entryData[id]))
+	}
+	win := test.NewWindow(entry)
+	win.Resize(fyne.NewSize(500, 300))
+	defer win.Close()
+
+	entry.SetText("init")
+	scroll := test.WidgetRenderer(entry.navigableList).Objects()[0].(fyne.Widget)
+	list := test.WidgetRenderer(scroll).Objects()[0].(*fyne.Container).Objects[1].(fyne.Widget)
+	item1 := test.WidgetRenderer(list).Objects()[1]
+	assert.Equal(t, "BAR", item1.(*widget.Label).Text)
+}
+
 // Show the completion menu
 func TestCompletionEntry_ShowMenu(t *testing.T) {
 	entry := createEntry()
@@ -229,3 +248,269 @@ func TestCompletionEntry_DoubleSubmissionIssue(t *testing.T) {
 	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn}) // OnSubmitted should be called
 	assert.True(t, submitted)
 }
+
+// Navigate grouped options, skipping the headers.
+func TestCompletionEntry_GroupedOptions(t *testing.T) {
+	entry := NewCompletionEntry(nil)
+	entry.OnChanged = func(s string) {
+		entry.SetGroupedOptions([]CompletionGroup{
+			{Title: "Files", Items: []string{"open", "save"}},
+			{Title: "Edit", Items: []string{"undo"}},
+		})
+		entry.ShowCompletion()
+	}
+	win := test.NewWindow(entry)
+	win.Resize(fyne.NewSize(500, 300))
+	defer win.Close()
+
+	entry.SetText("init")
+	assert.Equal(t, []string{"open", "save", "undo"}, entry.Options)
+	assert.Equal(t, 5, entry.navigableList.Length())
+
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	assert.Equal(t, 1, entry.navigableList.selected)
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	assert.Equal(t, 4, entry.navigableList.selected)
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	assert.Equal(t, 1, entry.navigableList.selected)
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+
+	assert.Equal(t, "undo", entry.Text)
+	assert.False(t, entry.popupMenu.Visible())
+}
+
+// Collect multiple values as chips.
+func TestCompletionEntry_MultiValue(t *testing.T) {
+	entry := createEntry()
+	entry.SetMultiValue(true)
+	win := test.NewWindow(entry)
+	win.Resize(fyne.NewSize(500, 300))
+	defer win.Close()
+	width := entry.MinSize().Width
+
+	// select "bar" from the menu
+	entry.SetText("b")
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, []string{"bar"}, entry.Values())
+	assert.Empty(t, entry.Text)
+	assert.Greater(t, entry.MinSize().Width, width)
+	content := test.WidgetRenderer(entry).(*completionEntryRenderer).content
+	assert.Equal(t, entry.chipsWidth(), content.Position().X)
+	assert.Equal(t, entry.Size().Width-entry.chipsWidth(), content.Size().Width)
+
+	// commit typed text with a comma
+	win.Canvas().Focus(entry)
+	entry.HideCompletion()
+	entry.Entry.SetText(" qux ")
+	entry.TypedRune(',')
+	assert.Equal(t, []string{"bar", "qux"}, entry.Values())
+	assert.Empty(t, entry.Text)
+	assert.Len(t, entry.chips.Objects, 2)
+
+	entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	assert.Equal(t, []string{"bar"}, entry.Values())
+
+	test.Tap(test.WidgetRenderer(entry.chips.Objects[0].(*completionChip)).Objects()[2].(*completionChipRemove))
+	assert.Empty(t, entry.Values())
+	assert.False(t, entry.chips.Visible())
+	assert.Zero(t, content.Position().X)
+}
+
+// Remember accepted values and show them when the entry is empty.
+func TestCompletionEntry_History(t *testing.T) {
+	entry := createEntry()
+	entry.SetHistory([]string{"old", "older"})
+	win := test.NewWindow(entry)
+	win.Resize(fyne.NewSize(500, 300))
+	defer win.Close()
+
+	win.Canvas().Focus(entry)
+	assert.True(t, entry.popupMenu.Visible())
+	assert.Equal(t, []string{"old", "older"}, entry.navigableList.items)
+	entry.HideCompletion()
+
+	entry.SetText("b")
+	assert.Equal(t, entryData, entry.navigableList.items)
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, "bar", entry.Text)
+	assert.Equal(t, []string{"bar", "old", "older"}, entry.History())
+
+	// changing the returned values does not change the history
+	history := entry.History()
+	history[0] = "changed"
+	assert.Equal(t, []string{"bar", "old", "older"}, entry.History())
+
+	entry.SetMaxHistory(2)
+	assert.Equal(t, []string{"bar", "old"}, entry.History())
+
+	// selecting a remembered value moves it to the front
+	entry.CursorColumn = 3
+	for range "bar" {
+		entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	}
+	assert.Empty(t, entry.Text)
+	assert.True(t, entry.popupMenu.Visible())
+	assert.Equal(t, []string{"bar", "old"}, entry.navigableList.items)
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	win.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, "old", entry.Text)
+	assert.Equal(t, []string{"old", "bar"}, entry.History())
+
+	entry.SetMaxHistory(0)
+	assert.Empty(t, entry.History())
+}
+
+func TestCompletionEntry_MinLength(t *testing.T) {
+	entry := createEntry()
+	entry.SetMinLength(3)
+	requests := 0
+	entry.OnChanged = func(s string) {
+		if !entry.MinLengthReached() {
+			entry.HideCompletion()
+			return
+		}
+		requests++
+		entry.SetOptions(entryData)
+		entry.ShowCompletion()
+	}
+	win := test.NewWindow(entry)
+	win.Resize(fyne.NewSize(500, 300))
+	defer win.Close()
+
+	entry.SetText("ba")
+	assert.Nil(t, entry.popupMenu)
+	assert.Equal(t, 0, requests)
+
+	entry.SetText("bar")
+	assert.True(t, entry.popupMenu.Visible())
+	assert.Equal(t, 1, requests)
+
+	entry.SetText("ba")
+	assert.False(t, entry.popupMenu.Visible())
+
+	// the completion is not shown below the threshold even if asked for
+	entry.ShowCompletion()
+	assert.False(t, entry.popupMenu.Visible())
+
+	// or when the options are changed without asking for it
+	entry.SetText("bar")
+	assert.True(t, entry.popupMenu.Visible())
+	entry.OnChanged = nil
+	entry.SetText("ba")
+	entry.SetOptions(entryData)
+	assert.False(t, entry.popupMenu.Visible())
+
+	// nor is the history
+	entry.SetHistory([]string{"bar"})
+	entry.SetText("")
+	win.Canvas().Focus(entry)
+	assert.False(t, entry.popupMenu.Visible())
+	entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	assert.False(t, entry.popupMenu.Visible())
+
+	entry.SetMinLength(0)
+	entry.ShowCompletion()
+	assert.True(t, entry.popupMenu.Visible())
+}
+
+func TestCompletionEntry_ShowHideCompletion(t *testing.T) {
+	entry := NewCompletionEntry(entryData)
+	assert.False(t, entry.IsCompletionShown())
+	entry.HideCompletion()
+
+	button := widget.NewButton("v", entry.ShowCompletion)
+	win := test.NewWindow(container.NewVBox(entry, button))
+	win.Resize(fyne.NewSize(500, 300))
+	defer win.Close()
+
+	// a button can reveal the options without focusing the entry first
+	test.Tap(button)
+	assert.True(t, entry.IsCompletionShown())
+	assert.Equal(t, entryData, entry.navigableList.items)
+
+	entry.HideCompletion()
+	assert.False(t, entry.IsCompletionShown())
+
+	entry.SetOptions([]string{"bar"})
+	entry.ShowCompletion()
+	assert.True(t, entry.IsCompletionShown())
+	assert.Equal(t, []string{"bar"}, entry.navigableList.items)
+
+	entry.SetOptions(nil)
+	entry.ShowCompletion()
+	assert.False(t, entry.IsCompletionShown())
+}
+
+func TestCompletionEntry_HighlightMatch(t *testing.T) {
+	texts := func(segments []widget.RichTextSegment) (plain, bold []string) {
+		for _, s := range segments {
+			text := s.(*widget.TextSegment)
+			if text.Style.TextStyle.Bold {
+				bold = append(bold, text.Text)
+			} else {
+				plain = append(plain, text.Text)
+			}
+		}
+		return plain, bold
+	}
+
+	// a prefix or a part of the item is highlighted, ignoring case
+	plain, bold := texts(highlightMatch("Barcelona", "bar", completionMatching{}))
+	assert.Equal(t, []string{"celona"}, plain)
+	assert.Equal(t, []string{"Bar"}, bold)
+	plain, bold = texts(highlightMatch("Barcelona", "LON", completionMatching{}))
+	assert.Equal(t, []string{"Barce", "a"}, plain)
+	assert.Equal(t, []string{"lon"}, bold)
+
+	// otherwise the characters are matched in order
+	plain, bold = texts(highlightMatch("Barcelona", "bcn", completionMatching{}))
+	assert.Equal(t, []string{"ar", "elo", "a"}, plain)
+	assert.Equal(t, []string{"B", "c", "n"}, bold)
+
+	plain, bold = texts(highlightMatch("Barcelona", "xyz", completionMatching{}))
+	assert.Equal(t, []string{"Barcelona"}, plain)
+	assert.Empty(t, bold)
+
+	entry := createEntry()
+	win := test.NewWindow(entry)
+	win.Resize(fyne.NewSize(500, 300))
+	defer win.Close()
+
+	// the rows of the menu highlight the typed text
+	entry.SetText("ba")
+	item := entry.navigableList.CreateItem()
+	entry.navigableList.UpdateItem(2, item)
+	plain, bold = texts(item.(*widget.RichText).Segments)
+	assert.Equal(t, []string{"z"}, plain)
+	assert.Equal(t, []string{"ba"}, bold)
+}
+
+func TestCompletionEntry_Matching(t *testing.T) {
+	entry := NewCompletionEntry([]string{"Café", "Cafeteria"})
+	entry.SetText("cafe")
+	assert.False(t, entry.Matches("Café"))
+	assert.True(t, entry.Matches("Cafeteria"))
+
+	entry.SetAccentInsensitive(true)
+	assert.True(t, entry.Matches("Café"))
+	assert.True(t, entry.Matches("CAFÉ"))
+	assert.True(t, entry.Matches("Cafe\u0301"))
+
+	entry.SetCaseSensitive(true)
+	assert.False(t, entry.Matches("Café"))
+	entry.SetText("Cafe")
+	assert.True(t, entry.Matches("Café"))
+
+	// the accented characters are highlighted
+	matching := completionMatching{accentInsensitive: true}
+	segments := highlightMatch("Crème brûlée", "brulee", matching)
+	assert.Equal(t, "brûlée", segments[1].(*widget.TextSegment).Text)
+	assert.True(t, segments[1].(*widget.TextSegment).Style.TextStyle.Bold)
+}
@@ -0,0 +1,131 @@
+package diagramwidget
+
+import (
+	"sort"
+
+	"fyne.io/fyne/v2"
+)
+
+// AlignEdge identifies the edge or center line used to align diagram nodes
+type AlignEdge int
+
+// Specify the enumerated values for AlignEdge
+const (
+	// AlignLeft lines up the left edges of the nodes
+	AlignLeft AlignEdge = iota
+	// AlignRight lines up the right edges of the nodes
+	AlignRight
+	// AlignTop lines up the top edges of the nodes
+	AlignTop
+	// AlignBottom lines up the bottom edges of the nodes
+	AlignBottom
+	// AlignCenterH lines up the horizontal centers of the nodes, placing them in a column
+	AlignCenterH
+	// AlignCenterV lines up the vertical centers of the nodes, placing them in a row
+	AlignCenterV
+)
+
+// Axis identifies the direction in which diagram nodes are distributed
+type Axis int
+
+// Specify the enumerated values for Axis
+const (
+	// AxisHorizontal distributes the nodes from left to right
+	AxisHorizontal Axis = iota
+	// AxisVertical distributes the nodes from top to bottom
+	AxisVertical
+)
+
+// AlignSelection moves the selected nodes so that the indicated edges or centers line up. The nodes are aligned
+// with the outermost edge of the selection (or the center of the selection's bounds). Links follow the nodes.
+func (dw *DiagramWidget) AlignSelection(edge AlignEdge) {
+	nodes := dw.selectedNodes()
+	if len(nodes) < 2 {
+		return
+	}
+
+	left, top := nodes[0].Position().X, nodes[0].Position().Y
+	right, bottom := left+nodes[0].Size().Width, top+nodes[0].Size().Height
+	for _, node := range nodes[1:] {
+		position, size := node.Position(), node.Size()
+		left = fyne.Min(left, position.X)
+		top = fyne.Min(top, position.Y)
+		right = fyne.Max(right, position.X+size.Width)
+		bottom = fyne.Max(bottom, position.Y+size.Height)
+	}
+
+	for _, node := range nodes {
+		position, size := node.Position(), node.Size()
+		target := position
+		switch edge {
+		case AlignLeft:
+			target.X = left
+		case AlignRight:
+			target.X = right - size.Width
+		case AlignTop:
+			target.Y = top
+		case AlignBottom:
+			target.Y = bottom - size.Height
+		case AlignCenterH:
+			target.X = (left+right)/2 - size.Width/2
+		case AlignCenterV:
+			target.Y = (top+bottom)/2 - size.Height/2
+		}
+		dw.DisplaceNode(node, target.Subtract(position))
+	}
+}
+
+// DistributeSelection moves the selected nodes along the axis so that the spaces between them are equal.
+// The first and last nodes along the axis stay where they are, so at least three nodes must be selected.
+func (dw *DiagramWidget) DistributeSelection(axis Axis) {
+	nodes := dw.selectedNodes()
+	if len(nodes) < 3 {
+		return
+	}
+
+	start := func(node DiagramNode) float32 {
+		if axis == AxisHorizontal {
+			return node.Position().X
+		}
+		return node.Position().Y
+	}
+	length := func(node DiagramNode) float32 {
+		if axis == AxisHorizontal {
+			return node.Size().Width
+		}
+		return node.Size().Height
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return start(nodes[i]) < start(nodes[j])
+	})
+
+	first, last := nodes[0], nodes[len(nodes)-1]
+	span := start(last) + length(last) - start(first)
+	occupied := float32(0)
+	for _, node := range nodes {
+		occupied += length(node)
+	}
+	gap := (span - occupied) / float32(len(nodes)-1)
+
+	next := start(first) + length(first) + gap
+	for _, node := range nodes[1 : len(nodes)-1] {
+		delta := next - start(node)
+		if axis == AxisHorizontal {
+			dw.DisplaceNode(node, fyne.NewPos(delta, 0))
+		} else {
+			dw.DisplaceNode(node, fyne.NewPos(0, delta))
+		}
+		next += length(node) + gap
+	}
+}
+
+// selectedNodes returns the selected nodes in display order
+func (dw *DiagramWidget) selectedNodes() []DiagramNode {
+	nodes := []DiagramNode{}
+	for _, node := range dw.GetDiagramNodes() {
+		if dw.IsSelected(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
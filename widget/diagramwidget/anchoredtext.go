@@ -2,8 +2,10 @@ package diagramwidget
 
 import (
 	"image/color"
+	"sync"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/driver/desktop"
@@ -16,7 +18,8 @@ import (
 // of the path's reference points (e.g. end or middle). The anchored text may
 // be moved independently, but it keeps track of its position relative to the
 // reference point. If the reference point moves, the AnchoredText will also
-// move by the same amount
+// move by the same amount. The text is edited in place by double-tapping it
+// and committed by pressing Enter or by moving the focus away from it.
 type AnchoredText struct {
 	widget.BaseWidget
 	link                 *BaseDiagramLink
@@ -24,7 +27,34 @@ type AnchoredText struct {
 	referencePosition    fyne.Position
 	displayedTextBinding binding.String
 	ForegroundColor      color.Color
-	textEntry            *widget.Entry
+	textEntry            *anchoredTextEntry
+	// OnLabelChanged is called with the new text when an edit of the text is committed
+	OnLabelChanged func(string)
+	label          *canvas.Text
+	background     *canvas.Rectangle
+	showBackground bool
+	editing        bool
+	// textLock guards text, the last value of the binding that the renderer displayed
+	textLock sync.Mutex
+	text     string
+}
+
+// anchoredTextEntry is the entry used to edit an AnchoredText. It commits the edit when it loses the focus.
+type anchoredTextEntry struct {
+	widget.Entry
+	anchoredText *AnchoredText
+}
+
+func newAnchoredTextEntry(at *AnchoredText) *anchoredTextEntry {
+	e := &anchoredTextEntry{anchoredText: at}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// FocusLost commits the edit in progress before the entry handles the focus loss
+func (e *anchoredTextEntry) FocusLost() {
+	e.anchoredText.commitEdit(e.Text)
+	e.Entry.FocusLost()
 }
 
 // NewAnchoredText creates an textual annotation for a link. After it is created, one of the
@@ -35,14 +65,27 @@ func NewAnchoredText(text string) *AnchoredText {
 		offset:            r2.MakeVec2(0, 0),
 		ForegroundColor:   theme.ForegroundColor(),
 		referencePosition: fyne.Position{X: 0, Y: 0},
+		text:              text,
 	}
 	at.displayedTextBinding = binding.NewString()
 	at.displayedTextBinding.Set(text)
-	at.textEntry = widget.NewEntryWithData(at.displayedTextBinding)
 	at.displayedTextBinding.AddListener(at)
+	at.textEntry = newAnchoredTextEntry(at)
+	at.textEntry.SetText(text)
 	at.textEntry.Wrapping = fyne.TextWrapOff
 	at.textEntry.Scroll = container.ScrollNone
 	at.textEntry.Validator = nil
+	at.textEntry.OnSubmitted = func(text string) {
+		at.commitEdit(text)
+		if c := fyne.CurrentApp().Driver().CanvasForObject(at); c != nil && c.Focused() == at.textEntry {
+			c.Unfocus()
+		}
+	}
+	at.textEntry.Hide()
+	at.label = canvas.NewText(text, at.ForegroundColor)
+	at.background = canvas.NewRectangle(theme.BackgroundColor())
+	at.background.CornerRadius = theme.InputRadiusSize()
+	at.background.Hide()
 	at.ExtendBaseWidget(at)
 	return at
 }
@@ -57,9 +100,25 @@ func (at *AnchoredText) CreateRenderer() fyne.WidgetRenderer {
 	return atr
 }
 
-// DataChanged is the callback function for the displayedTextBinding.
+// Cursor returns the move cursor to show that the text can be dragged, unless the diagram cannot be edited
+func (at *AnchoredText) Cursor() desktop.Cursor {
+	if at.link == nil {
+		return desktop.DefaultCursor
+	}
+	return cursorFor(at.link.diagram)
+}
+
+// DataChanged is the callback function for the displayedTextBinding. It is called on the
+// binding's goroutine and only refreshes the widget when the text differs from the one shown;
+// the renderer reads the new text from the binding.
 func (at *AnchoredText) DataChanged() {
-	at.Refresh()
+	text, _ := at.displayedTextBinding.Get()
+	at.textLock.Lock()
+	changed := text != at.text
+	at.textLock.Unlock()
+	if changed {
+		at.Refresh()
+	}
 }
 
 // Displace moves the anchored text relative to its reference position.
@@ -67,6 +126,21 @@ func (at *AnchoredText) Displace(delta fyne.Position) {
 	at.Move(at.Position().Add(delta))
 }
 
+// DoubleTapped starts editing the text in place, unless the diagram is not editable.
+func (at *AnchoredText) DoubleTapped(event *fyne.PointEvent) {
+	if at.link != nil && !at.link.diagram.IsEditable() {
+		return
+	}
+	at.editing = true
+	text, _ := at.displayedTextBinding.Get()
+	at.textEntry.SetText(text)
+	at.textEntry.CursorColumn = len([]rune(text))
+	at.Refresh()
+	if c := fyne.CurrentApp().Driver().CanvasForObject(at); c != nil {
+		c.Focus(at.textEntry)
+	}
+}
+
 // DragEnd is one of the required methods for a draggable widget. It just refreshes the widget.
 func (at *AnchoredText) DragEnd() {
 	at.Refresh()
@@ -75,6 +149,9 @@ func (at *AnchoredText) DragEnd() {
 // Dragged is the required method for a draggable widget. It moves the anchored text
 // relative to its reference position
 func (at *AnchoredText) Dragged(event *fyne.DragEvent) {
+	if at.link != nil && !at.link.diagram.IsEditable() {
+		return
+	}
 	delta := fyne.Position{X: event.Dragged.DX, Y: event.Dragged.DY}
 	at.Move(at.Position().Add(delta))
 	at.Refresh()
@@ -87,7 +164,7 @@ func (at *AnchoredText) GetDisplayedTextBinding() binding.String {
 
 // GetTextEntry returns the entry widget
 func (at *AnchoredText) GetTextEntry() *widget.Entry {
-	return at.textEntry
+	return &at.textEntry.Entry
 }
 
 // MinSize returns the size of the entry widget plus a one-pixel border
@@ -118,6 +195,13 @@ func (at *AnchoredText) Move(position fyne.Position) {
 	at.BaseWidget.Move(position)
 }
 
+// SetLabelBackground sets whether a rounded background in the theme's background color is drawn behind the text,
+// which keeps the text legible where it is drawn over links.
+func (at *AnchoredText) SetLabelBackground(show bool) {
+	at.showBackground = show
+	at.Refresh()
+}
+
 // SetForegroundColor sets the text color
 func (at *AnchoredText) SetForegroundColor(fc color.Color) {
 	at.ForegroundColor = fc
@@ -133,6 +217,20 @@ func (at *AnchoredText) SetReferencePosition(position fyne.Position) {
 	at.referencePosition = position
 }
 
+// commitEdit ends editing the text, stores it in the binding and notifies the OnLabelChanged callback.
+// It does nothing if the text is not being edited.
+func (at *AnchoredText) commitEdit(text string) {
+	if !at.editing {
+		return
+	}
+	at.editing = false
+	at.displayedTextBinding.Set(text)
+	at.Refresh()
+	if at.OnLabelChanged != nil {
+		at.OnLabelChanged(text)
+	}
+}
+
 // anchoredTextRenderer
 type anchoredTextRenderer struct {
 	widget *AnchoredText
@@ -151,14 +249,42 @@ func (atr *anchoredTextRenderer) MinSize() fyne.Size {
 
 func (atr *anchoredTextRenderer) Objects() []fyne.CanvasObject {
 	canvasObjects := []fyne.CanvasObject{
+		atr.widget.background,
+		atr.widget.label,
 		atr.widget.textEntry,
 	}
 	return canvasObjects
 }
 
 func (atr *anchoredTextRenderer) Refresh() {
+	text, _ := atr.widget.displayedTextBinding.Get()
+	atr.widget.textLock.Lock()
+	atr.widget.text = text
+	atr.widget.textLock.Unlock()
+	if !atr.widget.editing && atr.widget.textEntry.Text != text {
+		atr.widget.textEntry.SetText(text)
+	}
+
 	atr.widget.Resize(atr.widget.MinSize())
 	atr.widget.textEntry.Resize(atr.widget.textEntry.MinSize())
 	atr.widget.textEntry.Move(fyne.NewPos(5, 5))
 	atr.widget.textEntry.Refresh()
+
+	// the text is shown where the entry would show it, so that it does not move when editing starts
+	label := atr.widget.label
+	label.Text = text
+	label.Color = atr.widget.ForegroundColor
+	label.TextSize = theme.TextSize()
+	entrySize := atr.widget.textEntry.Size()
+	label.Resize(label.MinSize())
+	label.Move(fyne.NewPos(5+theme.InnerPadding(), 5+(entrySize.Height-label.MinSize().Height)/2))
+	label.Hidden = atr.widget.editing
+	label.Refresh()
+	atr.widget.textEntry.Hidden = !atr.widget.editing
+
+	background := atr.widget.background
+	background.FillColor = theme.BackgroundColor()
+	background.Resize(atr.widget.Size())
+	background.Hidden = !atr.widget.showBackground
+	background.Refresh()
 }
@@ -0,0 +1,102 @@
+package diagramwidget
+
+import (
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// BackgroundStyle determines what is drawn behind the elements of the diagram
+type BackgroundStyle int
+
+// Specify the enumerated values for BackgroundStyle
+const (
+	// BackgroundNone draws nothing behind the diagram, so that the theme background shows through
+	BackgroundNone BackgroundStyle = iota
+	// BackgroundSolid fills the diagram with the background color
+	BackgroundSolid
+	// BackgroundGrid draws lines in the background color at each multiple of the spacing
+	BackgroundGrid
+	// BackgroundDots draws a dot in the background color where the lines of a grid would cross
+	BackgroundDots
+)
+
+// backgroundDotSize is the width and height of each dot of a BackgroundDots background
+const backgroundDotSize float32 = 2
+
+// diagramBackground holds the settings of the background drawn behind the diagram
+type diagramBackground struct {
+	style   BackgroundStyle
+	color   color.Color
+	spacing float32
+	// origin is a point through which the grid lines pass. It moves as the diagram is panned so that
+	// the grid stays aligned with the elements.
+	origin fyne.Position
+}
+
+// SetBackground sets what is drawn behind the elements of the diagram. For a grid or dot grid, the spacing is
+// the distance between the lines or dots, which move with the diagram when it is panned. The background is only
+// drawn and does not respond to the mouse, so events still reach the elements, pads and drawing area.
+func (dw *DiagramWidget) SetBackground(style BackgroundStyle, col color.Color, spacing float32) {
+	dw.background.style = style
+	dw.background.color = col
+	dw.background.spacing = spacing
+	dw.drawingArea.Refresh()
+}
+
+// panBackground moves the grid of the background along with the elements of the diagram
+func (dw *DiagramWidget) panBackground(delta fyne.Position) {
+	if dw.background.style != BackgroundGrid && dw.background.style != BackgroundDots {
+		return
+	}
+	dw.background.origin = dw.background.origin.Add(delta)
+	dw.drawingArea.Refresh()
+}
+
+// newBackgroundRaster returns a raster that draws the background of the diagram
+func newBackgroundRaster(dw *DiagramWidget) *canvas.Raster {
+	// the raster keeps the image type of the first pixel, so every pixel has the same color type
+	transparent := color.NRGBA{}
+	return canvas.NewRasterWithPixels(func(x, y, w, h int) color.Color {
+		bg := dw.background
+		if bg.style == BackgroundNone || bg.color == nil {
+			return transparent
+		}
+		col := color.NRGBAModel.Convert(bg.color)
+		if bg.style == BackgroundSolid {
+			return col
+		}
+
+		size := dw.drawingArea.Size()
+		if size.Width <= 0 || bg.spacing <= 0 {
+			return transparent
+		}
+		scale := float32(w) / size.Width
+		// distance in pixels past the previous grid line in each direction
+		gridX := gridRemainder(float32(x)-bg.origin.X*scale, bg.spacing*scale)
+		gridY := gridRemainder(float32(y)-bg.origin.Y*scale, bg.spacing*scale)
+		switch bg.style {
+		case BackgroundGrid:
+			if gridX < 1 || gridY < 1 {
+				return col
+			}
+		case BackgroundDots:
+			dot := backgroundDotSize * scale
+			if gridX < dot && gridY < dot {
+				return col
+			}
+		}
+		return transparent
+	})
+}
+
+// gridRemainder returns the non-negative remainder of the value divided by the spacing
+func gridRemainder(value, spacing float32) float32 {
+	remainder := float32(math.Mod(float64(value), float64(spacing)))
+	if remainder < 0 {
+		remainder += spacing
+	}
+	return remainder
+}
@@ -0,0 +1,240 @@
+package diagramwidget
+
+import (
+	"reflect"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// defaultPasteOffset is the distance by which each successive paste is displaced from the copied elements
+const defaultPasteOffset float32 = 20
+
+// CloneFunc creates a copy of the original DiagramElement in the same diagram using the supplied ID.
+// Clones of links do not need to be connected: the diagram connects pasted links to the pasted copies
+// of the elements the original link was connected to.
+type CloneFunc func(original DiagramElement, newID string) DiagramElement
+
+// diagramClipboard holds the elements captured by Copy
+type diagramClipboard struct {
+	elements   []DiagramElement
+	pasteCount int
+}
+
+// Copy captures the current selection in the diagram's internal clipboard. Links that are not selected are
+// also captured if both of their ends are connected to captured elements. Selected links that are not wholly
+// between captured elements are ignored.
+func (dw *DiagramWidget) Copy() {
+	captured := map[string]bool{}
+	for id, element := range dw.selection {
+		if element.IsNode() {
+			captured[id] = true
+		}
+	}
+	// Links may connect to other links, so keep going until no more links are captured
+	for changed := true; changed; {
+		changed = false
+		for _, link := range dw.GetDiagramLinks() {
+			id := link.GetDiagramElementID()
+			if captured[id] || !dw.isLinkBetween(link, captured) {
+				continue
+			}
+			captured[id] = true
+			changed = true
+		}
+	}
+
+	dw.clipboard = &diagramClipboard{}
+	for _, element := range dw.GetDiagramElements() {
+		if captured[element.GetDiagramElementID()] {
+			dw.clipboard.elements = append(dw.clipboard.elements, element)
+		}
+	}
+}
+
+// Paste creates clones of the elements captured by the last Copy, displaced slightly from the originals,
+// and makes them the selection. Pasted links are connected to the pasted elements rather than the originals.
+// Elements of a type for which no clone function is available are skipped, along with the links attached to them.
+func (dw *DiagramWidget) Paste() {
+	if dw.clipboard == nil || len(dw.clipboard.elements) == 0 {
+		return
+	}
+	dw.clipboard.pasteCount++
+	offset := defaultPasteOffset * float32(dw.clipboard.pasteCount)
+
+	clones := map[string]DiagramElement{}
+	pasted := []DiagramElement{}
+	// Nodes first so that the links have something to connect to
+	for _, original := range dw.clipboard.elements {
+		if !original.IsNode() {
+			continue
+		}
+		clone := dw.cloneElement(original)
+		if clone == nil {
+			continue
+		}
+		clone.Move(original.Position().AddXY(offset, offset))
+		clones[original.GetDiagramElementID()] = clone
+		pasted = append(pasted, clone)
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, original := range dw.clipboard.elements {
+			if !original.IsLink() || clones[original.GetDiagramElementID()] != nil {
+				continue
+			}
+			originalLink := original.(DiagramLink)
+			sourcePad := clonedPad(originalLink.GetSourcePad(), clones)
+			targetPad := clonedPad(originalLink.GetTargetPad(), clones)
+			if sourcePad == nil || targetPad == nil {
+				continue
+			}
+			clone, ok := dw.cloneElement(original).(DiagramLink)
+			if !ok {
+				continue
+			}
+			clone.SetSourcePad(sourcePad)
+			clone.SetTargetPad(targetPad)
+			clones[original.GetDiagramElementID()] = clone
+			pasted = append(pasted, clone)
+			changed = true
+		}
+	}
+
+	dw.ClearSelection()
+	for _, element := range pasted {
+		dw.addElementToSelection(element)
+	}
+	dw.adjustBounds()
+	dw.Refresh()
+}
+
+// RegisterCloneFunc registers the function used to clone elements of the same type as the prototype when
+// pasting. It is required for extensions of BaseDiagramNode and BaseDiagramLink. It may also be used to replace
+// the default cloning of BaseDiagramNode and BaseDiagramLink.
+func (dw *DiagramWidget) RegisterCloneFunc(prototype DiagramElement, cloneFunc CloneFunc) {
+	if dw.cloneFuncs == nil {
+		dw.cloneFuncs = map[reflect.Type]CloneFunc{}
+	}
+	dw.cloneFuncs[reflect.TypeOf(prototype)] = cloneFunc
+}
+
+func (dw *DiagramWidget) cloneElement(original DiagramElement) DiagramElement {
+	newID := dw.uniqueID(original.GetDiagramElementID())
+	if cloneFunc, ok := dw.cloneFuncs[reflect.TypeOf(original)]; ok {
+		return cloneFunc(original, newID)
+	}
+
+	switch typed := original.(type) {
+	case *imageNode:
+		return newImageNode(typed.diagram, typed.resource, typed.caption, newID)
+	case *BaseDiagramNode:
+		return cloneNode(typed, newID)
+	case *BaseDiagramLink:
+		return cloneLink(typed, newID)
+	}
+	return nil
+}
+
+// isLinkBetween returns true if both ends of the link are connected to elements in the set
+func (dw *DiagramWidget) isLinkBetween(link DiagramLink, elements map[string]bool) bool {
+	for _, pad := range []ConnectionPad{link.GetSourcePad(), link.GetTargetPad()} {
+		if pad == nil || !elements[pad.GetPadOwner().GetDiagramElementID()] {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueID returns an element ID based on the supplied one that is not in use in the diagram
+func (dw *DiagramWidget) uniqueID(base string) string {
+	for i := 1; ; i++ {
+		id := base + "-copy" + strconv.Itoa(i)
+		if dw.GetDiagramElement(id) == nil {
+			return id
+		}
+	}
+}
+
+// clonedPad returns the pad on the clone of the pad's owner that corresponds to the supplied pad
+func clonedPad(pad ConnectionPad, clones map[string]DiagramElement) ConnectionPad {
+	if pad == nil {
+		return nil
+	}
+	owner := pad.GetPadOwner()
+	clone := clones[owner.GetDiagramElementID()]
+	if clone == nil {
+		return nil
+	}
+	for key, ownerPad := range owner.GetConnectionPads() {
+		if ownerPad == pad {
+			return clone.GetConnectionPads()[key]
+		}
+	}
+	return nil
+}
+
+func cloneNode(original *BaseDiagramNode, newID string) DiagramNode {
+	var inner fyne.CanvasObject
+	if label, ok := original.innerObject.(*widget.Label); ok {
+		inner = widget.NewLabel(label.Text)
+	}
+	clone := NewDiagramNode(original.diagram, inner, newID)
+	clone.SetProperties(original.GetProperties())
+	clone.getBaseDiagramNode().InnerSize = original.InnerSize
+	clone.Refresh()
+	return clone
+}
+
+func cloneLink(original *BaseDiagramLink, newID string) DiagramLink {
+	clone := NewDiagramLink(original.diagram, newID)
+	clone.SetProperties(original.GetProperties())
+	for _, decoration := range original.SourceDecorations {
+		if d := cloneDecoration(decoration); d != nil {
+			clone.AddSourceDecoration(d)
+		}
+	}
+	for _, decoration := range original.MidpointDecorations {
+		if d := cloneDecoration(decoration); d != nil {
+			clone.AddMidpointDecoration(d)
+		}
+	}
+	for _, decoration := range original.TargetDecorations {
+		if d := cloneDecoration(decoration); d != nil {
+			clone.AddTargetDecoration(d)
+		}
+	}
+	for key, text := range original.sourceAnchoredText {
+		clone.AddSourceAnchoredText(key, text.label.Text)
+	}
+	for key, text := range original.midpointAnchoredText {
+		clone.AddMidpointAnchoredText(key, text.label.Text)
+	}
+	for key, text := range original.targetAnchoredText {
+		clone.AddTargetAnchoredText(key, text.label.Text)
+	}
+	return clone
+}
+
+// cloneDecoration copies the decorations provided by this package. Other decorations are not copied.
+func cloneDecoration(decoration Decoration) Decoration {
+	switch typed := decoration.(type) {
+	case *Arrowhead:
+		clone := NewArrowhead()
+		clone.StrokeWidth = typed.StrokeWidth
+		clone.StrokeColor = typed.StrokeColor
+		clone.Theta = typed.Theta
+		clone.Length = typed.Length
+		return clone
+	case *Polygon:
+		clone := NewPolygon(append([]fyne.Position{}, typed.definingPoints...))
+		clone.StrokeWidth = typed.StrokeWidth
+		clone.StrokeColor = typed.StrokeColor
+		clone.FillColor = typed.FillColor
+		clone.closed = typed.closed
+		clone.solid = typed.solid
+		return clone
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package diagramwidget
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// compositeMargin is the space between the children of an expanded CompositeElement and its border
+const compositeMargin float32 = 10
+
+var _ DiagramNode = (*CompositeElement)(nil)
+
+// CompositeElement is a DiagramNode that groups other nodes. When expanded it is drawn around its children
+// and moving it moves them too. When collapsed its children, and the links between them, are hidden and links
+// from its children to the rest of the diagram are connected to the composite's edge pad instead.
+type CompositeElement struct {
+	BaseDiagramNode
+	title     *widget.Label
+	children  []DiagramNode
+	collapsed bool
+	// rerouted holds the original pads of the links that were connected to the composite while collapsed
+	rerouted map[DiagramLink][2]ConnectionPad
+	// hiddenLinks holds the links between children that were hidden while collapsed
+	hiddenLinks []DiagramLink
+}
+
+// NewCompositeElement creates a CompositeElement with the title and adds it to the DiagramWidget. The nodeID
+// must be unique across all of the DiagramElements in the diagram. Children are added with AddChild.
+func NewCompositeElement(diagram *DiagramWidget, nodeID string, title string) *CompositeElement {
+	ce := &CompositeElement{title: widget.NewLabel(title)}
+	InitializeBaseDiagramNode(ce, diagram, container.NewVBox(ce.title), nodeID)
+	return ce
+}
+
+// AddChild makes the node a child of the composite. The composite is resized to enclose its children
+// and is drawn behind them.
+func (ce *CompositeElement) AddChild(child DiagramNode) {
+	ce.children = append(ce.children, child)
+	if ce.collapsed {
+		child.Hide()
+	}
+	ce.fitChildren()
+	ce.diagram.SendToBack(ce.GetDiagramElementID())
+}
+
+// Dragged moves the composite and its children together
+func (ce *CompositeElement) Dragged(event *fyne.DragEvent) {
+	if !ce.diagram.IsEditable() {
+		return
+	}
+	delta := fyne.NewPos(event.Dragged.DX, event.Dragged.DY)
+	ce.diagram.DisplaceNode(ce, delta)
+	for _, child := range ce.children {
+		ce.diagram.DisplaceNode(child, delta)
+	}
+}
+
+// GetChildren returns the child nodes of the composite
+func (ce *CompositeElement) GetChildren() []DiagramNode {
+	return ce.children
+}
+
+// IsCollapsed returns true if the children of the composite are hidden
+func (ce *CompositeElement) IsCollapsed() bool {
+	return ce.collapsed
+}
+
+// RemoveChild removes the node from the children of the composite. The node itself stays in the diagram.
+func (ce *CompositeElement) RemoveChild(child DiagramNode) {
+	for i, existing := range ce.children {
+		if existing == child {
+			ce.children = append(ce.children[:i], ce.children[i+1:]...)
+			child.Show()
+			ce.fitChildren()
+			return
+		}
+	}
+}
+
+// SetCollapsed hides or shows the children of the composite. While collapsed, the links between children are
+// hidden and the ends of links connecting children to the rest of the diagram are moved to the composite's
+// edge pad. Expanding the composite restores the children and the original link connections.
+func (ce *CompositeElement) SetCollapsed(collapsed bool) {
+	if collapsed == ce.collapsed {
+		return
+	}
+	ce.collapsed = collapsed
+	if collapsed {
+		ce.collapse()
+	} else {
+		ce.expand()
+	}
+	ce.fitChildren()
+	ce.diagram.drawingArea.Refresh()
+}
+
+func (ce *CompositeElement) collapse() {
+	inside := map[string]bool{}
+	for _, child := range ce.children {
+		inside[child.GetDiagramElementID()] = true
+		child.Hide()
+		ce.diagram.removeElementFromSelection(child)
+	}
+	// Links between children, including links connected to such links, are hidden
+	for changed := true; changed; {
+		changed = false
+		for _, link := range ce.diagram.GetDiagramLinks() {
+			id := link.GetDiagramElementID()
+			if inside[id] || !ce.diagram.isLinkBetween(link, inside) {
+				continue
+			}
+			inside[id] = true
+			link.Hide()
+			ce.diagram.removeElementFromSelection(link)
+			ce.hiddenLinks = append(ce.hiddenLinks, link)
+			changed = true
+		}
+	}
+
+	ce.rerouted = map[DiagramLink][2]ConnectionPad{}
+	for _, link := range ce.diagram.GetDiagramLinks() {
+		if inside[link.GetDiagramElementID()] {
+			continue
+		}
+		pads := [2]ConnectionPad{link.GetSourcePad(), link.GetTargetPad()}
+		for i, end := range LinkEnds {
+			if pads[i] != nil && inside[pads[i].GetPadOwner().GetDiagramElementID()] {
+				link.getBaseDiagramLink().setPadSilently(end, ce.GetEdgePad())
+				ce.rerouted[link] = pads
+			}
+		}
+	}
+}
+
+func (ce *CompositeElement) expand() {
+	for _, child := range ce.children {
+		child.Show()
+	}
+	for _, link := range ce.hiddenLinks {
+		link.Show()
+		link.Refresh()
+	}
+	ce.hiddenLinks = nil
+
+	for link, pads := range ce.rerouted {
+		for i, end := range LinkEnds {
+			if pads[i] != nil {
+				link.getBaseDiagramLink().setPadSilently(end, pads[i])
+			}
+		}
+	}
+	ce.rerouted = nil
+}
+
+// fitChildren sizes the composite to enclose its children when expanded, or to fit its title when collapsed
+func (ce *CompositeElement) fitChildren() {
+	if ce.collapsed || len(ce.children) == 0 {
+		ce.InnerSize = fyne.Size{}
+		ce.Refresh()
+		return
+	}
+
+	topLeft := ce.children[0].Position()
+	bottomRight := topLeft.Add(ce.children[0].Size())
+	for _, child := range ce.children[1:] {
+		position, size := child.Position(), child.Size()
+		topLeft = fyne.NewPos(fyne.Min(topLeft.X, position.X), fyne.Min(topLeft.Y, position.Y))
+		bottomRight = fyne.NewPos(fyne.Max(bottomRight.X, position.X+size.Width), fyne.Max(bottomRight.Y, position.Y+size.Height))
+	}
+
+	padding := float32(ce.properties.Padding)
+	titleHeight := ce.title.MinSize().Height
+	ce.InnerSize = fyne.NewSize(
+		bottomRight.X-topLeft.X+2*compositeMargin,
+		bottomRight.Y-topLeft.Y+2*compositeMargin+titleHeight)
+	ce.Move(topLeft.SubtractXY(padding+compositeMargin, padding+compositeMargin+titleHeight))
+	ce.diagram.adjustBounds()
+}
@@ -24,23 +24,38 @@ type ConnectionPad interface {
 	getConnectionPointInDiagramCoordinates(referencePoint fyne.Position) fyne.Position
 	MouseDown(*desktop.MouseEvent)
 	MouseUp(*desktop.MouseEvent)
+	SetOnHoverChanged(func(hovered bool))
 	SetPadColor(color.Color)
 }
 
 type connectionPad struct {
-	padOwner  DiagramElement
-	lineWidth float32
-	padColor  color.Color
+	padOwner       DiagramElement
+	lineWidth      float32
+	padColor       color.Color
+	onHoverChanged func(hovered bool)
 }
 
 func (cp *connectionPad) GetPadOwner() DiagramElement {
 	return cp.padOwner
 }
 
+// hoverChanged invokes the hover callback, if present
+func (cp *connectionPad) hoverChanged(hovered bool) {
+	if cp.onHoverChanged != nil {
+		cp.onHoverChanged(hovered)
+	}
+}
+
+// SetOnHoverChanged sets a function to be called when the mouse enters or leaves the pad,
+// for example to show a description of the pad while a connection is being made
+func (cp *connectionPad) SetOnHoverChanged(hoverChanged func(hovered bool)) {
+	cp.onHoverChanged = hoverChanged
+}
+
 // MouseDown responds to mouse down events
 func (pp *PointPad) MouseDown(event *desktop.MouseEvent) {
 	connectionTransaction := pp.padOwner.GetDiagram().ConnectionTransaction
-	if connectionTransaction != nil {
+	if connectionTransaction != nil && pp.padOwner.GetDiagram().IsEditable() {
 		link := connectionTransaction.Link
 		if link.isConnectionAllowed(connectionTransaction.LinkPoint, pp) {
 			padOwnerPosition := pp.padOwner.Position()
@@ -63,7 +78,7 @@ func (pp *PointPad) MouseDown(event *desktop.MouseEvent) {
 // MouseDown responds to mouse down events
 func (rp *RectanglePad) MouseDown(event *desktop.MouseEvent) {
 	connectionTransaction := rp.padOwner.GetDiagram().ConnectionTransaction
-	if connectionTransaction != nil {
+	if connectionTransaction != nil && rp.padOwner.GetDiagram().IsEditable() {
 		link := connectionTransaction.Link
 		if link.isConnectionAllowed(connectionTransaction.LinkPoint, rp) {
 			padOwnerPosition := rp.padOwner.Position()
@@ -101,6 +116,9 @@ var _ ConnectionPad = (*PointPad)(nil)
 type PointPad struct {
 	widget.BaseWidget
 	connectionPad
+	// relativePosition is the center of the pad set by SetRelativePosition, before the owner is rotated
+	relativePosition fyne.Position
+	relative         bool
 }
 
 // NewPointPad creates a PointPad and associates it with the DiagramElement. Note that, by default,
@@ -126,6 +144,15 @@ func (pp *PointPad) CreateRenderer() fyne.WidgetRenderer {
 	return ppr
 }
 
+// Cursor returns the crosshair cursor to show that links can be connected to the pad, unless the diagram
+// cannot be edited
+func (pp *PointPad) Cursor() desktop.Cursor {
+	if !pp.padOwner.GetDiagram().IsEditable() {
+		return desktop.DefaultCursor
+	}
+	return desktop.CrosshairCursor
+}
+
 // GetCenterInDiagramCoordinates returns the position in diagram coordinates
 func (pp *PointPad) GetCenterInDiagramCoordinates() fyne.Position {
 	return pp.padOwner.Position().Add(pp.Position().Add(fyne.NewPos(pointPadSize/2, pointPadSize/2)))
@@ -140,13 +167,14 @@ func (pp *PointPad) getConnectionPointInDiagramCoordinates(referencePoint fyne.P
 // MouseIn responds to mouse movements within the pointPadSize distance of the center
 func (pp *PointPad) MouseIn(event *desktop.MouseEvent) {
 	conTrans := pp.padOwner.GetDiagram().ConnectionTransaction
-	if conTrans != nil && conTrans.Link.isConnectionAllowed(conTrans.LinkPoint, pp) {
+	if conTrans != nil && pp.padOwner.GetDiagram().IsEditable() && conTrans.Link.isConnectionAllowed(conTrans.LinkPoint, pp) {
 		pp.padColor = pp.padOwner.GetProperties().PadColor
 		conTrans.PendingPad = pp
 	} else {
 		pp.padColor = color.Transparent
 	}
 	pp.Refresh()
+	pp.hoverChanged(true)
 }
 
 // MouseMoved responds to mouse movements within the pointPadSize distance of the center
@@ -161,6 +189,26 @@ func (pp *PointPad) MouseOut() {
 		conTrans.PendingPad = nil
 	}
 	pp.Refresh()
+	pp.hoverChanged(false)
+}
+
+// SetRelativePosition places the center of the pad, which is its connection point, at the indicated
+// position relative to the origin of the pad owner. The pad turns with the owner when it is rotated.
+// Links connected to the pad are updated immediately.
+func (pp *PointPad) SetRelativePosition(position fyne.Position) {
+	pp.relativePosition = position
+	pp.relative = true
+	pp.place()
+	pp.Refresh()
+	pp.padOwner.GetDiagram().refreshDependentLinks(pp.padOwner)
+}
+
+// place moves the pad to its relative position, rotated with the owner
+func (pp *PointPad) place() {
+	ownerSize := pp.padOwner.Size()
+	center := rotateAbout(pp.relativePosition, fyne.NewPos(ownerSize.Width/2, ownerSize.Height/2), pp.padOwner.GetRotation())
+	pp.Resize(fyne.NewSize(pointPadSize, pointPadSize))
+	pp.Move(center.SubtractXY(pointPadSize/2, pointPadSize/2))
 }
 
 // SetPadColor sets the color to be used in rendering the pad
@@ -219,6 +267,8 @@ var _ ConnectionPad = (*RectanglePad)(nil)
 type RectanglePad struct {
 	widget.BaseWidget
 	connectionPad
+	// hovered is true while the mouse is over the pad, which is within its rotated outline if the owner is rotated
+	hovered bool
 }
 
 // NewRectanglePad creates a RectanglePad and associates it with the DiagramElement. The size of the
@@ -238,6 +288,9 @@ func (rp *RectanglePad) CreateRenderer() fyne.WidgetRenderer {
 		rp:   rp,
 		rect: *canvas.NewRectangle(rp.padColor),
 	}
+	for i := range rpr.outline {
+		rpr.outline[i] = canvas.NewLine(rp.padColor)
+	}
 	rpr.rect.StrokeWidth = rp.padOwner.GetProperties().PadStrokeWidth
 	return rpr
 }
@@ -246,15 +299,21 @@ func (rp *RectanglePad) CreateRenderer() fyne.WidgetRenderer {
 func (rp *RectanglePad) GetCenterInDiagramCoordinates() fyne.Position {
 	box := rp.makeBox()
 	r2Center := box.Center()
-	return fyne.NewPos(float32(r2Center.X), float32(r2Center.Y))
+	return rotateAbout(fyne.NewPos(float32(r2Center.X), float32(r2Center.Y)), elementCenter(rp.padOwner), rp.padOwner.GetRotation())
 }
 
 // getConnectionPointInDiagramCoordinates returns the point at which the connection should be made from a reference point.
 // The reference point is in diagram coordinates and the returned point is also in diagram coordinates.
 // For a RectanglePad this point is the intersection of a line segment from the reference point to the center
 // of the rectangle pad and the rectangle bounding the pad. If the reference point is within the bounds of the rectangle,
-// the returned point is the point on the perimeter that is nearest the reference point.
+// the returned point is the point on the perimeter that is nearest the reference point. If the owner of the pad
+// is rotated, the rectangle is rotated with it.
 func (rp *RectanglePad) getConnectionPointInDiagramCoordinates(referencePoint fyne.Position) fyne.Position {
+	// the connection point is found in the frame of the unrotated owner and then rotated back
+	rotation := rp.padOwner.GetRotation()
+	ownerCenter := elementCenter(rp.padOwner)
+	referencePoint = rotateAbout(referencePoint, ownerCenter, -rotation)
+
 	var connectionPoint r2.Vec2
 	box := rp.makeBox()
 	r2ReferencePoint := r2.MakeVec2(float64(referencePoint.X), float64(referencePoint.Y))
@@ -264,7 +323,20 @@ func (rp *RectanglePad) getConnectionPointInDiagramCoordinates(referencePoint fy
 		linkLine := r2.MakeLineFromEndpoints(box.Center(), r2ReferencePoint)
 		connectionPoint, _ = box.Intersect(linkLine)
 	}
-	return fyne.NewPos(float32(connectionPoint.X), float32(connectionPoint.Y))
+	return rotateAbout(fyne.NewPos(float32(connectionPoint.X), float32(connectionPoint.Y)), ownerCenter, rotation)
+}
+
+// Cursor returns the crosshair cursor while a connection is being made to show that the link can be connected to
+// the pad. Otherwise the pad, which usually covers its owner, shows the cursor of the owner.
+func (rp *RectanglePad) Cursor() desktop.Cursor {
+	diagram := rp.padOwner.GetDiagram()
+	if diagram.ConnectionTransaction != nil && diagram.IsEditable() {
+		return desktop.CrosshairCursor
+	}
+	if owner, ok := rp.padOwner.(desktop.Cursorable); ok {
+		return owner.Cursor()
+	}
+	return desktop.DefaultCursor
 }
 
 // makeBox returns an r2 box representing the rectangle pad's position and size in the
@@ -279,10 +351,21 @@ func (rp *RectanglePad) makeBox() r2.Box {
 	return r2.MakeBox(r2Position, s)
 }
 
-// MouseIn responds to the mouse entering the bounds of the RectanglePad
+// containsPoint returns true if the position relative to the pad lies within it, taking the rotation of the
+// owner into account
+func (rp *RectanglePad) containsPoint(position fyne.Position) bool {
+	rotation := rp.padOwner.GetRotation()
+	return rotation == 0 || inRotatedRectangle(position.Add(rp.Position()), rp.padOwner.Size(), rotation)
+}
+
+// MouseIn responds to the mouse entering the bounds of the RectanglePad, which are rotated with the owner
 func (rp *RectanglePad) MouseIn(event *desktop.MouseEvent) {
+	if !rp.containsPoint(event.Position) {
+		return
+	}
+	rp.hovered = true
 	conTrans := rp.padOwner.GetDiagram().ConnectionTransaction
-	if conTrans != nil && conTrans.Link.isConnectionAllowed(conTrans.LinkPoint, rp) {
+	if conTrans != nil && rp.padOwner.GetDiagram().IsEditable() && conTrans.Link.isConnectionAllowed(conTrans.LinkPoint, rp) {
 		rp.padColor = rp.padOwner.GetProperties().PadColor
 		conTrans.PendingPad = rp
 		rp.Show()
@@ -290,20 +373,29 @@ func (rp *RectanglePad) MouseIn(event *desktop.MouseEvent) {
 		rp.padColor = color.Transparent
 	}
 	rp.Refresh()
+	rp.hoverChanged(true)
 }
 
-// MouseMoved responds to mouse movements within the rectangle pad
+// MouseMoved responds to the mouse entering or leaving the rotated outline of the rectangle pad
 func (rp *RectanglePad) MouseMoved(event *desktop.MouseEvent) {
+	inside := rp.containsPoint(event.Position)
+	if inside && !rp.hovered {
+		rp.MouseIn(event)
+	} else if !inside && rp.hovered {
+		rp.MouseOut()
+	}
 }
 
 // MouseOut responds to mouse movements leaving the rectangle pad
 func (rp *RectanglePad) MouseOut() {
+	rp.hovered = false
 	rp.padColor = color.Transparent
 	conTrans := rp.padOwner.GetDiagram().ConnectionTransaction
 	if conTrans != nil && conTrans.PendingPad == rp {
 		conTrans.PendingPad = nil
 	}
 	rp.Refresh()
+	rp.hoverChanged(false)
 }
 
 // SetPadColor sets the color to be used in rendering the pad
@@ -316,6 +408,8 @@ func (rp *RectanglePad) SetPadColor(c color.Color) {
 type rectanglePadRenderer struct {
 	rp   *RectanglePad
 	rect canvas.Rectangle
+	// outline draws the sides of the pad instead of the rectangle when the owner is rotated
+	outline [4]*canvas.Line
 }
 
 func (rpr *rectanglePadRenderer) Destroy() {
@@ -336,12 +430,29 @@ func (rpr *rectanglePadRenderer) Objects() []fyne.CanvasObject {
 	obj := []fyne.CanvasObject{
 		&rpr.rect,
 	}
+	for _, line := range rpr.outline {
+		obj = append(obj, line)
+	}
 	return obj
 }
 
 func (rpr *rectanglePadRenderer) Refresh() {
+	rotation := rpr.rp.padOwner.GetRotation()
 	rpr.rect.StrokeColor = rpr.rp.padColor
 	rpr.rect.FillColor = color.Transparent
 	rpr.rect.StrokeWidth = rpr.rp.lineWidth
+	rpr.rect.Hidden = rotation != 0
 	rpr.rect.Refresh()
+
+	// the corners are rotated about the center of the owner, relative to the pad
+	ownerSize := rpr.rp.padOwner.Size()
+	corners := rotatedCorners(ownerSize, rotation)
+	for i, line := range rpr.outline {
+		line.Position1 = corners[i].Subtract(rpr.rp.Position())
+		line.Position2 = corners[(i+1)%len(corners)].Subtract(rpr.rp.Position())
+		line.StrokeColor = rpr.rp.padColor
+		line.StrokeWidth = rpr.rp.lineWidth
+		line.Hidden = rotation == 0
+		line.Refresh()
+	}
 }
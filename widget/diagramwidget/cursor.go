@@ -0,0 +1,64 @@
+package diagramwidget
+
+import (
+	"image"
+	"image/color"
+
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// moveCursorSize is the width and height of the move cursor image
+const moveCursorSize = 23
+
+// moveCursor is shown over elements that can be dragged to move them. Fyne has no standard move cursor,
+// so it draws the usual four headed arrow.
+var moveCursor desktop.Cursor = moveCursorImage{}
+
+// moveCursorImage draws a black four headed arrow with a white outline
+type moveCursorImage struct{}
+
+// Image returns the image of the cursor and the position of its hot spot, which is the center of the arrows.
+func (moveCursorImage) Image() (image.Image, int, int) {
+	img := image.NewNRGBA(image.Rect(0, 0, moveCursorSize, moveCursorSize))
+	for y := 0; y < moveCursorSize; y++ {
+		for x := 0; x < moveCursorSize; x++ {
+			if inMoveArrows(x, y) {
+				img.Set(x, y, color.Black)
+			} else if inMoveArrows(x-1, y) || inMoveArrows(x+1, y) || inMoveArrows(x, y-1) || inMoveArrows(x, y+1) {
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+	center := moveCursorSize / 2
+	return img, center, center
+}
+
+// inMoveArrows returns true if the pixel is part of the arrows, which leave a pixel free at the edges for the outline
+func inMoveArrows(x, y int) bool {
+	center := moveCursorSize / 2
+	length := center - 1
+	headLength := 4
+	dx, dy := x-center, y-center
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	inArrow := func(along, across int) bool {
+		if along <= length-headLength {
+			return across <= 1
+		}
+		return along <= length && across <= length-along
+	}
+	return inArrow(dy, dx) || inArrow(dx, dy)
+}
+
+// cursorFor returns the cursor shown over an element that can be dragged, which is the move cursor unless the
+// diagram cannot be edited
+func cursorFor(diagram *DiagramWidget) desktop.Cursor {
+	if diagram == nil || !diagram.IsEditable() {
+		return desktop.DefaultCursor
+	}
+	return moveCursor
+}
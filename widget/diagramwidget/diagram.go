@@ -2,16 +2,34 @@ package diagramwidget
 
 import (
 	"container/list"
+	"errors"
 	"image/color"
 	"math"
+	"reflect"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
+// defaultEditAnimationDuration is the time taken to fade links in and out when edit animations are enabled
+const defaultEditAnimationDuration = 300 * time.Millisecond
+
+// startEditAnimation starts an animation fading a link in or out, tests replace it to step through the animation
+var startEditAnimation = (*fyne.Animation).Start
+
+// ErrConnectionNotAllowed is returned when a link cannot be connected to a pad, either because the
+// IsConnectionAllowedCallback rejects the connection or because the diagram is not editable
+var ErrConnectionNotAllowed = errors.New("the connection is not allowed")
+
+// ErrNoConnectionInProgress is returned when a connection is completed without one having been begun
+var ErrNoConnectionInProgress = errors.New("no connection is in progress")
+
 // Verify that interfaces are fully implemented
 var _ fyne.Tappable = (*drawingArea)(nil)
 
@@ -67,6 +85,8 @@ type DiagramWidget struct {
 	MouseOutCallback func()
 	// MouseUpCallback is invoked when a MouseUp occurs in the diagram
 	MouseUpCallback func(*desktop.MouseEvent)
+	// OnResizedCallback is called after a node has been resized by dragging one of its handles
+	OnResizedCallback func(DiagramElement)
 	// OnTappedCallback is called when the diagram background is tapped. If present, it overrides the default
 	// diagram behavior for Tapped()
 	OnTappedCallback func(*DiagramWidget, *fyne.PointEvent)
@@ -76,6 +96,52 @@ type DiagramWidget struct {
 	// an element that is not currently selected is tapped. When true, the new element is added to the selection.
 	// When false, the selection is cleared and the new element is made the only selected element.
 	ElementTappedExtendsSelection bool
+	// AllowLinkReconnection determines whether dragging the handle at a connected end of a link can move that
+	// end to a different pad. Dropping the end anywhere other than a valid pad restores the original connection.
+	// Defaults to true.
+	AllowLinkReconnection bool
+	// hoverHighlightConnected determines whether hovering over a node emphasizes the node and its
+	// connections while dimming the rest of the diagram
+	hoverHighlightConnected bool
+	// readOnly prevents the user from editing or rearranging the diagram
+	readOnly bool
+	// dropSnapRadius is the distance within which a dropped link end connects to the nearest pad
+	dropSnapRadius float32
+	// editAnimationsEnabled determines whether links fade in when created and fade out when removed
+	editAnimationsEnabled bool
+	editAnimationDuration time.Duration
+	// fadingLinks holds the links that have been removed but are still being faded out. It is guarded by
+	// fadeLock as the fade ends on the animation goroutine.
+	fadeLock    sync.Mutex
+	fadingLinks []DiagramLink
+	// tooltip is shown when the mouse rests on an element that has a tooltip
+	tooltip *diagramTooltip
+	// clipboard holds the elements captured by Copy
+	clipboard *diagramClipboard
+	// cloneFuncs holds the registered functions used to clone elements when pasting, indexed by type
+	cloneFuncs map[reflect.Type]CloneFunc
+	// linkCrossingStyle determines how links are drawn where they cross each other
+	linkCrossingStyle LinkCrossingStyle
+	// parallelLinkSpacing is the distance between links connecting the same pair of pads
+	parallelLinkSpacing float32
+	// elementIndex records the bounds of the elements so that pads near a point can be found quickly
+	elementIndex *spatialIndex
+	// background determines what is drawn behind the elements
+	background diagramBackground
+	// guides snap dragged nodes into alignment with the other nodes
+	guides *alignmentGuides
+	// linkHitTolerance is how far beyond the stroke of a link a tap still selects the link
+	linkHitTolerance float32
+	// selectionListeners are notified of every change of the selection, including those made without invoking
+	// the PrimaryDiagramElementSelectionChangedCallback
+	selectionListeners []func()
+	// inspectorFields returns the application's rows shown by the inspectors for an element
+	inspectorFields func(DiagramElement) []*widget.FormItem
+	// themeColors are the application theme colors from which the default colors were last derived
+	themeColors themeColors
+	// batchDepth counts the nested calls of BatchUpdate in progress. While it is positive the elements are
+	// not refreshed and the bounds of the diagram are not recalculated
+	batchDepth int
 }
 
 // NewDiagramWidget creates a DiagramWidget. The user-supplied ID can be used to map the diagram
@@ -91,21 +157,27 @@ func NewDiagramWidget(id string) *DiagramWidget {
 		selection:                      map[string]DiagramElement{},
 		diagramElementLinkDependencies: map[string][]linkPadPair{},
 	}
+	dw.AllowLinkReconnection = true
+	dw.editAnimationDuration = defaultEditAnimationDuration
+	dw.linkHitTolerance = defaultLinkHitTolerance
+	dw.tooltip = newDiagramTooltip()
+	dw.guides = newAlignmentGuides()
+	dw.elementIndex = newSpatialIndex()
 	dw.drawingArea = newDrawingArea(dw)
 	dw.drawingArea.Resize(dw.DesiredSize)
 	dw.scrollingContainer = container.NewScroll(dw.drawingArea)
 	appTheme := fyne.CurrentApp().Settings().Theme()
-	appVariant := fyne.CurrentApp().Settings().ThemeVariant()
-	dw.DefaultDiagramElementProperties.ForegroundColor = appTheme.Color(theme.ColorNameForeground, appVariant)
-	dw.DefaultDiagramElementProperties.HandleColor = appTheme.Color(theme.ColorNameForeground, appVariant)
-	dw.DefaultDiagramElementProperties.BackgroundColor = appTheme.Color(theme.ColorNameBackground, appVariant)
+	dw.themeColors = currentThemeColors()
+	dw.DefaultDiagramElementProperties.ForegroundColor = dw.themeColors.foreground
+	dw.DefaultDiagramElementProperties.HandleColor = dw.themeColors.foreground
+	dw.DefaultDiagramElementProperties.BackgroundColor = dw.themeColors.background
 	dw.DefaultDiagramElementProperties.TextSize = 12
 	dw.DefaultDiagramElementProperties.CaptionTextSize = appTheme.Size(theme.SizeNameCaptionText)
 	dw.DefaultDiagramElementProperties.Padding = appTheme.Size(theme.SizeNamePadding)
 	dw.DefaultDiagramElementProperties.StrokeWidth = 1
 	dw.DefaultDiagramElementProperties.HandleStrokeWidth = 1
 	dw.DefaultDiagramElementProperties.PadStrokeWidth = 3
-	dw.DefaultDiagramElementProperties.PadColor = color.RGBA{121, 237, 119, 255}
+	dw.DefaultDiagramElementProperties.PadColor = dw.themeColors.primary
 
 	dw.ExtendBaseWidget(dw)
 
@@ -123,16 +195,45 @@ func (dw *DiagramWidget) addElementToSelection(de DiagramElement) {
 		}
 		dw.selection[de.GetDiagramElementID()] = de
 		de.ShowHandles()
+		dw.notifySelectionListeners()
 	}
 }
 
-// addLink adds a link to the diagram
-func (dw *DiagramWidget) addLink(link DiagramLink) {
+// BatchUpdate calls update, suspending the refresh of the elements and the recalculation of the bounds of the
+// diagram until it returns, after which the diagram is refreshed once. This makes adding or changing many elements
+// at a time, such as when loading a large diagram, much faster. Calls may be nested, the diagram is refreshed when
+// the outermost one returns.
+func (dw *DiagramWidget) BatchUpdate(update func()) {
+	dw.batchDepth++
+	defer func() {
+		dw.batchDepth--
+		if dw.batchDepth == 0 {
+			dw.Refresh()
+			dw.adjustBounds()
+		}
+	}()
+	update()
+}
+
+// addLink adds a link to the diagram, refusing it if its ID is already in use
+func (dw *DiagramWidget) addLink(link DiagramLink) error {
+	if err := dw.checkUniqueID(link); err != nil {
+		return err
+	}
 	dw.DiagramElements.PushBack(link)
+	dw.elementIndex.insert(baseElement(link), link.Position(), link.Size())
+	if dw.editAnimationsEnabled {
+		dw.animateLink(link, true, nil)
+	}
 	link.Refresh()
+	return nil
 }
 
 func (dw *DiagramWidget) addLinkDependency(diagramElement DiagramElement, link *BaseDiagramLink, pad ConnectionPad) {
+	if !link.inDiagram() {
+		// a link refused because of a duplicate ID must not be confused with the link that has the ID
+		return
+	}
 	deID := diagramElement.GetDiagramElementID()
 	currentDependencies := dw.diagramElementLinkDependencies[deID]
 	if currentDependencies == nil {
@@ -148,16 +249,24 @@ func (dw *DiagramWidget) addLinkDependency(diagramElement DiagramElement, link *
 	}
 }
 
-// addNode adds a node to the diagram
-func (dw *DiagramWidget) addNode(node DiagramNode) {
+// addNode adds a node to the diagram, refusing it if its ID is already in use
+func (dw *DiagramWidget) addNode(node DiagramNode) error {
+	if err := dw.checkUniqueID(node); err != nil {
+		return err
+	}
 	dw.DiagramElements.PushBack(node)
+	dw.elementIndex.insert(baseElement(node), node.Position(), node.Size())
 	dw.adjustBounds()
 	node.Refresh()
+	return nil
 }
 
 // adjustBounds calculates the bounds of the diagram elements and adjusts the size of the drawing area accordingly
 // If necessary, it also moves all the diagram elements so that their position coordinates are all positive
 func (dw *DiagramWidget) adjustBounds() {
+	if dw.batchDepth > 0 {
+		return
+	}
 	position := dw.drawingArea.Position()
 	size := dw.drawingArea.Size()
 	left := position.X
@@ -206,6 +315,31 @@ func (dw *DiagramWidget) adjustBounds() {
 	dw.scrollingContainer.Refresh()
 }
 
+// animateLink fades the link in or out over the edit animation duration. The link ignores user
+// interaction until the animation completes, after which the done function is called if present.
+func (dw *DiagramWidget) animateLink(link DiagramLink, appearing bool, done func()) {
+	bdl := link.getBaseDiagramLink()
+	bdl.fadeLock.Lock()
+	bdl.animating = true
+	if appearing {
+		bdl.opacity = 0
+	}
+	bdl.fadeLock.Unlock()
+	startEditAnimation(fyne.NewAnimation(dw.editAnimationDuration, func(progress float32) {
+		opacity := progress
+		if !appearing {
+			opacity = 1 - progress
+		}
+		bdl.setFade(opacity, progress < 1)
+		bdl.Refresh()
+		if progress >= 1 {
+			if done != nil {
+				done()
+			}
+		}
+	}))
+}
+
 // BringToFront moves the diagram element to the top of the display list (which is the back of the DiagramElements list)
 func (dw *DiagramWidget) BringToFront(elementID string) {
 	for listElement := dw.DiagramElements.Front(); listElement != nil; listElement = listElement.Next() {
@@ -238,6 +372,21 @@ func (dw *DiagramWidget) CreateRenderer() fyne.WidgetRenderer {
 	return &r
 }
 
+// clearHighlight restores the normal rendering of all diagram elements
+func (dw *DiagramWidget) clearHighlight() {
+	for _, element := range dw.GetDiagramElements() {
+		element.setDimmed(false)
+	}
+}
+
+// checkUniqueID returns ErrDuplicateID if an element being added to the diagram has the same ID as an existing one
+func (dw *DiagramWidget) checkUniqueID(de DiagramElement) error {
+	if dw.GetDiagramElement(de.GetDiagramElementID()) != nil {
+		return ErrDuplicateID
+	}
+	return nil
+}
+
 // ClearSelection clears the selection and invokes the PrimaryDiagramElementSelectionChangedCallback
 func (dw *DiagramWidget) ClearSelection() {
 	for _, de := range dw.selection {
@@ -253,6 +402,7 @@ func (dw *DiagramWidget) ClearSelectionNoCallback() {
 		element.HideHandles()
 	}
 	dw.selection = map[string]DiagramElement{}
+	dw.notifySelectionListeners()
 }
 
 // Cursor returns the default cursor
@@ -274,9 +424,17 @@ func (dw *DiagramWidget) DiagramElementTapped(de DiagramElement) {
 // to it
 func (dw *DiagramWidget) DiagramNodeDragged(node *BaseDiagramNode, event *fyne.DragEvent) {
 	delta := fyne.Position{X: event.Dragged.DX, Y: event.Dragged.DY}
+	if dw.guides.enabled {
+		delta = dw.alignedPosition(node, delta).Subtract(node.Position())
+	}
 	dw.DisplaceNode(node, delta)
 }
 
+// DiagramNodeDragEnded hides the alignment guides shown while dragging the node
+func (dw *DiagramWidget) DiagramNodeDragEnded(node *BaseDiagramNode) {
+	dw.hideAlignmentGuides()
+}
+
 // DisplaceNode moves the indicated node, refreshes any links that may be attached
 // to it, and adjusts the bounds of the drawing area
 func (dw *DiagramWidget) DisplaceNode(node DiagramNode, delta fyne.Position) {
@@ -285,6 +443,87 @@ func (dw *DiagramWidget) DisplaceNode(node DiagramNode, delta fyne.Position) {
 	dw.adjustBounds()
 }
 
+// fadeOutLink keeps displaying a removed link while it fades out
+func (dw *DiagramWidget) fadeOutLink(link DiagramLink) {
+	dw.fadeLock.Lock()
+	dw.fadingLinks = append(dw.fadingLinks, link)
+	dw.fadeLock.Unlock()
+	dw.animateLink(link, false, func() {
+		dw.fadeLock.Lock()
+		for i, fading := range dw.fadingLinks {
+			if fading == link {
+				dw.fadingLinks = append(dw.fadingLinks[:i], dw.fadingLinks[i+1:]...)
+				break
+			}
+		}
+		dw.fadeLock.Unlock()
+		dw.drawingArea.Refresh()
+	})
+}
+
+// findNearestPad returns the pad nearest to the position (in diagram coordinates) that lies within the drop
+// snap radius and accepts a connection from the link point. Point pads, which are the fixed ports of an element,
+// are preferred to the outline of an element, as a drop near a port is meant for it. It returns nil if there is
+// no such pad.
+func (dw *DiagramWidget) findNearestPad(linkPoint *LinkPoint, position fyne.Position) ConnectionPad {
+	if dw.dropSnapRadius <= 0 {
+		return nil
+	}
+	var nearest, nearestPort ConnectionPad
+	nearestDistance := float64(dw.dropSnapRadius)
+	nearestPortDistance := nearestDistance
+	candidates := dw.elementIndex.near(position, dw.dropSnapRadius)
+	// only the elements near the position are checked, but they are checked in display order so that
+	// the topmost of two equally near pads is chosen
+	for listElement := dw.DiagramElements.Front(); listElement != nil; listElement = listElement.Next() {
+		element := listElement.Value.(DiagramElement)
+		if !candidates[baseElement(element)] || element.GetDiagramElementID() == linkPoint.GetLink().GetDiagramElementID() {
+			continue
+		}
+		for _, pad := range element.GetConnectionPads() {
+			connectionPoint := pad.getConnectionPointInDiagramCoordinates(position)
+			distance := math.Hypot(float64(connectionPoint.X-position.X), float64(connectionPoint.Y-position.Y))
+			if _, isPort := pad.(*PointPad); isPort {
+				if distance <= nearestPortDistance && linkPoint.IsConnectionAllowed(pad) {
+					nearestPort = pad
+					nearestPortDistance = distance
+				}
+			} else if distance <= nearestDistance && linkPoint.IsConnectionAllowed(pad) {
+				nearest = pad
+				nearestDistance = distance
+			}
+		}
+	}
+	if nearestPort != nil {
+		return nearestPort
+	}
+	return nearest
+}
+
+// findNearestPort returns the point pad of the element nearest to the position (in diagram coordinates) that
+// accepts a connection from the link point, so that a link dropped on the outline of an element next to one of its
+// ports connects to the port. The port must lie within the drop snap radius, or within the size of a point pad
+// if that is larger. It returns nil if there is no such pad.
+func (dw *DiagramWidget) findNearestPort(linkPoint *LinkPoint, position fyne.Position, element DiagramElement) ConnectionPad {
+	var nearest ConnectionPad
+	nearestDistance := float64(dw.dropSnapRadius)
+	if nearestDistance < float64(pointPadSize) {
+		nearestDistance = float64(pointPadSize)
+	}
+	for _, pad := range element.GetConnectionPads() {
+		if _, isPort := pad.(*PointPad); !isPort {
+			continue
+		}
+		center := pad.GetCenterInDiagramCoordinates()
+		distance := math.Hypot(float64(center.X-position.X), float64(center.Y-position.Y))
+		if distance <= nearestDistance && linkPoint.IsConnectionAllowed(pad) {
+			nearest = pad
+			nearestDistance = distance
+		}
+	}
+	return nearest
+}
+
 // GetBackgroundColor returns the background color for the widget from the diagram's theme, which
 // may be different from the application's theme.
 func (dw *DiagramWidget) GetBackgroundColor() color.Color {
@@ -320,6 +559,12 @@ func (dw *DiagramWidget) GetDiagramElements() []DiagramElement {
 	return diagramElements
 }
 
+// GetElementByID returns the node or link with the indicated ID, or nil if there is none.
+// It is equivalent to GetDiagramElement.
+func (dw *DiagramWidget) GetElementByID(id string) DiagramElement {
+	return dw.GetDiagramElement(id)
+}
+
 // GetDiagramLink returns the diagram link with the indicated ID
 func (dw *DiagramWidget) GetDiagramLink(id string) DiagramLink {
 	{
@@ -343,6 +588,12 @@ func (dw *DiagramWidget) GetDiagramLinks() []DiagramLink {
 	return diagramLinks
 }
 
+// GetLinkByID returns the link with the indicated ID, or nil if there is no link with that ID.
+// It is equivalent to GetDiagramLink.
+func (dw *DiagramWidget) GetLinkByID(id string) DiagramLink {
+	return dw.GetDiagramLink(id)
+}
+
 // GetDiagramNode returns the diagram node with the indicated ID
 func (dw *DiagramWidget) GetDiagramNode(id string) DiagramNode {
 	{
@@ -371,6 +622,26 @@ func (dw *DiagramWidget) GetPrimarySelection() DiagramElement {
 	return dw.primarySelection
 }
 
+// highlightConnected dims every diagram element except the indicated one, its incident links, and
+// the elements at the other end of those links. It does nothing unless hover highlighting is enabled.
+func (dw *DiagramWidget) highlightConnected(de DiagramElement) {
+	if !dw.hoverHighlightConnected {
+		return
+	}
+	highlighted := map[string]bool{de.GetDiagramElementID(): true}
+	for _, pair := range dw.diagramElementLinkDependencies[de.GetDiagramElementID()] {
+		highlighted[pair.link.id] = true
+		for _, pad := range []ConnectionPad{pair.link.sourcePad, pair.link.targetPad} {
+			if pad != nil {
+				highlighted[pad.GetPadOwner().GetDiagramElementID()] = true
+			}
+		}
+	}
+	for _, element := range dw.GetDiagramElements() {
+		element.setDimmed(!highlighted[element.GetDiagramElementID()])
+	}
+}
+
 // hideAllPads is a work-around for fyne Issue #3906 in which a child's Hoverable interface
 // (i.e. the pad) masks the parent's Tappable interface. This function (and all references to
 // it) should be removed when this issue has been resolved
@@ -383,6 +654,11 @@ func (dw *DiagramWidget) hideAllPads() {
 	}
 }
 
+// IsEditable returns true if the user can edit and rearrange the diagram
+func (dw *DiagramWidget) IsEditable() bool {
+	return !dw.readOnly
+}
+
 // IsSelected returns true if the indicated element is currently part of the selection
 func (dw *DiagramWidget) IsSelected(de DiagramElement) bool {
 	return dw.selection[de.GetDiagramElementID()] != nil
@@ -424,6 +700,7 @@ func (dw *DiagramWidget) removeElementFromSelection(de DiagramElement) {
 			}
 		}
 		de.HideHandles()
+		dw.notifySelectionListeners()
 	}
 }
 
@@ -448,6 +725,18 @@ func (dw *DiagramWidget) refreshDependentLinks(de DiagramElement) {
 	}
 }
 
+// renameElement updates the diagram's records of an element whose ID is changing
+func (dw *DiagramWidget) renameElement(oldID, newID string) {
+	if element, ok := dw.selection[oldID]; ok {
+		delete(dw.selection, oldID)
+		dw.selection[newID] = element
+	}
+	if dependencies, ok := dw.diagramElementLinkDependencies[oldID]; ok {
+		delete(dw.diagramElementLinkDependencies, oldID)
+		dw.diagramElementLinkDependencies[newID] = dependencies
+	}
+}
+
 // RemoveElement removes the element from the diagram. It also removes any linkss to the element
 func (dw *DiagramWidget) RemoveElement(elementID string) {
 	element := dw.GetDiagramElement(elementID)
@@ -464,14 +753,55 @@ func (dw *DiagramWidget) RemoveElement(elementID string) {
 		diagramElement := listElement.Value.(DiagramElement)
 		if diagramElement.GetDiagramElementID() == elementID {
 			dw.DiagramElements.Remove(listElement)
+			dw.elementIndex.remove(baseElement(diagramElement))
 		}
 	}
 	if element.IsLink() {
 		dw.removeDependenciesInvolvingLink(elementID)
+		removed := element.(DiagramLink).getBaseDiagramLink()
+		for _, link := range dw.linksBetween(removed.sourcePad, removed.targetPad) {
+			link.Refresh()
+		}
+		if dw.editAnimationsEnabled {
+			dw.fadeOutLink(element.(DiagramLink))
+		}
 	}
 	dw.drawingArea.Refresh()
 }
 
+// SetDropSnapRadius sets the distance within which a link end that is dropped away from any pad is connected
+// to the nearest pad that allows the connection. A radius of 0 (the default) turns snapping off.
+func (dw *DiagramWidget) SetDropSnapRadius(radius float32) {
+	dw.dropSnapRadius = radius
+}
+
+// SetEditAnimationDuration sets the time taken to fade links in and out when edit animations are enabled
+func (dw *DiagramWidget) SetEditAnimationDuration(duration time.Duration) {
+	dw.editAnimationDuration = duration
+}
+
+// SetEditAnimationsEnabled determines whether new links fade in and removed links fade out rather than
+// appearing and disappearing instantly. Links do not respond to the user while they are fading.
+func (dw *DiagramWidget) SetEditAnimationsEnabled(enabled bool) {
+	dw.editAnimationsEnabled = enabled
+}
+
+// SetEditable determines whether the user can edit the diagram. When it is not editable, elements cannot be
+// dragged or resized, pads are not highlighted, and no connections can be started. The diagram can still be
+// scrolled and elements can still be selected. The diagram is editable by default.
+func (dw *DiagramWidget) SetEditable(editable bool) {
+	dw.readOnly = !editable
+}
+
+// SetHoverHighlightConnected determines whether hovering over a node emphasizes the node, its incident
+// links, and its directly connected neighbors while dimming everything else
+func (dw *DiagramWidget) SetHoverHighlightConnected(highlight bool) {
+	dw.hoverHighlightConnected = highlight
+	if !highlight {
+		dw.clearHighlight()
+	}
+}
+
 // SelectDiagramElement clears the selection, makes the indicated element the primary selection, and invokes
 // the PrimaryDiagramElementSelectionChangedCallback
 func (dw *DiagramWidget) SelectDiagramElement(element DiagramElement) {
@@ -488,6 +818,7 @@ func (dw *DiagramWidget) SelectDiagramElementNoCallback(id string) {
 		dw.primarySelection = element
 		dw.selection[id] = element
 		element.ShowHandles()
+		dw.notifySelectionListeners()
 	}
 }
 
@@ -529,10 +860,89 @@ func (dw *DiagramWidget) showAllPads() {
 
 // StartNewLinkConnectionTransaction starts the process of adding a link, setting up for the source connection
 func (dw *DiagramWidget) StartNewLinkConnectionTransaction(link DiagramLink) {
+	if dw.readOnly {
+		return
+	}
 	dw.ConnectionTransaction = NewConnectionTransaction(link.getBaseDiagramLink().linkPoints[0], link, nil, fyne.NewPos(0, 0))
 	dw.showAllPads()
 }
 
+// BeginConnection connects the source of the link to the pad and starts connecting its target, as the mouse does
+// when a pad is pressed after StartNewLinkConnectionTransaction. The link may already be connected, in which case it
+// is reconnected. CompleteConnection or CancelConnection ends the connection; any connection already in progress
+// is cancelled first. It returns ErrConnectionNotAllowed if the diagram is not editable or the
+// IsConnectionAllowedCallback rejects the source pad.
+func (dw *DiagramWidget) BeginConnection(sourcePad ConnectionPad, link DiagramLink) error {
+	if !dw.IsEditable() {
+		return ErrConnectionNotAllowed
+	}
+	dw.CancelConnection()
+	bdl := link.getBaseDiagramLink()
+	if !bdl.isConnectionAllowed(bdl.linkPoints[0], sourcePad) {
+		return ErrConnectionNotAllowed
+	}
+	previousSourcePad := bdl.sourcePad
+	link.SetSourcePad(sourcePad)
+	targetPoint := bdl.linkPoints[len(bdl.linkPoints)-1]
+	connTrans := NewConnectionTransaction(targetPoint, link, bdl.targetPad, targetPoint.Position())
+	connTrans.sourceChanged = true
+	connTrans.previousSourcePad = previousSourcePad
+	dw.ConnectionTransaction = connTrans
+	dw.showAllPads()
+	dw.SelectDiagramElement(link)
+	link.ShowHandles()
+	return nil
+}
+
+// CancelConnection abandons the connection in progress, whether it was begun with BeginConnection or with the
+// mouse. The link is connected to the pads it had before, and the LinkConnectionChangedCallback is called if
+// BeginConnection changed its source. It does nothing if no connection is in progress.
+func (dw *DiagramWidget) CancelConnection() {
+	connTrans := dw.ConnectionTransaction
+	if connTrans == nil {
+		return
+	}
+	dw.ConnectionTransaction = nil
+	bdl := connTrans.Link.getBaseDiagramLink()
+	// the end being connected may have been detached by dragging its handle
+	if connTrans.LinkPoint == bdl.linkPoints[0] {
+		bdl.sourcePad = connTrans.InitialPad
+	} else {
+		bdl.targetPad = connTrans.InitialPad
+	}
+	if connTrans.sourceChanged && bdl.sourcePad != connTrans.previousSourcePad {
+		oldPad := bdl.sourcePad
+		bdl.setPadSilently(SOURCE, connTrans.previousSourcePad)
+		if dw.LinkConnectionChangedCallback != nil {
+			dw.LinkConnectionChangedCallback(bdl.typedLink, SOURCE.ToString(), oldPad, connTrans.previousSourcePad)
+		}
+	}
+	dw.hideAllPads()
+	bdl.Refresh()
+}
+
+// CompleteConnection connects the end of the link that is being connected to the pad, as the mouse does when the
+// handle of the link is released over the pad, and ends the connection. It returns ErrNoConnectionInProgress if
+// there is no connection to complete, and ErrConnectionNotAllowed if the diagram is not editable or the
+// IsConnectionAllowedCallback rejects the pad, in which case the connection remains in progress.
+func (dw *DiagramWidget) CompleteConnection(targetPad ConnectionPad) error {
+	connTrans := dw.ConnectionTransaction
+	if connTrans == nil {
+		return ErrNoConnectionInProgress
+	}
+	link := connTrans.Link
+	if !dw.IsEditable() || !link.isConnectionAllowed(connTrans.LinkPoint, targetPad) {
+		return ErrConnectionNotAllowed
+	}
+	end := TARGET
+	if connTrans.LinkPoint == link.getBaseDiagramLink().linkPoints[0] {
+		end = SOURCE
+	}
+	connTrans.PendingPad = targetPad
+	link.handleDragEnd(link.GetHandle(end.ToString()))
+	return nil
+}
+
 // diagramWidgetRenderer
 type diagramWidgetRenderer struct {
 	diagramWidget *DiagramWidget
@@ -556,6 +966,8 @@ func (r *diagramWidgetRenderer) Objects() []fyne.CanvasObject {
 }
 
 func (r *diagramWidgetRenderer) Refresh() {
+	// the diagram is refreshed when the application theme changes
+	r.diagramWidget.updateThemeColors()
 	r.diagramWidget.drawingArea.Refresh()
 }
 
@@ -578,6 +990,7 @@ func newDrawingArea(diagram *DiagramWidget) *drawingArea {
 func (da *drawingArea) CreateRenderer() fyne.WidgetRenderer {
 	dar := &drawingAreaRenderer{}
 	dar.da = da
+	dar.background = newBackgroundRaster(da.diagram)
 	return dar
 }
 
@@ -590,6 +1003,7 @@ func (da *drawingArea) DragEnd() {
 func (da *drawingArea) Dragged(event *fyne.DragEvent) {
 	delta := fyne.NewPos(event.Dragged.DX, event.Dragged.DY)
 	da.diagram.moveDiagramElements(delta)
+	da.diagram.panBackground(delta)
 	da.diagram.adjustBounds()
 }
 
@@ -628,10 +1042,12 @@ func (da *drawingArea) MouseUp(event *desktop.MouseEvent) {
 	}
 }
 
-// Tapped  respondss to taps in the diagram background. It removes all diagram elements
-// from the selection
+// Tapped  respondss to taps in the diagram background. A tap within the hit tolerance of a link selects the
+// link, otherwise it removes all diagram elements from the selection
 func (da *drawingArea) Tapped(event *fyne.PointEvent) {
-	if da.diagram.OnTappedCallback != nil {
+	if link := da.diagram.linkAt(event.Position); link != nil {
+		da.diagram.DiagramElementTapped(link)
+	} else if da.diagram.OnTappedCallback != nil {
 		da.diagram.OnTappedCallback(da.diagram, event)
 	} else {
 		da.diagram.ClearSelection()
@@ -639,15 +1055,16 @@ func (da *drawingArea) Tapped(event *fyne.PointEvent) {
 }
 
 type drawingAreaRenderer struct {
-	da *drawingArea
+	da         *drawingArea
+	background *canvas.Raster
 }
 
 func (dar *drawingAreaRenderer) Destroy() {
 
 }
 
-func (dar *drawingAreaRenderer) Layout(fyne.Size) {
-
+func (dar *drawingAreaRenderer) Layout(size fyne.Size) {
+	dar.background.Resize(size)
 }
 
 func (dar *drawingAreaRenderer) MinSize() fyne.Size {
@@ -655,14 +1072,23 @@ func (dar *drawingAreaRenderer) MinSize() fyne.Size {
 }
 
 func (dar *drawingAreaRenderer) Objects() []fyne.CanvasObject {
-	obj := []fyne.CanvasObject{}
+	obj := []fyne.CanvasObject{dar.background}
 	for _, n := range dar.da.diagram.GetDiagramElements() {
 		obj = append(obj, n)
 	}
+	dar.da.diagram.fadeLock.Lock()
+	for _, link := range dar.da.diagram.fadingLinks {
+		obj = append(obj, link)
+	}
+	dar.da.diagram.fadeLock.Unlock()
+	obj = append(obj, dar.da.diagram.guides.vertical, dar.da.diagram.guides.horizontal)
+	obj = append(obj, dar.da.diagram.tooltip.content)
 	return obj
 }
 
 func (dar *drawingAreaRenderer) Refresh() {
+	dar.background.Resize(dar.da.Size())
+	dar.background.Refresh()
 	for _, obj := range dar.da.diagram.GetDiagramElements() {
 		obj.Refresh()
 	}
@@ -1,12 +1,17 @@
 package diagramwidget
 
 import (
+	"errors"
 	"image/color"
+	"math"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/widget"
 )
 
+// ErrDuplicateID is returned when an element is given an ID that is already in use in its diagram
+var ErrDuplicateID = errors.New("diagram element ID is already in use")
+
 // DiagramElementProperties are the rendering properties of a DiagramElement
 type DiagramElementProperties struct {
 	ForegroundColor   color.Color
@@ -37,6 +42,8 @@ type DiagramElement interface {
 	GetDiagram() *DiagramWidget
 	// GetDiagramElementID returns the string identifier provided at the time the DiagramElement was created
 	GetDiagramElementID() string
+	// GetID returns the element's identifier, which is unique within the diagram. It is the same as GetDiagramElementID.
+	GetID() string
 	// GetHandle returns the handle with the indicated index name
 	GetHandle(string) *Handle
 	// GetHandleColor returns the color for the element's handles
@@ -45,6 +52,10 @@ type DiagramElement interface {
 	GetPadColor() color.Color
 	// GetProperties returns the properties of the DiagramElement
 	GetProperties() DiagramElementProperties
+	// GetRotation returns the angle in degrees by which the element is rotated clockwise about its center
+	GetRotation() float32
+	// GetTooltip returns the text shown when the mouse rests on the DiagramElement
+	GetTooltip() string
 	// handleDragged responds to drag events
 	handleDragged(handle *Handle, event *fyne.DragEvent)
 	// handleDragEnd responds to the end of a drag
@@ -55,14 +66,29 @@ type DiagramElement interface {
 	IsLink() bool
 	// IsNode returns true of the diagram element is a node
 	IsNode() bool
+	// IsResizable returns true if the element can be resized by dragging its handles
+	IsResizable() bool
 	// Position returns the position of the diagram element
 	Position() fyne.Position
+	// SetID changes the element's identifier, for example to match the ID of the model object it represents.
+	// It returns ErrDuplicateID if another element in the diagram already uses the ID.
+	SetID(string) error
 	// SetForegroundColor sets the foreground color for the widget
 	SetForegroundColor(color.Color)
 	// SetBackgroundColor sets the background color for the widget
 	SetBackgroundColor(color.Color)
 	// SetProperties sets the foreground, background, and handle colors
 	SetProperties(DiagramElementProperties)
+	// SetResizable sets whether a selected node shows handles at its corners and edges that resize it when dragged.
+	// Nodes are resizable by default. Links are sized by their ends, so it has no effect on them.
+	SetResizable(bool)
+	// SetRotation rotates the element clockwise about its center by the angle in degrees. Links connected to the
+	// element follow its rotated outline. Links themselves are drawn between their pads and ignore the rotation.
+	SetRotation(degrees float32)
+	// setDimmed sets whether the element is rendered with a dimmed foreground color
+	setDimmed(bool)
+	// SetTooltip sets the text shown when the mouse rests on the DiagramElement. An empty string shows no tooltip.
+	SetTooltip(string)
 	// ShowHandles shows the handles on the DiagramElement
 	ShowHandles()
 	// Size returns the size of the diagram element
@@ -79,6 +105,27 @@ type diagramElement struct {
 	id      string
 	handles map[string]*Handle
 	pads    map[string]ConnectionPad
+	dimmed  bool
+	tooltip string
+	// rotation is the angle in degrees by which the element is rotated clockwise about its center
+	rotation float32
+	// fixedSize is true if the element cannot be resized with its handles
+	fixedSize bool
+}
+
+// dimColor returns a faded version of the color, used to de-emphasize elements
+func dimColor(c color.Color) color.Color {
+	return scaleAlpha(c, 0.25)
+}
+
+// scaleAlpha returns the color with its alpha channel multiplied by the factor, which should be between 0 and 1
+func scaleAlpha(c color.Color, factor float32) color.Color {
+	if c == nil {
+		return nil
+	}
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	nrgba.A = uint8(float32(nrgba.A) * factor)
+	return nrgba
 }
 
 func (de *diagramElement) GetDiagram() *DiagramWidget {
@@ -89,6 +136,10 @@ func (de *diagramElement) GetDiagramElementID() string {
 	return de.id
 }
 
+func (de *diagramElement) GetID() string {
+	return de.id
+}
+
 func (de *diagramElement) GetBackgroundColor() color.Color {
 	return de.properties.BackgroundColor
 }
@@ -117,12 +168,24 @@ func (de *diagramElement) GetProperties() DiagramElementProperties {
 	return de.properties
 }
 
+func (de *diagramElement) GetRotation() float32 {
+	return de.rotation
+}
+
+func (de *diagramElement) GetTooltip() string {
+	return de.tooltip
+}
+
 func (de *diagramElement) HideHandles() {
 	for _, handle := range de.handles {
 		handle.Hide()
 	}
 }
 
+func (de *diagramElement) IsResizable() bool {
+	return !de.fixedSize
+}
+
 func (de *diagramElement) initialize(diagram *DiagramWidget, id string) {
 	de.diagram = diagram
 	de.id = id
@@ -131,6 +194,35 @@ func (de *diagramElement) initialize(diagram *DiagramWidget, id string) {
 	de.pads = make(map[string]ConnectionPad)
 }
 
+func (de *diagramElement) SetID(id string) error {
+	if id == de.id {
+		return nil
+	}
+	if de.diagram.GetDiagramElement(id) != nil {
+		return ErrDuplicateID
+	}
+	if de.inDiagram() {
+		de.diagram.renameElement(de.id, id)
+	}
+	de.id = id
+	return nil
+}
+
+// inDiagram returns true if the element is the one the diagram holds under its ID. It is false for an element
+// that was refused because its ID was already in use.
+func (de *diagramElement) inDiagram() bool {
+	element := de.diagram.GetDiagramElement(de.id)
+	return element != nil && baseElement(element) == de
+}
+
+// Refresh redraws the element, unless the diagram is in a BatchUpdate, which refreshes all the elements at its end
+func (de *diagramElement) Refresh() {
+	if de.diagram != nil && de.diagram.batchDepth > 0 {
+		return
+	}
+	de.BaseWidget.Refresh()
+}
+
 func (de *diagramElement) SetBackgroundColor(backgroundColor color.Color) {
 	de.properties.BackgroundColor = backgroundColor
 	de.Refresh()
@@ -146,10 +238,39 @@ func (de *diagramElement) SetHandleColor(handleColor color.Color) {
 	de.Refresh()
 }
 
+// renderForegroundColor returns the foreground color that should be used when rendering the element
+func (de *diagramElement) renderForegroundColor() color.Color {
+	if de.dimmed {
+		return dimColor(de.properties.ForegroundColor)
+	}
+	return de.properties.ForegroundColor
+}
+
+func (de *diagramElement) setDimmed(dimmed bool) {
+	if de.dimmed == dimmed {
+		return
+	}
+	de.dimmed = dimmed
+	de.Refresh()
+}
+
 func (de *diagramElement) SetProperties(properties DiagramElementProperties) {
 	de.properties = properties
 }
 
+func (de *diagramElement) SetResizable(resizable bool) {
+	de.fixedSize = !resizable
+}
+
+func (de *diagramElement) SetRotation(degrees float32) {
+	de.rotation = float32(math.Mod(float64(degrees), 360))
+	de.Refresh()
+}
+
+func (de *diagramElement) SetTooltip(tooltip string) {
+	de.tooltip = tooltip
+}
+
 func (de *diagramElement) ShowHandles() {
 	for _, handle := range de.handles {
 		handle.Show()
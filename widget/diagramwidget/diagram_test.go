@@ -1,10 +1,21 @@
 package diagramwidget
 
 import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strconv"
 	"testing"
+	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +54,1644 @@ func TestDependencies(t *testing.T) {
 	assert.Equal(t, 0, len(diagram.diagramElementLinkDependencies))
 
 }
+
+func TestCopyPaste(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node1.Move(fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	node2.Move(fyne.NewPos(200, 100))
+	node3 := NewDiagramNode(diagram, nil, "Node3")
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	outside := NewDiagramLink(diagram, "Link2")
+	outside.SetSourcePad(node2.GetDefaultConnectionPad())
+	outside.SetTargetPad(node3.GetDefaultConnectionPad())
+
+	diagram.ElementTappedExtendsSelection = true
+	diagram.DiagramElementTapped(node1)
+	diagram.DiagramElementTapped(node2)
+	diagram.Copy()
+	diagram.Paste()
+
+	node1Copy := diagram.GetDiagramNode("Node1-copy1")
+	node2Copy := diagram.GetDiagramNode("Node2-copy1")
+	linkCopy := diagram.GetDiagramLink("Link1-copy1")
+	assert.NotNil(t, node1Copy)
+	assert.NotNil(t, node2Copy)
+	assert.NotNil(t, linkCopy)
+	assert.Nil(t, diagram.GetDiagramElement("Link2-copy1"))
+	assert.Equal(t, node1.Position().AddXY(defaultPasteOffset, defaultPasteOffset), node1Copy.Position())
+	assert.Equal(t, node1Copy.GetDefaultConnectionPad(), linkCopy.GetSourcePad())
+	assert.Equal(t, node2Copy.GetDefaultConnectionPad(), linkCopy.GetTargetPad())
+	assert.True(t, diagram.IsSelected(linkCopy))
+	assert.False(t, diagram.IsSelected(node1))
+
+	diagram.Paste()
+	assert.NotNil(t, diagram.GetDiagramNode("Node1-copy2"))
+}
+
+func TestReadOnly(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node1.Move(fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	node2.Move(fyne.NewPos(200, 100))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+
+	diagram.SetEditable(false)
+	assert.False(t, diagram.IsEditable())
+	link.GetTargetHandle().Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(10, 10)})
+	assert.Nil(t, diagram.ConnectionTransaction)
+	assert.Equal(t, node2.GetDefaultConnectionPad(), link.GetTargetPad())
+	diagram.StartNewLinkConnectionTransaction(NewDiagramLink(diagram, "Link2"))
+	assert.Nil(t, diagram.ConnectionTransaction)
+
+	position := node1.Position()
+	node1.(*BaseDiagramNode).Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(10, 10)})
+	assert.Equal(t, position, node1.Position())
+
+	diagram.SetEditable(true)
+	link.GetTargetHandle().Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(10, 10)})
+	assert.NotNil(t, diagram.ConnectionTransaction)
+}
+
+func TestNewImageNode(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+
+	node := NewImageNode(diagram, theme.ComputerIcon(), "Server")
+	assert.Equal(t, "Server", node.GetDiagramElementID())
+	assert.Equal(t, node, diagram.GetDiagramNode("Server"))
+	assert.GreaterOrEqual(t, node.Size().Width, defaultImageSize)
+	assert.Greater(t, node.Size().Height, defaultImageSize)
+	assert.NotNil(t, node.GetEdgePad())
+
+	second := NewImageNode(diagram, theme.ComputerIcon(), "Server")
+	assert.Equal(t, "Server-2", second.GetDiagramElementID())
+
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node.GetEdgePad())
+	link.SetTargetPad(second.GetEdgePad())
+	assert.Equal(t, 1, len(diagram.diagramElementLinkDependencies["Server-2"]))
+}
+
+func TestCompositeElement(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	inner1 := NewDiagramNode(diagram, nil, "Inner1")
+	inner1.Move(fyne.NewPos(100, 100))
+	inner2 := NewDiagramNode(diagram, nil, "Inner2")
+	inner2.Move(fyne.NewPos(200, 100))
+	outer := NewDiagramNode(diagram, nil, "Outer")
+	outer.Move(fyne.NewPos(400, 100))
+	internal := NewDiagramLink(diagram, "Internal")
+	internal.SetSourcePad(inner1.GetEdgePad())
+	internal.SetTargetPad(inner2.GetEdgePad())
+	external := NewDiagramLink(diagram, "External")
+	external.SetSourcePad(outer.GetEdgePad())
+	external.SetTargetPad(inner2.GetEdgePad())
+
+	composite := NewCompositeElement(diagram, "Composite", "Group")
+	composite.AddChild(inner1)
+	composite.AddChild(inner2)
+	assert.Less(t, composite.Position().X, inner1.Position().X)
+	assert.Greater(t, composite.Position().X+composite.Size().Width, inner2.Position().X+inner2.Size().Width)
+	assert.Equal(t, composite, diagram.GetDiagramElements()[0])
+
+	var changes int
+	diagram.LinkConnectionChangedCallback = func(DiagramLink, string, ConnectionPad, ConnectionPad) {
+		changes++
+	}
+	composite.SetCollapsed(true)
+	assert.True(t, composite.IsCollapsed())
+	assert.False(t, inner1.Visible())
+	assert.False(t, internal.Visible())
+	assert.True(t, external.Visible())
+	assert.Equal(t, composite.GetEdgePad(), external.GetTargetPad())
+	assert.Equal(t, outer.GetEdgePad(), external.GetSourcePad())
+	assert.Less(t, composite.Size().Width, inner2.Position().X-inner1.Position().X)
+
+	composite.SetCollapsed(false)
+	assert.True(t, inner1.Visible())
+	assert.True(t, internal.Visible())
+	assert.Equal(t, inner2.GetEdgePad(), external.GetTargetPad())
+	assert.Equal(t, 2, len(diagram.diagramElementLinkDependencies["Inner2"]))
+	assert.Equal(t, 0, len(diagram.diagramElementLinkDependencies["Composite"]))
+	assert.Equal(t, 0, changes)
+}
+
+func TestViewportState(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	diagram.Resize(fyne.NewSize(200, 200))
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	NewDiagramNode(diagram, nil, "Node3")
+	diagram.SelectDiagramElement(node2)
+	diagram.addElementToSelection(node1)
+
+	state := diagram.ViewportState()
+	assert.Equal(t, []string{"Node2", "Node1"}, state.SelectedIDs)
+	assert.Equal(t, float32(1), state.Zoom)
+
+	state.Center = fyne.NewPos(300, 250)
+	state.SelectedIDs = []string{"Missing", "Node3"}
+	diagram.SetViewportState(state)
+	assert.Equal(t, fyne.NewPos(200, 150), diagram.scrollingContainer.Offset)
+	assert.Equal(t, []string{"Node3"}, diagram.ViewportState().SelectedIDs)
+	assert.Equal(t, "Node3", diagram.GetPrimarySelection().GetDiagramElementID())
+	assert.Equal(t, state.Center, diagram.ViewportState().Center)
+}
+
+func TestLinkCrossingStyle(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	left := NewDiagramNode(diagram, nil, "Left")
+	left.Move(fyne.NewPos(0, 200))
+	right := NewDiagramNode(diagram, nil, "Right")
+	right.Move(fyne.NewPos(400, 200))
+	top := NewDiagramNode(diagram, nil, "Top")
+	top.Move(fyne.NewPos(200, 0))
+	bottom := NewDiagramNode(diagram, nil, "Bottom")
+	bottom.Move(fyne.NewPos(200, 400))
+	below := NewDiagramLink(diagram, "Below")
+	below.SetSourcePad(left.GetDefaultConnectionPad())
+	below.SetTargetPad(right.GetDefaultConnectionPad())
+	above := NewDiagramLink(diagram, "Above")
+	above.SetSourcePad(top.GetDefaultConnectionPad())
+	above.SetTargetPad(bottom.GetDefaultConnectionPad())
+	aboveRenderer := test.WidgetRenderer(above.linkSegments[0]).(*linkSegmentRenderer)
+	assert.Empty(t, above.linkSegments[0].jumpDistances())
+
+	diagram.SetLinkCrossingStyle(CrossingJump)
+	assert.Len(t, above.linkSegments[0].jumpDistances(), 1)
+	assert.Empty(t, below.linkSegments[0].jumpDistances())
+	assert.False(t, aboveRenderer.line.Visible())
+	assert.Len(t, aboveRenderer.dashes, linkJumpSteps+2)
+
+	// the jump follows the link below when it moves out of the way
+	left.Move(fyne.NewPos(0, 500))
+	right.Move(fyne.NewPos(400, 500))
+	below.Refresh()
+	assert.Empty(t, above.linkSegments[0].jumpDistances())
+	assert.True(t, aboveRenderer.line.Visible())
+
+	left.Move(fyne.NewPos(0, 200))
+	right.Move(fyne.NewPos(400, 200))
+	below.Refresh()
+	assert.Len(t, above.linkSegments[0].jumpDistances(), 1)
+	diagram.SetLinkCrossingStyle(CrossingNone)
+	assert.True(t, aboveRenderer.line.Visible())
+}
+
+func TestElementIDs(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	diagram.SelectDiagramElementNoCallback("Node1")
+
+	assert.Equal(t, "Node1", node1.GetID())
+	assert.Equal(t, node1, diagram.GetElementByID("Node1"))
+	assert.Equal(t, link, diagram.GetLinkByID("Link1"))
+	assert.Nil(t, diagram.GetLinkByID("Node1"))
+
+	assert.ErrorIs(t, node1.SetID("Node2"), ErrDuplicateID)
+	assert.ErrorIs(t, link.SetID("Node2"), ErrDuplicateID)
+	assert.Equal(t, "Node1", node1.GetID())
+
+	assert.NoError(t, node1.SetID("model-42"))
+	assert.NoError(t, link.SetID("model-43"))
+	assert.Nil(t, diagram.GetElementByID("Node1"))
+	assert.Equal(t, node1, diagram.GetElementByID("model-42"))
+	assert.Equal(t, link, diagram.GetLinkByID("model-43"))
+	assert.True(t, diagram.IsSelected(node1))
+
+	diagram.RemoveElement("model-42")
+	assert.Nil(t, diagram.GetLinkByID("model-43"))
+}
+
+func TestDiagramWidget_DuplicateID(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	count := len(diagram.GetDiagramElements())
+
+	duplicateNode := NewDiagramNode(diagram, nil, "Node1")
+	duplicateLink := NewDiagramLink(diagram, "Link1")
+	duplicateLink.SetSourcePad(node2.GetDefaultConnectionPad())
+	assert.Equal(t, count, len(diagram.GetDiagramElements()))
+	assert.Equal(t, node1, diagram.GetElementByID("Node1"))
+	assert.Equal(t, link, diagram.GetLinkByID("Link1"))
+	assert.Len(t, diagram.diagramElementLinkDependencies["Node2"], 1)
+
+	assert.NoError(t, duplicateNode.SetID("Node3"))
+	assert.Equal(t, node1, diagram.GetElementByID("Node1"))
+	assert.Nil(t, diagram.GetElementByID("Node3"))
+	assert.Len(t, diagram.diagramElementLinkDependencies["Node1"], 1)
+
+	diagram.RemoveElement("Node2")
+	assert.Nil(t, diagram.GetLinkByID("Link1"))
+	assert.Equal(t, node1, diagram.GetElementByID("Node1"))
+}
+
+func TestSpatialIndex(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	diagram.SetDropSnapRadius(20)
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node1.Move(fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	node2.Move(fyne.NewPos(1000, 1000))
+	link := NewDiagramLink(diagram, "Link1")
+	linkPoint := link.GetLinkPoints()[1]
+
+	near := diagram.elementIndex.near(fyne.NewPos(110, 110), 20)
+	assert.True(t, near[baseElement(node1)])
+	assert.False(t, near[baseElement(node2)])
+	assert.Equal(t, node1.GetDefaultConnectionPad(), diagram.findNearestPad(linkPoint, fyne.NewPos(95, 110)))
+
+	// the index follows the nodes as they move
+	node2.Move(fyne.NewPos(250, 100))
+	assert.True(t, diagram.elementIndex.near(fyne.NewPos(260, 110), 20)[baseElement(node2)])
+	assert.Equal(t, node2.GetDefaultConnectionPad(), diagram.findNearestPad(linkPoint, fyne.NewPos(260, 110)))
+
+	diagram.RemoveElement("Node2")
+	assert.Nil(t, diagram.findNearestPad(linkPoint, fyne.NewPos(260, 110)))
+	assert.NotContains(t, diagram.elementIndex.elements, baseElement(node2))
+}
+
+func BenchmarkFindNearestPad(b *testing.B) {
+	test.NewApp()
+	diagram := NewDiagramWidget("Diagram1")
+	diagram.SetDropSnapRadius(20)
+	for i := 0; i < 1000; i++ {
+		node := NewDiagramNode(diagram, nil, "Node"+strconv.Itoa(i))
+		node.Move(fyne.NewPos(float32(i%40)*120, float32(i/40)*80))
+	}
+	link := NewDiagramLink(diagram, "Link1")
+	linkPoint := link.GetLinkPoints()[1]
+	position := fyne.NewPos(20*120+5, 12*80+5)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		diagram.findNearestPad(linkPoint, position)
+	}
+	// without the index, the pads of all 1000 nodes would be checked
+	b.ReportMetric(float64(len(diagram.elementIndex.near(position, 20))), "candidates/op")
+}
+
+func TestParallelLinkSpacing(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node1.Move(fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	node2.Move(fyne.NewPos(400, 100))
+	connect := func(id string, source, target DiagramNode) DiagramLink {
+		link := NewDiagramLink(diagram, id)
+		link.SetSourcePad(source.GetDefaultConnectionPad())
+		link.SetTargetPad(target.GetDefaultConnectionPad())
+		return link
+	}
+	link1 := connect("Link1", node1, node2)
+	link2 := connect("Link2", node1, node2)
+	link3 := connect("Link3", node2, node1)
+	centerY := node1.Position().Y + node1.Size().Height/2
+	sourceY := func(link DiagramLink) float32 {
+		return link.Position().Y + link.GetLinkPoints()[0].Position().Y
+	}
+	assert.Equal(t, sourceY(link1), sourceY(link2))
+
+	diagram.SetParallelLinkSpacing(10)
+	assert.InDelta(t, centerY-10, sourceY(link1), 1)
+	assert.InDelta(t, centerY, sourceY(link2), 1)
+	assert.InDelta(t, centerY+10, sourceY(link3), 1)
+	// the ends stay on the edges of the nodes
+	assert.InDelta(t, node1.Position().X+node1.Size().Width, link1.Position().X+link1.GetLinkPoints()[0].Position().X, 1)
+
+	diagram.RemoveElement("Link2")
+	assert.InDelta(t, centerY-5, sourceY(link1), 1)
+	assert.InDelta(t, centerY+5, sourceY(link3), 1)
+
+	// reconnecting a link moves it out of the group and back in again
+	link3.SetTargetPad(node2.GetDefaultConnectionPad())
+	assert.InDelta(t, centerY, sourceY(link1), 1)
+	link3.SetSourcePad(node1.GetDefaultConnectionPad())
+	assert.InDelta(t, centerY-5, sourceY(link1), 1)
+	assert.InDelta(t, centerY+5, sourceY(link3), 1)
+}
+
+func TestSetBackground(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node := NewDiagramNode(diagram, nil, "Node")
+	node.Move(fyne.NewPos(100, 100))
+	renderer := test.WidgetRenderer(diagram.drawingArea).(*drawingAreaRenderer)
+	assert.Equal(t, renderer.background, renderer.Objects()[0])
+	size := diagram.drawingArea.Size()
+	w, h := int(size.Width), int(size.Height)
+	pixel := func(x, y int) color.Color {
+		return color.NRGBAModel.Convert(renderer.background.Generator(w, h).At(x, y))
+	}
+	transparent := color.NRGBAModel.Convert(color.Transparent)
+	white := color.NRGBAModel.Convert(color.White)
+	black := color.NRGBAModel.Convert(color.Black)
+	assert.Equal(t, transparent, pixel(10, 10))
+
+	diagram.SetBackground(BackgroundSolid, color.White, 0)
+	assert.Equal(t, white, pixel(10, 10))
+
+	diagram.SetBackground(BackgroundGrid, color.Black, 20)
+	assert.Equal(t, black, pixel(40, 15))
+	assert.Equal(t, black, pixel(15, 40))
+	assert.Equal(t, transparent, pixel(15, 15))
+
+	diagram.SetBackground(BackgroundDots, color.Black, 20)
+	assert.Equal(t, black, pixel(40, 40))
+	assert.Equal(t, transparent, pixel(40, 45))
+
+	// the dots follow the diagram when it is panned
+	diagram.drawingArea.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(5, 5)})
+	assert.Equal(t, fyne.NewPos(105, 105), node.Position())
+	assert.Equal(t, transparent, pixel(40, 40))
+	assert.Equal(t, black, pixel(45, 45))
+
+	// the background does not intercept taps meant for the drawing area
+	diagram.SelectDiagramElementNoCallback("Node")
+	test.Tap(diagram.drawingArea)
+	assert.Nil(t, diagram.GetPrimarySelection())
+}
+
+func TestExportPNGRegion(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	box := canvas.NewRectangle(color.NRGBA{R: 0xff, A: 0xff})
+	box.SetMinSize(fyne.NewSize(80, 80))
+	node := NewDiagramNode(diagram, box, "Node")
+	node.Move(fyne.NewPos(600, 500))
+	w := test.NewWindow(diagram)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 200))
+	position := diagram.drawingArea.Position()
+
+	var buf bytes.Buffer
+	assert.Error(t, diagram.ExportPNGRegion(fyne.NewPos(0, 0), fyne.NewSize(0, 10), 1, &buf))
+	assert.Error(t, diagram.ExportPNGRegion(fyne.NewPos(0, 0), fyne.NewSize(10, 10), 0, &buf))
+
+	// the node is outside of the visible part of the diagram
+	center := node.Position().Add(fyne.NewPos(node.Size().Width/2, node.Size().Height/2))
+	assert.NoError(t, diagram.ExportPNGRegion(center.SubtractXY(25, 25), fyne.NewSize(50, 50), 2, &buf))
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 100, 100), img.Bounds())
+	assert.Equal(t, color.NRGBA{R: 0xff, A: 0xff}, color.NRGBAModel.Convert(img.At(50, 50)))
+	assert.Equal(t, position, diagram.drawingArea.Position())
+}
+
+func TestAlignSelection(t *testing.T) {
+	for name, tt := range map[string]struct {
+		edge     AlignEdge
+		expected []fyne.Position
+	}{
+		"left":     {AlignLeft, []fyne.Position{{X: 10, Y: 20}, {X: 10, Y: 60}, {X: 10, Y: 150}}},
+		"right":    {AlignRight, []fyne.Position{{X: 130, Y: 20}, {X: 100, Y: 60}, {X: 160, Y: 150}}},
+		"top":      {AlignTop, []fyne.Position{{X: 10, Y: 20}, {X: 100, Y: 20}, {X: 40, Y: 20}}},
+		"bottom":   {AlignBottom, []fyne.Position{{X: 10, Y: 190}, {X: 100, Y: 180}, {X: 40, Y: 150}}},
+		"center h": {AlignCenterH, []fyne.Position{{X: 70, Y: 20}, {X: 55, Y: 60}, {X: 85, Y: 150}}},
+		"center v": {AlignCenterV, []fyne.Position{{X: 10, Y: 105}, {X: 100, Y: 100}, {X: 40, Y: 85}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.NewApp()
+			diagram := NewDiagramWidget("Diagram1")
+			nodes := []DiagramNode{
+				newSizedNode(diagram, "A", fyne.NewPos(10, 20), fyne.NewSize(50, 30)),
+				newSizedNode(diagram, "B", fyne.NewPos(100, 60), fyne.NewSize(80, 40)),
+				newSizedNode(diagram, "C", fyne.NewPos(40, 150), fyne.NewSize(20, 70)),
+			}
+			unselected := newSizedNode(diagram, "D", fyne.NewPos(300, 300), fyne.NewSize(10, 10))
+			for _, node := range nodes {
+				diagram.addElementToSelection(node)
+			}
+
+			diagram.AlignSelection(tt.edge)
+			for i, node := range nodes {
+				assert.Equal(t, tt.expected[i], node.Position(), node.GetDiagramElementID())
+			}
+			assert.Equal(t, fyne.NewPos(300, 300), unselected.Position())
+
+			// a single node is not moved
+			diagram.ClearSelectionNoCallback()
+			diagram.addElementToSelection(unselected)
+			diagram.AlignSelection(tt.edge)
+			assert.Equal(t, fyne.NewPos(300, 300), unselected.Position())
+		})
+	}
+}
+
+func TestDistributeSelection(t *testing.T) {
+	for name, tt := range map[string]struct {
+		axis     Axis
+		position func(offset float32) fyne.Position
+		size     func(length float32) fyne.Size
+	}{
+		"horizontal": {AxisHorizontal,
+			func(offset float32) fyne.Position { return fyne.NewPos(offset, 5) },
+			func(length float32) fyne.Size { return fyne.NewSize(length, 15) }},
+		"vertical": {AxisVertical,
+			func(offset float32) fyne.Position { return fyne.NewPos(5, offset) },
+			func(length float32) fyne.Size { return fyne.NewSize(15, length) }},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.NewApp()
+			diagram := NewDiagramWidget("Diagram1")
+			// the nodes are out of order and have different lengths, the spaces between them become 40
+			first := newSizedNode(diagram, "First", tt.position(0), tt.size(20))
+			third := newSizedNode(diagram, "Third", tt.position(50), tt.size(10))
+			second := newSizedNode(diagram, "Second", tt.position(30), tt.size(40))
+			last := newSizedNode(diagram, "Last", tt.position(190), tt.size(30))
+			nodes := []DiagramNode{first, third, second, last}
+
+			// fewer than three nodes are not moved
+			diagram.addElementToSelection(first)
+			diagram.addElementToSelection(third)
+			diagram.DistributeSelection(tt.axis)
+			assert.Equal(t, tt.position(50), third.Position())
+
+			for _, node := range nodes {
+				diagram.addElementToSelection(node)
+			}
+			diagram.DistributeSelection(tt.axis)
+			assert.Equal(t, tt.position(0), first.Position())
+			assert.Equal(t, tt.position(60), second.Position())
+			assert.Equal(t, tt.position(140), third.Position())
+			assert.Equal(t, tt.position(190), last.Position())
+		})
+	}
+}
+
+// newSizedNode returns a node of the size, including its padding, at the position
+func newSizedNode(diagram *DiagramWidget, id string, position fyne.Position, size fyne.Size) DiagramNode {
+	node := NewDiagramNode(diagram, nil, id)
+	base := node.(*BaseDiagramNode)
+	padding := float32(2 * base.properties.Padding)
+	base.InnerSize = size.SubtractWidthHeight(padding, padding)
+	node.Refresh()
+	node.Move(position)
+	return node
+}
+
+func TestAlignmentGuides(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	anchor := NewDiagramNode(diagram, nil, "Anchor")
+	anchor.Move(fyne.NewPos(100, 100))
+	dragged := NewDiagramNode(diagram, nil, "Dragged")
+	dragged.Move(fyne.NewPos(300, 300))
+	base := dragged.(*BaseDiagramNode)
+
+	// without guides the node follows the mouse
+	base.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(-197, 0)})
+	base.DragEnd()
+	assert.Equal(t, fyne.NewPos(103, 300), dragged.Position())
+	assert.False(t, diagram.guides.vertical.Visible())
+
+	diagram.SetAlignmentGuidesEnabled(true)
+	base.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, -1)})
+	assert.Equal(t, fyne.NewPos(100, 299), dragged.Position())
+	assert.True(t, diagram.guides.vertical.Visible())
+	assert.False(t, diagram.guides.horizontal.Visible())
+	assert.Equal(t, float32(100), diagram.guides.vertical.Position1.X)
+
+	// the node leaves the alignment once the mouse is moved beyond the threshold
+	base.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(10, 0)})
+	assert.Equal(t, fyne.NewPos(113, 299), dragged.Position())
+	assert.False(t, diagram.guides.vertical.Visible())
+
+	diagram.SetAlignmentGuideThreshold(20)
+	base.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, 0)})
+	assert.Equal(t, float32(100), dragged.Position().X)
+	base.DragEnd()
+	assert.False(t, diagram.guides.vertical.Visible())
+}
+
+func TestLayoutTree(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	nodes := map[string]DiagramNode{}
+	for _, id := range []string{"Root", "A", "B", "A1", "A2", "Loose"} {
+		nodes[id] = NewDiagramNode(diagram, nil, id)
+	}
+	connect := func(id, source, target string) {
+		link := NewDiagramLink(diagram, id)
+		link.SetSourcePad(nodes[source].GetDefaultConnectionPad())
+		link.SetTargetPad(nodes[target].GetDefaultConnectionPad())
+	}
+	connect("L1", "Root", "A")
+	connect("L2", "Root", "B")
+	connect("L3", "A", "A1")
+	connect("L4", "A", "A2")
+	// a second path to A2 does not move it into another layer
+	connect("L5", "B", "A2")
+	nodes["Root"].Move(fyne.NewPos(100, 50))
+	nodes["Loose"].Move(fyne.NewPos(500, 500))
+
+	w, h := nodes["Root"].Size().Width, nodes["Root"].Size().Height
+
+	diagram.LayoutTree(nodes["Root"], TopDown, 10, 30)
+	assert.Equal(t, fyne.NewPos(100, 50), nodes["Root"].Position())
+	// the subtree of A is 2w+10 wide and B w wide, so the tree starts w+10 to the left of the root
+	assert.Equal(t, fyne.NewPos(95-w/2, 80+h), nodes["A"].Position())
+	assert.Equal(t, fyne.NewPos(110+w, 80+h), nodes["B"].Position())
+	assert.Equal(t, fyne.NewPos(90-w, 110+2*h), nodes["A1"].Position())
+	assert.Equal(t, fyne.NewPos(100, 110+2*h), nodes["A2"].Position())
+	assert.Equal(t, fyne.NewPos(500, 500), nodes["Loose"].Position())
+
+	nodes["Root"].Move(fyne.NewPos(100, 100))
+	diagram.LayoutTree(nodes["Root"], LeftRight, 10, 30)
+	assert.Equal(t, fyne.NewPos(100, 100), nodes["Root"].Position())
+	assert.Equal(t, fyne.NewPos(110+w, 85-h/2), nodes["A"].Position())
+	assert.Equal(t, fyne.NewPos(110+w, 130+h), nodes["B"].Position())
+	assert.Equal(t, fyne.NewPos(120+2*w, 70-h), nodes["A1"].Position())
+	assert.Equal(t, fyne.NewPos(120+2*w, 100), nodes["A2"].Position())
+}
+
+func TestAnchoredTextEditing(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	node2.Move(fyne.NewPos(200, 200))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	label := link.AddMidpointAnchoredText("name", "label")
+	w := test.NewWindow(diagram)
+	defer w.Close()
+
+	assert.False(t, label.background.Visible())
+	label.SetLabelBackground(true)
+	assert.True(t, label.background.Visible())
+	assert.Equal(t, label.Size(), label.background.Size())
+
+	changed := ""
+	label.OnLabelChanged = func(text string) {
+		changed = text
+	}
+	assert.True(t, label.label.Visible())
+	assert.False(t, label.textEntry.Visible())
+	label.DoubleTapped(&fyne.PointEvent{})
+	assert.False(t, label.label.Visible())
+	assert.True(t, label.textEntry.Visible())
+	assert.Equal(t, label.textEntry, w.Canvas().Focused())
+
+	test.Type(label.textEntry, "s")
+	assert.Empty(t, changed)
+	label.textEntry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, "labels", changed)
+	assert.Equal(t, "labels", label.label.Text)
+	assert.True(t, label.label.Visible())
+	assert.False(t, label.textEntry.Visible())
+	assert.Nil(t, w.Canvas().Focused())
+	text, _ := label.GetDisplayedTextBinding().Get()
+	assert.Equal(t, "labels", text)
+
+	// moving the focus away from the entry commits the edit
+	label.DoubleTapped(&fyne.PointEvent{})
+	test.Type(label.textEntry, "!")
+	w.Canvas().Unfocus()
+	assert.Equal(t, "labels!", changed)
+	assert.Equal(t, "labels!", label.label.Text)
+	assert.True(t, label.label.Visible())
+	assert.False(t, label.textEntry.Visible())
+
+	// labels are not edited in a diagram that is not editable
+	diagram.SetEditable(false)
+	label.DoubleTapped(&fyne.PointEvent{})
+	assert.False(t, label.textEntry.Visible())
+}
+
+func TestCursors(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node := NewDiagramNode(diagram, nil, "Node1")
+	base := node.(*BaseDiagramNode)
+	pointPad := NewPointPad(node)
+	rectanglePad := node.GetDefaultConnectionPad().(*RectanglePad)
+
+	assert.Equal(t, desktop.DefaultCursor, diagram.Cursor())
+	assert.Equal(t, moveCursor, base.Cursor())
+	assert.Equal(t, desktop.CrosshairCursor, pointPad.Cursor())
+	// the rectangle pad covers the node, so it shows the node's cursor unless a connection is being made
+	assert.Equal(t, moveCursor, rectanglePad.Cursor())
+	diagram.ConnectionTransaction = &ConnectionTransaction{}
+	assert.Equal(t, desktop.CrosshairCursor, rectanglePad.Cursor())
+	diagram.ConnectionTransaction = nil
+
+	diagram.SetEditable(false)
+	assert.Equal(t, desktop.DefaultCursor, base.Cursor())
+	assert.Equal(t, desktop.DefaultCursor, pointPad.Cursor())
+	assert.Equal(t, desktop.DefaultCursor, rectanglePad.Cursor())
+
+	img, x, y := moveCursor.Image()
+	assert.Equal(t, image.Rect(0, 0, moveCursorSize, moveCursorSize), img.Bounds())
+	assert.Equal(t, moveCursorSize/2, x)
+	assert.Equal(t, moveCursorSize/2, y)
+	assert.Equal(t, color.NRGBAModel.Convert(color.Black), img.At(x, y))
+	assert.Equal(t, color.NRGBA{}, img.At(0, 0))
+}
+
+func TestSetLinkHitTolerance(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	connect := func(id string, y float32) DiagramLink {
+		source := NewDiagramNode(diagram, nil, id+"Source")
+		source.Move(fyne.NewPos(100, y))
+		target := NewDiagramNode(diagram, nil, id+"Target")
+		target.Move(fyne.NewPos(400, y))
+		link := NewDiagramLink(diagram, id)
+		link.SetSourcePad(source.GetDefaultConnectionPad())
+		link.SetTargetPad(target.GetDefaultConnectionPad())
+		return link
+	}
+	upper := connect("Upper", 100)
+	lower := connect("Lower", 120)
+	w := test.NewWindow(diagram)
+	defer w.Close()
+
+	// the point on the upper link halfway between the nodes
+	segment := upper.getBaseDiagramLink().linkSegments[0]
+	middle := fyne.NewPos((2*upper.Position().X+segment.p1.X+segment.p2.X)/2, (2*upper.Position().Y+segment.p1.Y+segment.p2.Y)/2)
+	halfStroke := upper.getBaseDiagramLink().properties.StrokeWidth / 2
+
+	diagram.drawingArea.Tapped(&fyne.PointEvent{Position: middle.SubtractXY(0, halfStroke+5)})
+	assert.False(t, diagram.IsSelected(upper))
+
+	diagram.SetLinkHitTolerance(6)
+	diagram.drawingArea.Tapped(&fyne.PointEvent{Position: middle.SubtractXY(0, halfStroke+5)})
+	assert.True(t, diagram.IsSelected(upper))
+	assert.False(t, diagram.IsSelected(lower))
+
+	// the nearest of two links within the tolerance is selected
+	diagram.SetLinkHitTolerance(20)
+	diagram.drawingArea.Tapped(&fyne.PointEvent{Position: middle.AddXY(0, 15)})
+	assert.True(t, diagram.IsSelected(lower))
+	assert.False(t, diagram.IsSelected(upper))
+
+	// a tap beyond the tolerance clears the selection
+	diagram.drawingArea.Tapped(&fyne.PointEvent{Position: middle.SubtractXY(0, 50)})
+	assert.False(t, diagram.IsSelected(lower))
+}
+
+func TestEditAnimations(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	animations := []*fyne.Animation{}
+	defer func(start func(*fyne.Animation)) {
+		startEditAnimation = start
+	}(startEditAnimation)
+	startEditAnimation = func(a *fyne.Animation) {
+		animations = append(animations, a)
+	}
+
+	diagram := NewDiagramWidget("Diagram1")
+	diagram.SetEditAnimationsEnabled(true)
+	diagram.SetEditAnimationDuration(10 * time.Millisecond)
+	diagram.AllowLinkReconnection = true
+	source := NewDiagramNode(diagram, nil, "Source")
+	source.Move(fyne.NewPos(100, 100))
+	target := NewDiagramNode(diagram, nil, "Target")
+	target.Move(fyne.NewPos(400, 100))
+	link := NewDiagramLink(diagram, "Link")
+	link.SetSourcePad(source.GetDefaultConnectionPad())
+	link.SetTargetPad(target.GetDefaultConnectionPad())
+	w := test.NewWindow(diagram)
+	defer w.Close()
+	bdl := link.getBaseDiagramLink()
+	assert.Len(t, animations, 1)
+	assert.Equal(t, 10*time.Millisecond, animations[0].Duration)
+
+	// the link fades in and ignores input until it is fully shown
+	assert.True(t, bdl.animating)
+	assert.Zero(t, bdl.opacity)
+	animations[0].Tick(0.5)
+	assert.Equal(t, float32(0.5), bdl.opacity)
+	segment := bdl.linkSegments[0]
+	middle := fyne.NewPos((2*link.Position().X+segment.p1.X+segment.p2.X)/2, (2*link.Position().Y+segment.p1.Y+segment.p2.Y)/2)
+	diagram.drawingArea.Tapped(&fyne.PointEvent{Position: middle})
+	assert.False(t, diagram.IsSelected(link))
+	bdl.handleDragged(bdl.GetSourceHandle(), &fyne.DragEvent{Dragged: fyne.NewDelta(50, 50)})
+	assert.Nil(t, diagram.ConnectionTransaction)
+	assert.Equal(t, source.GetDefaultConnectionPad(), link.GetSourcePad())
+
+	animations[0].Tick(1)
+	assert.False(t, bdl.animating)
+	assert.Equal(t, float32(1), bdl.opacity)
+	diagram.drawingArea.Tapped(&fyne.PointEvent{Position: middle})
+	assert.True(t, diagram.IsSelected(link))
+
+	// a removed link is still drawn until it has faded out
+	diagram.RemoveElement("Link")
+	assert.Nil(t, diagram.GetDiagramElement("Link"))
+	assert.Len(t, animations, 2)
+	assert.True(t, bdl.animating)
+	assert.Equal(t, []DiagramLink{link}, diagram.fadingLinks)
+	animations[1].Tick(0.5)
+	assert.Equal(t, float32(0.5), bdl.opacity)
+	assert.Equal(t, []DiagramLink{link}, diagram.fadingLinks)
+	animations[1].Tick(1)
+	assert.False(t, bdl.animating)
+	assert.Empty(t, diagram.fadingLinks)
+}
+
+func TestEditAnimations_ConcurrentRender(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	animations := make(chan *fyne.Animation, 2)
+	defer func(start func(*fyne.Animation)) {
+		startEditAnimation = start
+	}(startEditAnimation)
+	startEditAnimation = func(a *fyne.Animation) {
+		animations <- a
+	}
+
+	diagram := NewDiagramWidget("Diagram1")
+	diagram.SetEditAnimationsEnabled(true)
+	link := NewDiagramLink(diagram, "Link")
+	<-animations
+	w := test.NewWindow(diagram)
+	defer w.Close()
+	renderer := test.WidgetRenderer(diagram.drawingArea).(*drawingAreaRenderer)
+
+	// the fade out runs on the animation goroutine while the diagram is being drawn
+	diagram.RemoveElement("Link")
+	fade := <-animations
+	done := make(chan struct{})
+	go func() {
+		for step := 1; step <= 10; step++ {
+			fade.Tick(float32(step) / 10)
+		}
+		close(done)
+	}()
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		default:
+			renderer.Objects()
+			link.renderForegroundColor()
+			diagram.linkAt(fyne.NewPos(0, 0))
+		}
+	}
+	assert.NotContains(t, renderer.Objects(), link)
+}
+
+func TestRotation(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node := NewDiagramNode(diagram, nil, "Node1")
+	base := node.(*BaseDiagramNode)
+	diagram.DisplaceNode(node, fyne.NewPos(100, 100))
+	size := node.Size()
+	w, h := size.Width, size.Height
+	center := elementCenter(node)
+
+	node.SetRotation(450)
+	assert.Equal(t, float32(90), node.GetRotation())
+
+	// links connect to the rotated outline, a node that is wider than it is tall turned upright.
+	// The intersections are found to the nearest pixel.
+	pad := node.GetEdgePad()
+	point := pad.getConnectionPointInDiagramCoordinates(center.AddXY(200, 0))
+	assert.InDelta(t, center.X+h/2, point.X, 1)
+	assert.InDelta(t, center.Y, point.Y, 1)
+	point = pad.getConnectionPointInDiagramCoordinates(center.AddXY(0, -200))
+	assert.InDelta(t, center.X, point.X, 1)
+	assert.InDelta(t, center.Y-w/2, point.Y, 1)
+	assert.Equal(t, center, pad.GetCenterInDiagramCoordinates())
+
+	// the corners of the unrotated box are outside of the rotated one
+	assert.False(t, base.containsPoint(fyne.NewPos(1, 1)))
+	assert.True(t, base.containsPoint(fyne.NewPos(w/2, h/2)))
+	base.Tapped(&fyne.PointEvent{Position: fyne.NewPos(1, 1)})
+	assert.False(t, diagram.IsSelected(node))
+	base.Tapped(&fyne.PointEvent{Position: fyne.NewPos(w/2, h/2)})
+	assert.True(t, diagram.IsSelected(node))
+
+	// the handles and the indexed bounds turn with the node
+	handle := node.GetHandle("upperLeft")
+	corner := rotateAbout(fyne.NewPos(0, 0), fyne.NewPos(w/2, h/2), 90)
+	assert.Equal(t, corner.SubtractXY(handle.handleSize/2, handle.handleSize/2), handle.Position())
+	assert.True(t, diagram.elementIndex.near(center.AddXY(0, w/2-1), 0)[baseElement(node)])
+
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetRotation(30)
+	assert.Equal(t, float32(0), link.GetRotation())
+}
+
+func TestDiagramInspector(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node, fyne.NewPos(100, 100))
+	inspector := NewDiagramInspector(diagram).(*diagramInspector)
+	assert.True(t, inspector.empty.Visible())
+	assert.False(t, inspector.form.Visible())
+
+	diagram.SetInspectorFields(func(element DiagramElement) []*widget.FormItem {
+		return []*widget.FormItem{widget.NewFormItem("Name", widget.NewLabel(element.GetDiagramElementID()+" name"))}
+	})
+	diagram.SelectDiagramElement(node)
+	assert.True(t, inspector.form.Visible())
+	assert.Equal(t, "Node1", inspector.id.Text)
+	assert.Equal(t, "100", inspector.x.Text)
+	assert.Len(t, inspector.form.Items, 6)
+	assert.Equal(t, "Node1 name", inspector.form.Items[5].Widget.(*widget.Label).Text)
+
+	// editing the fields moves and resizes the node
+	inspector.x.SetText("150")
+	assert.Equal(t, fyne.NewPos(150, 100), node.Position())
+	inspector.width.SetText("120")
+	assert.Equal(t, float32(120), node.Size().Width)
+	inspector.x.SetText("not a number")
+	assert.Equal(t, fyne.NewPos(150, 100), node.Position())
+
+	// the fields of a link cannot be edited
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node.GetEdgePad())
+	diagram.DiagramElementTapped(link)
+	assert.Equal(t, "Link1", inspector.id.Text)
+	assert.True(t, inspector.x.Disabled())
+	assert.Len(t, inspector.form.Items, 6)
+
+	diagram.ClearSelection()
+	assert.True(t, inspector.empty.Visible())
+	assert.Len(t, inspector.form.Items, 5)
+}
+
+func TestConnectionAPI(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	node3 := NewDiagramNode(diagram, nil, "Node3")
+	node4 := NewDiagramNode(diagram, nil, "Node4")
+	diagram.IsConnectionAllowedCallback = func(link DiagramLink, end LinkEnd, pad ConnectionPad) bool {
+		return pad.GetPadOwner() != node3
+	}
+	changes := 0
+	diagram.LinkConnectionChangedCallback = func(DiagramLink, string, ConnectionPad, ConnectionPad) {
+		changes++
+	}
+
+	assert.Equal(t, ErrNoConnectionInProgress, diagram.CompleteConnection(node2.GetDefaultConnectionPad()))
+	link := NewDiagramLink(diagram, "Link1")
+	assert.Equal(t, ErrConnectionNotAllowed, diagram.BeginConnection(node3.GetDefaultConnectionPad(), link))
+	assert.Nil(t, diagram.ConnectionTransaction)
+
+	assert.NoError(t, diagram.BeginConnection(node1.GetDefaultConnectionPad(), link))
+	assert.NotNil(t, diagram.ConnectionTransaction)
+	assert.Equal(t, node1.GetDefaultConnectionPad(), link.GetSourcePad())
+	assert.Equal(t, ErrConnectionNotAllowed, diagram.CompleteConnection(node3.GetDefaultConnectionPad()))
+	assert.NotNil(t, diagram.ConnectionTransaction)
+	assert.NoError(t, diagram.CompleteConnection(node2.GetDefaultConnectionPad()))
+	assert.Nil(t, diagram.ConnectionTransaction)
+	assert.Equal(t, node2.GetDefaultConnectionPad(), link.GetTargetPad())
+	assert.Equal(t, 2, changes)
+	assert.Len(t, diagram.diagramElementLinkDependencies["Node2"], 1)
+
+	// cancelling a reconnection restores the original pads
+	assert.NoError(t, diagram.BeginConnection(node4.GetDefaultConnectionPad(), link))
+	assert.Equal(t, node4.GetDefaultConnectionPad(), link.GetSourcePad())
+	diagram.CancelConnection()
+	assert.Nil(t, diagram.ConnectionTransaction)
+	assert.Equal(t, node1.GetDefaultConnectionPad(), link.GetSourcePad())
+	assert.Equal(t, node2.GetDefaultConnectionPad(), link.GetTargetPad())
+	assert.Equal(t, 4, changes)
+	assert.Len(t, diagram.diagramElementLinkDependencies["Node1"], 1)
+	assert.Len(t, diagram.diagramElementLinkDependencies["Node2"], 1)
+
+	diagram.SetEditable(false)
+	assert.Equal(t, ErrConnectionNotAllowed, diagram.BeginConnection(node1.GetDefaultConnectionPad(), link))
+}
+
+func TestFloatingEndpoint(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node, fyne.NewPos(100, 100))
+	var changedTo []ConnectionPad
+	diagram.LinkConnectionChangedCallback = func(_ DiagramLink, _ string, _, newPad ConnectionPad) {
+		changedTo = append(changedTo, newPad)
+	}
+
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node.GetDefaultConnectionPad())
+	link.SetFloatingEndpoint(TARGET, fyne.NewPos(300, 50))
+	position, ok := link.GetFloatingEndpoint(TARGET)
+	assert.True(t, ok)
+	assert.Equal(t, fyne.NewPos(300, 50), position)
+	_, ok = link.GetFloatingEndpoint(SOURCE)
+	assert.False(t, ok)
+	assert.Equal(t, fyne.NewPos(300, 50), link.getTargetPosition().Add(link.Position()))
+
+	// the floating end stays put when the node moves
+	diagram.DisplaceNode(node, fyne.NewPos(20, 20))
+	assert.Equal(t, fyne.NewPos(300, 50), link.getTargetPosition().Add(link.Position()))
+
+	// dragging the handle moves the floating end
+	link.GetTargetHandle().Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, 400)})
+	link.GetTargetHandle().DragEnd()
+	position, ok = link.GetFloatingEndpoint(TARGET)
+	assert.True(t, ok)
+	assert.Equal(t, fyne.NewPos(300, 450), position)
+	assert.Nil(t, diagram.ConnectionTransaction)
+
+	// the source can float too, and a floating end can be connected again
+	link.SetFloatingEndpoint(SOURCE, fyne.NewPos(10, 10))
+	assert.Nil(t, link.GetSourcePad())
+	assert.Len(t, diagram.diagramElementLinkDependencies["Node1"], 0)
+	assert.Equal(t, fyne.NewPos(10, 10), link.getSourcePosition().Add(link.Position()))
+	link.SetTargetPad(node.GetDefaultConnectionPad())
+	_, ok = link.GetFloatingEndpoint(TARGET)
+	assert.False(t, ok)
+	assert.Equal(t, []ConnectionPad{node.GetDefaultConnectionPad(), nil, node.GetDefaultConnectionPad()}, changedTo)
+}
+
+func TestThemeColors(t *testing.T) {
+	test.NewApp()
+	defer test.ApplyTheme(t, test.Theme())
+	test.ApplyTheme(t, theme.LightTheme())
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	text := link.AddMidpointAnchoredText("label", "Label")
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	node2.SetForegroundColor(red)
+
+	light := theme.LightTheme()
+	assert.Equal(t, light.Color(theme.ColorNameForeground, theme.VariantLight), node1.GetForegroundColor())
+	assert.Equal(t, light.Color(theme.ColorNamePrimary, theme.VariantLight), node1.GetPadColor())
+
+	dark := theme.DarkTheme()
+	test.ApplyTheme(t, dark)
+	diagram.Refresh()
+	foreground := dark.Color(theme.ColorNameForeground, theme.VariantDark)
+	assert.Equal(t, foreground, diagram.DefaultDiagramElementProperties.ForegroundColor)
+	assert.Equal(t, foreground, node1.GetForegroundColor())
+	assert.Equal(t, dark.Color(theme.ColorNameBackground, theme.VariantDark), node1.GetBackgroundColor())
+	assert.Equal(t, dark.Color(theme.ColorNamePrimary, theme.VariantDark), node1.GetPadColor())
+	assert.Equal(t, foreground, link.GetForegroundColor())
+	assert.Equal(t, foreground, text.ForegroundColor)
+	// an explicit color is kept
+	assert.Equal(t, red, node2.GetForegroundColor())
+}
+
+func TestResizeHandles(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node, fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	diagram.DisplaceNode(node2, fyne.NewPos(400, 100))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	var resized []DiagramElement
+	diagram.OnResizedCallback = func(element DiagramElement) {
+		resized = append(resized, element)
+	}
+
+	diagram.SelectDiagramElement(node)
+	assert.True(t, node.IsResizable())
+	assert.True(t, node.GetHandle("lowerRight").Visible())
+	size := node.Size()
+	source := link.getSourcePosition().Add(link.Position())
+
+	// the links follow the edge of the resized node
+	node.GetHandle("rightMiddle").Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(50, 0)})
+	node.GetHandle("rightMiddle").DragEnd()
+	assert.Equal(t, size.AddWidthHeight(50, 0), node.Size())
+	assert.Equal(t, fyne.NewPos(100, 100), node.Position())
+	assert.InDelta(t, source.X+50, link.getSourcePosition().Add(link.Position()).X, 0.01)
+	assert.Equal(t, []DiagramElement{node}, resized)
+
+	// dragging the upper left handle moves the node, and it cannot be smaller than nothing
+	node.GetHandle("upperLeft").Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(10, 500)})
+	node.GetHandle("upperLeft").DragEnd()
+	inner := node.getBaseDiagramNode().InnerSize
+	assert.Equal(t, float32(0), inner.Height)
+	assert.Equal(t, size.Width+40, node.Size().Width)
+	assert.Equal(t, fyne.NewPos(110, 100+defaultHeight), node.Position())
+
+	node.SetResizable(false)
+	assert.False(t, node.GetHandle("lowerRight").Visible())
+	size = node.Size()
+	node.GetHandle("lowerRight").Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(50, 50)})
+	assert.Equal(t, size, node.Size())
+	assert.Len(t, resized, 2)
+	node.SetResizable(true)
+	assert.True(t, node.GetHandle("lowerRight").Visible())
+	assert.False(t, link.IsResizable())
+
+	// with the aspect ratio kept, the dimension that changed most leads
+	assert.Equal(t, fyne.NewSize(60, 30), keepAspectRatio(fyne.NewSize(50, 25), fyne.NewSize(60, 26), 2))
+	assert.Equal(t, fyne.NewSize(80, 40), keepAspectRatio(fyne.NewSize(50, 25), fyne.NewSize(52, 40), 2))
+}
+
+func TestPortSnapping(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node1, fyne.NewPos(100, 100))
+	port := NewPointPad(node1)
+	node1.GetConnectionPads()["port"] = port
+	port.SetRelativePosition(fyne.NewPos(node1.Size().Width, node1.Size().Height/2))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	diagram.DisplaceNode(node2, fyne.NewPos(400, 100))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node2.GetDefaultConnectionPad())
+	link.SetTargetPad(node1.GetDefaultConnectionPad())
+
+	// with snapping on, a port near the drop is preferred to the outline
+	diagram.SetDropSnapRadius(20)
+	portCenter := port.GetCenterInDiagramCoordinates()
+	assert.Equal(t, port, diagram.findNearestPad(link.GetLinkPoints()[1], portCenter.AddXY(-2, 6)))
+	diagram.SetDropSnapRadius(0)
+
+	// dropping the end on the outline next to the port connects it to the port
+	handle := link.GetTargetHandle()
+	drop := portCenter.AddXY(-2, 4)
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, 0)})
+	target := link.getTargetPosition().Add(link.Position())
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(drop.X-target.X, drop.Y-target.Y)})
+	diagram.ConnectionTransaction.PendingPad = node1.GetDefaultConnectionPad()
+	handle.DragEnd()
+	assert.Equal(t, port, link.GetTargetPad())
+	assert.Equal(t, port.GetCenterInDiagramCoordinates(), link.getTargetPosition().Add(link.Position()))
+
+	// the end stays on the port as the node moves
+	diagram.DisplaceNode(node1, fyne.NewPos(0, 80))
+	assert.Equal(t, port.GetCenterInDiagramCoordinates(), link.getTargetPosition().Add(link.Position()))
+}
+
+func TestMinVisibleLength(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node1, fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	diagram.DisplaceNode(node2, fyne.NewPos(100+node1.Size().Width+10, 100))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	source := link.getSourcePosition().Add(link.Position())
+	target := link.getTargetPosition().Add(link.Position())
+	assert.InDelta(t, 10, target.X-source.X, 0.01)
+
+	// a short link is lengthened about its middle
+	link.SetMinVisibleLength(60)
+	lengthened := link.getTargetPosition().Add(link.Position())
+	assert.InDelta(t, 60, lengthened.X-link.getSourcePosition().Add(link.Position()).X, 0.01)
+	assert.InDelta(t, (source.X+target.X)/2+30, lengthened.X, 0.01)
+	assert.Equal(t, target.Y, lengthened.Y)
+
+	// overlapping nodes still draw the link from the source to the target
+	diagram.DisplaceNode(node2, fyne.NewPos(-20, 0))
+	source = link.getSourcePosition().Add(link.Position())
+	target = link.getTargetPosition().Add(link.Position())
+	assert.InDelta(t, 60, target.X-source.X, 0.01)
+
+	// longer links are not changed
+	diagram.DisplaceNode(node2, fyne.NewPos(200, 0))
+	source = link.getSourcePosition().Add(link.Position())
+	target = link.getTargetPosition().Add(link.Position())
+	assert.InDelta(t, 190, target.X-source.X, 0.01)
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	build := NewDiagramNode(diagram, nil, "Build")
+	deploy := NewDiagramNode(diagram, nil, "Deploy")
+	test1 := NewDiagramNode(diagram, nil, "Test")
+	connect := func(id string, source, target DiagramNode) DiagramLink {
+		link := NewDiagramLink(diagram, id)
+		link.SetSourcePad(source.GetDefaultConnectionPad())
+		link.SetTargetPad(target.GetDefaultConnectionPad())
+		return link
+	}
+	connect("Link1", build, test1)
+	connect("Link2", test1, deploy)
+	// a floating link does not order the nodes
+	floating := NewDiagramLink(diagram, "Link3")
+	floating.SetSourcePad(deploy.GetDefaultConnectionPad())
+
+	assert.False(t, diagram.HasCycle())
+	order, err := diagram.TopologicalOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, []DiagramElement{build, test1, deploy}, order)
+
+	connect("Link4", deploy, build)
+	assert.True(t, diagram.HasCycle())
+	order, err = diagram.TopologicalOrder()
+	assert.Equal(t, ErrCycle, err)
+	assert.Nil(t, order)
+
+	diagram.RemoveElement("Link4")
+	assert.False(t, diagram.HasCycle())
+	connect("Link5", test1, test1)
+	assert.True(t, diagram.HasCycle())
+}
+
+// countingRectangle counts how many times a node lays out its inner object, which happens on each refresh
+type countingRectangle struct {
+	*canvas.Rectangle
+	count *int
+}
+
+func (r countingRectangle) Resize(size fyne.Size) {
+	*r.count++
+	r.Rectangle.Resize(size)
+}
+
+func TestBatchUpdate(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	count := 0
+	inner := countingRectangle{canvas.NewRectangle(color.Black), &count}
+	initialSize := diagram.DesiredSize
+
+	diagram.BatchUpdate(func() {
+		node := NewDiagramNode(diagram, inner, "Node1")
+		diagram.BatchUpdate(func() {
+			node.Move(fyne.NewPos(900, 700))
+		})
+		// nested batches do not refresh the diagram
+		assert.Equal(t, 0, count)
+		assert.Equal(t, initialSize, diagram.DesiredSize)
+		link := NewDiagramLink(diagram, "Link1")
+		link.SetSourcePad(node.GetDefaultConnectionPad())
+	})
+	assert.NotZero(t, count)
+	node := diagram.GetDiagramNode("Node1")
+	assert.Equal(t, node.Position().X+node.Size().Width, diagram.DesiredSize.Width)
+	assert.Equal(t, node.Position().Y+node.Size().Height, diagram.DesiredSize.Height)
+	assert.Equal(t, node, diagram.GetDiagramLink("Link1").GetSourcePad().GetPadOwner())
+
+	// once the node has been rendered, the batch refreshes it a single time
+	count = 0
+	diagram.BatchUpdate(func() {
+		node.Move(fyne.NewPos(1000, 700))
+		node.Move(fyne.NewPos(1100, 700))
+	})
+	assert.Equal(t, 1, count)
+	assert.Equal(t, node.Position().X+node.Size().Width, diagram.DesiredSize.Width)
+}
+
+func BenchmarkBatchUpdate(b *testing.B) {
+	test.NewApp()
+	build := func(diagram *DiagramWidget, count *int) {
+		for i := 0; i < 500; i++ {
+			node := NewDiagramNode(diagram, countingRectangle{canvas.NewRectangle(color.Black), count}, "Node"+strconv.Itoa(i))
+			node.Move(fyne.NewPos(float32(i%25)*120, float32(i/25)*80))
+		}
+	}
+	for _, batched := range []bool{false, true} {
+		name := "Unbatched"
+		if batched {
+			name = "Batched"
+		}
+		b.Run(name, func(b *testing.B) {
+			count := 0
+			for i := 0; i < b.N; i++ {
+				diagram := NewDiagramWidget("Diagram1")
+				if batched {
+					diagram.BatchUpdate(func() { build(diagram, &count) })
+				} else {
+					build(diagram, &count)
+				}
+			}
+			// creating the renderer of each node lays it out twice whether or not the update is batched
+			b.ReportMetric(float64(count)/float64(b.N), "layouts/op")
+		})
+	}
+}
+
+func TestLinkReconnection(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node1, fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	diagram.DisplaceNode(node2, fyne.NewPos(300, 100))
+	node3 := NewDiagramNode(diagram, nil, "Node3")
+	diagram.DisplaceNode(node3, fyne.NewPos(300, 300))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	var changedEnd string
+	var changedFrom, changedTo ConnectionPad
+	diagram.LinkConnectionChangedCallback = func(l DiagramLink, end string, from, to ConnectionPad) {
+		changedEnd, changedFrom, changedTo = end, from, to
+	}
+	handle := link.GetTargetHandle()
+
+	// dropping the end on another pad reconnects the link to it
+	diagram.AllowLinkReconnection = true
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, 200)})
+	assert.NotNil(t, diagram.ConnectionTransaction)
+	assert.Nil(t, link.GetTargetPad())
+	diagram.ConnectionTransaction.PendingPad = node3.GetDefaultConnectionPad()
+	handle.DragEnd()
+	assert.Nil(t, diagram.ConnectionTransaction)
+	assert.Equal(t, node3.GetDefaultConnectionPad(), link.GetTargetPad())
+	assert.Equal(t, TARGET.ToString(), changedEnd)
+	assert.Equal(t, node2.GetDefaultConnectionPad(), changedFrom)
+	assert.Equal(t, node3.GetDefaultConnectionPad(), changedTo)
+	assert.Equal(t, []linkPadPair{{link.getBaseDiagramLink(), node3.GetDefaultConnectionPad()}}, diagram.diagramElementLinkDependencies[node3.GetDiagramElementID()])
+	assert.Empty(t, diagram.diagramElementLinkDependencies[node2.GetDiagramElementID()])
+
+	// dropping the end on empty space leaves it connected to the pad it had
+	changedEnd = ""
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(-500, 0)})
+	handle.DragEnd()
+	assert.Nil(t, diagram.ConnectionTransaction)
+	assert.Equal(t, node3.GetDefaultConnectionPad(), link.GetTargetPad())
+	assert.Equal(t, "", changedEnd)
+
+	// without reconnection a connected end cannot be dragged
+	diagram.AllowLinkReconnection = false
+	target := link.getTargetPosition()
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, -200)})
+	assert.Nil(t, diagram.ConnectionTransaction)
+	assert.Equal(t, node3.GetDefaultConnectionPad(), link.GetTargetPad())
+	assert.Equal(t, target, link.getTargetPosition())
+	handle.DragEnd()
+	assert.Equal(t, node3.GetDefaultConnectionPad(), link.GetTargetPad())
+	assert.Equal(t, "", changedEnd)
+}
+
+func TestHoverHighlightConnected(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1").(*BaseDiagramNode)
+	diagram.DisplaceNode(node1, fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2").(*BaseDiagramNode)
+	diagram.DisplaceNode(node2, fyne.NewPos(300, 100))
+	node3 := NewDiagramNode(diagram, nil, "Node3").(*BaseDiagramNode)
+	diagram.DisplaceNode(node3, fyne.NewPos(300, 300))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	dimmed := func() []bool {
+		return []bool{node1.dimmed, node2.dimmed, node3.dimmed, link.dimmed}
+	}
+	hover := &desktop.MouseEvent{PointEvent: fyne.PointEvent{Position: fyne.NewPos(5, 5)}}
+
+	// nothing is dimmed unless highlighting is enabled
+	node1.MouseIn(hover)
+	assert.Equal(t, []bool{false, false, false, false}, dimmed())
+	node1.MouseOut()
+
+	// hovering a node dims everything but the node, its links and the nodes they connect it to
+	diagram.SetHoverHighlightConnected(true)
+	node1.MouseIn(hover)
+	assert.Equal(t, []bool{false, false, true, false}, dimmed())
+	assert.Equal(t, dimColor(node3.properties.ForegroundColor), node3.renderForegroundColor())
+	assert.Equal(t, node1.properties.ForegroundColor, node1.renderForegroundColor())
+	node1.MouseOut()
+	assert.Equal(t, []bool{false, false, false, false}, dimmed())
+
+	// a node with no links is the only element left undimmed
+	node3.MouseIn(hover)
+	assert.Equal(t, []bool{true, true, false, true}, dimmed())
+
+	// turning highlighting off restores the diagram
+	diagram.SetHoverHighlightConnected(false)
+	assert.Equal(t, []bool{false, false, false, false}, dimmed())
+	node3.MouseOut()
+}
+
+func TestDropSnapRadius(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := newSizedNode(diagram, "Node1", fyne.NewPos(100, 100), fyne.NewSize(100, 50))
+	node2 := newSizedNode(diagram, "Node2", fyne.NewPos(230, 100), fyne.NewSize(100, 50))
+	node3 := newSizedNode(diagram, "Node3", fyne.NewPos(100, 300), fyne.NewSize(100, 50))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node3.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	linkPoint := link.GetLinkPoints()[1]
+
+	// snapping is off by default
+	assert.Nil(t, diagram.findNearestPad(linkPoint, fyne.NewPos(212, 125)))
+
+	// only pads within the radius are found
+	diagram.SetDropSnapRadius(10)
+	assert.Nil(t, diagram.findNearestPad(linkPoint, fyne.NewPos(212, 125)))
+	diagram.SetDropSnapRadius(15)
+	assert.Equal(t, node1.GetDefaultConnectionPad(), diagram.findNearestPad(linkPoint, fyne.NewPos(212, 125)))
+
+	// the nearest of the pads within the radius is found
+	diagram.SetDropSnapRadius(30)
+	assert.Equal(t, node1.GetDefaultConnectionPad(), diagram.findNearestPad(linkPoint, fyne.NewPos(212, 125)))
+	assert.Equal(t, node2.GetDefaultConnectionPad(), diagram.findNearestPad(linkPoint, fyne.NewPos(222, 125)))
+
+	// dropping the end of a link away from any pad connects it to the nearest one
+	diagram.SetDropSnapRadius(15)
+	handle := link.GetTargetHandle()
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, 0)})
+	drop := fyne.NewPos(150, 162)
+	target := link.getTargetPosition().Add(link.Position())
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(drop.X-target.X, drop.Y-target.Y)})
+	handle.DragEnd()
+	assert.Equal(t, node1.GetDefaultConnectionPad(), link.GetTargetPad())
+
+	// a drop outside the radius leaves the end where it was
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, 0)})
+	drop = fyne.NewPos(150, 200)
+	target = link.getTargetPosition().Add(link.Position())
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(drop.X-target.X, drop.Y-target.Y)})
+	handle.DragEnd()
+	assert.Equal(t, node1.GetDefaultConnectionPad(), link.GetTargetPad())
+}
+
+func TestEndpointInset(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node1, fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	diagram.DisplaceNode(node2, fyne.NewPos(100+node1.Size().Width+100, 100))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	source := link.getSourcePosition().Add(link.Position())
+	target := link.getTargetPosition().Add(link.Position())
+	assert.InDelta(t, 100, target.X-source.X, 0.01)
+
+	// both connected ends are moved in along the link
+	link.SetEndpointInset(10)
+	inset := link.getSourcePosition().Add(link.Position())
+	assert.InDelta(t, source.X+10, inset.X, 0.01)
+	assert.Equal(t, source.Y, inset.Y)
+	inset = link.getTargetPosition().Add(link.Position())
+	assert.InDelta(t, target.X-10, inset.X, 0.01)
+	assert.Equal(t, target.Y, inset.Y)
+
+	// a link too short for both insets is left unchanged
+	link.SetEndpointInset(60)
+	assert.InDelta(t, source.X, link.getSourcePosition().Add(link.Position()).X, 0.01)
+	assert.InDelta(t, target.X, link.getTargetPosition().Add(link.Position()).X, 0.01)
+
+	// a dragged end is not connected to a pad and is not inset
+	link.SetEndpointInset(10)
+	handle := link.GetTargetHandle()
+	handle.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(0, 100)})
+	link.Refresh()
+	dragged := link.getTargetPosition().Add(link.Position())
+	assert.InDelta(t, inset.X, dragged.X, 0.01)
+	assert.InDelta(t, inset.Y+100, dragged.Y, 0.01)
+	handle.DragEnd()
+}
+
+func TestStrokeDashPattern(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node1, fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	diagram.DisplaceNode(node2, fyne.NewPos(100+node1.Size().Width+100, 100))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	segment := link.linkSegments[0]
+	renderer := test.WidgetRenderer(segment).(*linkSegmentRenderer)
+	assert.True(t, renderer.line.Visible())
+	assert.Empty(t, renderer.dashes)
+	dashLength := func(dash *canvas.Line) float64 {
+		return math.Hypot(float64(dash.Position2.X-dash.Position1.X), float64(dash.Position2.Y-dash.Position1.Y))
+	}
+
+	// a dashed line is drawn as the "on" parts of the pattern along the segment
+	link.SetStrokeDashPattern([]float32{6, 4})
+	assert.False(t, renderer.line.Visible())
+	assert.Len(t, renderer.dashes, 10)
+	for _, dash := range renderer.dashes {
+		assert.InDelta(t, 6, dashLength(dash), 0.01)
+	}
+	assert.Equal(t, renderer.line.Position1, renderer.dashes[0].Position1)
+	assert.InDelta(t, 10, renderer.dashes[1].Position1.X-renderer.dashes[0].Position1.X, 0.01)
+
+	// a dotted line has many short dashes
+	link.SetStrokeDashPattern([]float32{1, 3})
+	assert.Len(t, renderer.dashes, 25)
+	assert.InDelta(t, 1, dashLength(renderer.dashes[0]), 0.01)
+
+	// a segment continues the pattern from where the previous segment left it
+	link.SetStrokeDashPattern([]float32{6, 4})
+	segment.dashOffset = 3
+	renderer.Refresh()
+	assert.Len(t, renderer.dashes, 11)
+	assert.InDelta(t, 3, dashLength(renderer.dashes[0]), 0.01)
+	assert.InDelta(t, 7, renderer.dashes[1].Position1.X-renderer.line.Position1.X, 0.01)
+	assert.InDelta(t, 3, dashLength(renderer.dashes[10]), 0.01)
+
+	// a pattern with no length and nil both restore a solid line
+	link.SetStrokeDashPattern([]float32{0, 0})
+	assert.True(t, renderer.line.Visible())
+	assert.Empty(t, renderer.dashes)
+	link.SetStrokeDashPattern([]float32{6, 4})
+	link.SetStrokeDashPattern(nil)
+	assert.True(t, renderer.line.Visible())
+	assert.Empty(t, renderer.dashes)
+}
+
+func TestPadHoverChanged(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1")
+	diagram.DisplaceNode(node1, fyne.NewPos(100, 100))
+	port := NewPointPad(node1)
+	node1.GetConnectionPads()["port"] = port
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	diagram.DisplaceNode(node2, fyne.NewPos(300, 100))
+	outline := node2.GetDefaultConnectionPad()
+	hovers := []bool{}
+	port.SetOnHoverChanged(func(hovered bool) {
+		hovers = append(hovers, hovered)
+	})
+	outline.SetOnHoverChanged(func(hovered bool) {
+		hovers = append(hovers, hovered)
+	})
+	event := &desktop.MouseEvent{PointEvent: fyne.PointEvent{Position: fyne.NewPos(1, 1)}}
+
+	// the callback is called as the mouse enters and leaves each kind of pad
+	port.MouseIn(event)
+	port.MouseOut()
+	outline.MouseIn(event)
+	outline.MouseOut()
+	assert.Equal(t, []bool{true, false, true, false}, hovers)
+
+	// it is also called while a connection is being made, when the pad becomes the pending pad
+	hovers = []bool{}
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	diagram.ConnectionTransaction = NewConnectionTransaction(link.GetLinkPoints()[1], link, nil, fyne.NewPos(0, 0))
+	outline.MouseIn(event)
+	assert.Equal(t, outline, diagram.ConnectionTransaction.PendingPad)
+	outline.MouseOut()
+	assert.Nil(t, diagram.ConnectionTransaction.PendingPad)
+	assert.Equal(t, []bool{true, false}, hovers)
+	diagram.ConnectionTransaction = nil
+
+	// removing the callback stops it being called
+	hovers = []bool{}
+	port.SetOnHoverChanged(nil)
+	port.MouseIn(event)
+	port.MouseOut()
+	assert.Empty(t, hovers)
+}
+
+func TestPointPadRelativePosition(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := newSizedNode(diagram, "Node1", fyne.NewPos(100, 100), fyne.NewSize(100, 50))
+	port := NewPointPad(node1)
+	node1.GetConnectionPads()["port"] = port
+	node2 := newSizedNode(diagram, "Node2", fyne.NewPos(400, 100), fyne.NewSize(100, 50))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(port)
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+
+	// the center of the pad is placed at the position and the link follows it at once
+	port.SetRelativePosition(fyne.NewPos(100, 25))
+	assert.Equal(t, fyne.NewPos(200, 125), port.GetCenterInDiagramCoordinates())
+	assert.Equal(t, fyne.NewPos(200, 125), link.getSourcePosition().Add(link.Position()))
+	port.SetRelativePosition(fyne.NewPos(50, 0))
+	assert.Equal(t, fyne.NewPos(150, 100), port.GetCenterInDiagramCoordinates())
+	assert.Equal(t, fyne.NewPos(150, 100), link.getSourcePosition().Add(link.Position()))
+
+	// the pad turns with its owner
+	node1.SetRotation(90)
+	center := port.GetCenterInDiagramCoordinates()
+	assert.InDelta(t, 175, center.X, 0.01)
+	assert.InDelta(t, 125, center.Y, 0.01)
+	assert.Equal(t, center, link.getSourcePosition().Add(link.Position()))
+
+	// and moves with it
+	node1.SetRotation(0)
+	diagram.DisplaceNode(node1, fyne.NewPos(20, 30))
+	assert.Equal(t, fyne.NewPos(170, 130), port.GetCenterInDiagramCoordinates())
+	assert.Equal(t, fyne.NewPos(170, 130), link.getSourcePosition().Add(link.Position()))
+}
+
+func TestTooltip(t *testing.T) {
+	app := test.NewApp()
+	assert.NotNil(t, app)
+	shows := []func(){}
+	defer func(start func(func()) *time.Timer) {
+		startTooltipTimer = start
+	}(startTooltipTimer)
+	startTooltipTimer = func(show func()) *time.Timer {
+		shows = append(shows, show)
+		return time.NewTimer(time.Hour)
+	}
+
+	diagram := NewDiagramWidget("Diagram1")
+	node1 := NewDiagramNode(diagram, nil, "Node1").(*BaseDiagramNode)
+	diagram.DisplaceNode(node1, fyne.NewPos(100, 100))
+	node2 := NewDiagramNode(diagram, nil, "Node2")
+	diagram.DisplaceNode(node2, fyne.NewPos(300, 100))
+	link := NewDiagramLink(diagram, "Link1")
+	link.SetSourcePad(node1.GetDefaultConnectionPad())
+	link.SetTargetPad(node2.GetDefaultConnectionPad())
+	tooltip := diagram.tooltip
+	hover := &desktop.MouseEvent{PointEvent: fyne.PointEvent{Position: fyne.NewPos(5, 5)}}
+
+	// an element without a tooltip shows none
+	node1.MouseIn(hover)
+	assert.Empty(t, shows)
+	node1.MouseOut()
+
+	// the tooltip is shown near the mouse once the delay has passed
+	node1.SetTooltip("First node")
+	node1.MouseIn(hover)
+	assert.Len(t, shows, 1)
+	assert.False(t, tooltip.content.Visible())
+	shows[0]()
+	assert.True(t, tooltip.content.Visible())
+	assert.Equal(t, "First node", tooltip.label.Text)
+	assert.Equal(t, fyne.NewPos(105+tooltipOffset, 105+tooltipOffset), tooltip.content.Position())
+	assert.Equal(t, tooltip.content.MinSize(), tooltip.content.Size())
+
+	// moving the mouse hides the tooltip and restarts the delay
+	node1.MouseMoved(&desktop.MouseEvent{PointEvent: fyne.PointEvent{Position: fyne.NewPos(10, 5)}})
+	assert.False(t, tooltip.content.Visible())
+	assert.Len(t, shows, 2)
+
+	// a delay that was cancelled does not show the tooltip
+	node1.MouseOut()
+	shows[1]()
+	assert.False(t, tooltip.content.Visible())
+
+	// links show their tooltips too, and leaving the link hides it
+	link.SetTooltip("The link")
+	link.MouseIn(hover)
+	assert.Len(t, shows, 3)
+	shows[2]()
+	assert.True(t, tooltip.content.Visible())
+	assert.Equal(t, "The link", tooltip.label.Text)
+	link.MouseOut()
+	assert.False(t, tooltip.content.Visible())
+}
@@ -0,0 +1,48 @@
+package diagramwidget
+
+import (
+	"errors"
+	"image/png"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/software"
+)
+
+// ExportPNGRegion renders the part of the diagram with the given origin and size, in diagram coordinates, to a
+// PNG image written to w. The scale is the number of image pixels for each unit of the diagram, so a scale of 2
+// gives an image twice the width and height of the region. The whole region is rendered regardless of which part
+// of the diagram is scrolled into view. Selection handles and tooltips are not included.
+func (dw *DiagramWidget) ExportPNGRegion(origin fyne.Position, size fyne.Size, scale float32, w io.Writer) error {
+	if size.Width <= 0 || size.Height <= 0 {
+		return errors.New("the region to export is empty")
+	}
+	if scale <= 0 {
+		return errors.New("the export scale must be greater than 0")
+	}
+
+	for _, element := range dw.selection {
+		element.HideHandles()
+	}
+	tooltipVisible := dw.tooltip.content.Visible()
+	dw.tooltip.content.Hide()
+	position := dw.drawingArea.Position()
+	defer func() {
+		dw.drawingArea.Move(position)
+		if tooltipVisible {
+			dw.tooltip.content.Show()
+		}
+		for _, element := range dw.selection {
+			element.ShowHandles()
+		}
+	}()
+
+	c := software.NewCanvas()
+	c.SetPadded(false)
+	c.SetScale(scale)
+	c.SetContent(container.NewWithoutLayout(dw.drawingArea))
+	c.Resize(size)
+	dw.drawingArea.Move(fyne.NewPos(-origin.X, -origin.Y))
+	return png.Encode(w, c.Capture())
+}
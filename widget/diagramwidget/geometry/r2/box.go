@@ -1,5 +1,7 @@
 package r2
 
+import "math"
+
 // Box defines a box in R2
 //
 //	            A
@@ -192,6 +194,15 @@ func (b Box) Contains(v Vec2) bool {
 	return true
 }
 
+// Inflate returns a box grown by dx on the left and right and by dy on the top and bottom, keeping the
+// same center. Negative values shrink the box. A box cannot shrink below zero size: it collapses to its center.
+func (b Box) Inflate(dx, dy float64) Box {
+	center := b.Center()
+	width := math.Max(b.S.X+2*dx, 0)
+	height := math.Max(b.S.Y+2*dy, 0)
+	return MakeBox(V2(center.X-width/2, center.Y-height/2), V2(width, height))
+}
+
 // BoundingBox creates a minimum axis-aligned bounding box for the given list
 // of points.
 func BoundingBox(points []Vec2) Box {
@@ -71,3 +71,33 @@ func TestFindPerimeterPointNearestContainedPoint(t *testing.T) {
 		t.Errorf("Point on right not returned, expected 200, 140, got %f, %f", result.X, result.Y)
 	}
 }
+
+func TestInflate(t *testing.T) {
+	box := MakeBox(MakeVec2(100, 100), MakeVec2(100, 50))
+
+	same := box.Inflate(0, 0)
+	if same != box {
+		t.Errorf("Zero inflation changed the box, expected %v, got %v", box, same)
+	}
+
+	grown := box.Inflate(10, 5)
+	if grown.A.X != 90 || grown.A.Y != 95 || grown.S.X != 120 || grown.S.Y != 60 {
+		t.Errorf("Inflated box incorrect, expected 90, 95, 120, 60, got %f, %f, %f, %f", grown.A.X, grown.A.Y, grown.S.X, grown.S.Y)
+	}
+	if grown.Center() != box.Center() {
+		t.Errorf("Inflation moved the center, expected %v, got %v", box.Center(), grown.Center())
+	}
+
+	shrunk := box.Inflate(-10, -5)
+	if shrunk.A.X != 110 || shrunk.A.Y != 105 || shrunk.S.X != 80 || shrunk.S.Y != 40 {
+		t.Errorf("Shrunk box incorrect, expected 110, 105, 80, 40, got %f, %f, %f, %f", shrunk.A.X, shrunk.A.Y, shrunk.S.X, shrunk.S.Y)
+	}
+
+	collapsed := box.Inflate(-100, -100)
+	if collapsed.S.X != 0 || collapsed.S.Y != 0 || collapsed.Center() != box.Center() {
+		t.Errorf("Over-shrunk box should collapse to its center, got %v", collapsed)
+	}
+	if !collapsed.Contains(box.Center()) {
+		t.Errorf("Collapsed box should contain its center")
+	}
+}
@@ -0,0 +1,94 @@
+package r2
+
+import "math"
+
+// segmentTolerance is used when deciding whether segments are parallel or a point lies on a segment
+const segmentTolerance = 1e-9
+
+// LineSegment describes the part of a line in R2 between two endpoints
+type LineSegment struct {
+	// P1 is the first endpoint of the segment
+	P1 Vec2
+
+	// P2 is the second endpoint of the segment
+	P2 Vec2
+}
+
+// MakeLineSegment creates an r2 LineSegment with endpoints p1 and p2
+func MakeLineSegment(p1, p2 Vec2) LineSegment {
+	return LineSegment{
+		P1: p1,
+		P2: p2,
+	}
+}
+
+// Direction returns the vector from the first endpoint to the second
+func (s LineSegment) Direction() Vec2 {
+	return s.P2.Add(s.P1.Scale(-1))
+}
+
+// Length returns the length of the segment
+func (s LineSegment) Length() float64 {
+	return s.Direction().Length()
+}
+
+// IntersectSegment returns the point at which the two segments cross, and a Boolean indicating whether they do.
+// Unlike IntersectLines, the intersection must lie within the endpoints of both segments; segments that touch
+// at an endpoint (for example in a T) are considered to intersect. Parallel segments do not intersect unless they
+// are collinear and overlap, in which case the overlapping point nearest to P1 of s is returned.
+// If the segments do not intersect, the zero vector is returned.
+func (s LineSegment) IntersectSegment(other LineSegment) (Vec2, bool) {
+	r := s.Direction()
+	q := other.Direction()
+	offset := other.P1.Add(s.P1.Scale(-1))
+	denominator := cross(r, q)
+
+	if math.Abs(denominator) < segmentTolerance {
+		if math.Abs(cross(offset, r)) >= segmentTolerance {
+			// parallel and not on the same line
+			return V2(0, 0), false
+		}
+		return s.intersectCollinear(other)
+	}
+
+	t := cross(offset, q) / denominator
+	u := cross(offset, r) / denominator
+	if t < -segmentTolerance || t > 1+segmentTolerance || u < -segmentTolerance || u > 1+segmentTolerance {
+		return V2(0, 0), false
+	}
+	return s.P1.Add(r.Scale(t)), true
+}
+
+// intersectCollinear finds the overlap of two segments lying on the same line
+func (s LineSegment) intersectCollinear(other LineSegment) (Vec2, bool) {
+	r := s.Direction()
+	lengthSquared := r.Dot(r)
+	if lengthSquared < segmentTolerance {
+		// s is a single point
+		if other.containsCollinearPoint(s.P1) {
+			return s.P1, true
+		}
+		return V2(0, 0), false
+	}
+
+	// project the other segment's endpoints onto s, where s runs from 0 to 1
+	t0 := other.P1.Add(s.P1.Scale(-1)).Dot(r) / lengthSquared
+	t1 := other.P2.Add(s.P1.Scale(-1)).Dot(r) / lengthSquared
+	start := math.Max(math.Min(t0, t1), 0)
+	end := math.Min(math.Max(t0, t1), 1)
+	if start > end+segmentTolerance {
+		return V2(0, 0), false
+	}
+	return s.P1.Add(r.Scale(start)), true
+}
+
+// containsCollinearPoint returns true if a point known to be on the segment's line is between its endpoints
+func (s LineSegment) containsCollinearPoint(p Vec2) bool {
+	return p.X >= math.Min(s.P1.X, s.P2.X)-segmentTolerance && p.X <= math.Max(s.P1.X, s.P2.X)+segmentTolerance &&
+		p.Y >= math.Min(s.P1.Y, s.P2.Y)-segmentTolerance && p.Y <= math.Max(s.P1.Y, s.P2.Y)+segmentTolerance
+}
+
+// cross returns the z component of the cross product of v and u
+func cross(v, u Vec2) float64 {
+	return v.X*u.Y - v.Y*u.X
+}
@@ -0,0 +1,51 @@
+package r2
+
+import (
+	"testing"
+)
+
+func TestIntersectSegment(t *testing.T) {
+	horizontal := MakeLineSegment(V2(0, 0), V2(10, 0))
+
+	// crossing
+	p, ok := horizontal.IntersectSegment(MakeLineSegment(V2(5, -5), V2(5, 5)))
+	if !ok || p.X != 5 || p.Y != 0 {
+		t.Errorf("Crossing segments failed, expected 5, 0, true, got %f, %f, %t", p.X, p.Y, ok)
+	}
+
+	// the lines cross, but beyond the end of the second segment
+	p, ok = horizontal.IntersectSegment(MakeLineSegment(V2(5, 1), V2(5, 5)))
+	if ok {
+		t.Errorf("Segments that do not reach each other reported an intersection at %f, %f", p.X, p.Y)
+	}
+
+	// T-intersection at the end of the second segment
+	p, ok = horizontal.IntersectSegment(MakeLineSegment(V2(5, 0), V2(5, 5)))
+	if !ok || p.X != 5 || p.Y != 0 {
+		t.Errorf("T-intersection failed, expected 5, 0, true, got %f, %f, %t", p.X, p.Y, ok)
+	}
+
+	// T-intersection at the end of the first segment
+	p, ok = horizontal.IntersectSegment(MakeLineSegment(V2(10, -5), V2(10, 5)))
+	if !ok || p.X != 10 || p.Y != 0 {
+		t.Errorf("T-intersection at endpoint failed, expected 10, 0, true, got %f, %f, %t", p.X, p.Y, ok)
+	}
+
+	// parallel
+	p, ok = horizontal.IntersectSegment(MakeLineSegment(V2(0, 1), V2(10, 1)))
+	if ok {
+		t.Errorf("Parallel segments reported an intersection at %f, %f", p.X, p.Y)
+	}
+
+	// collinear and overlapping
+	p, ok = horizontal.IntersectSegment(MakeLineSegment(V2(15, 0), V2(5, 0)))
+	if !ok || p.X != 5 || p.Y != 0 {
+		t.Errorf("Overlapping collinear segments failed, expected 5, 0, true, got %f, %f, %t", p.X, p.Y, ok)
+	}
+
+	// collinear and disjoint
+	p, ok = horizontal.IntersectSegment(MakeLineSegment(V2(11, 0), V2(20, 0)))
+	if ok {
+		t.Errorf("Disjoint collinear segments reported an intersection at %f, %f", p.X, p.Y)
+	}
+}
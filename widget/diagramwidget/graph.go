@@ -0,0 +1,66 @@
+package diagramwidget
+
+import "errors"
+
+// ErrCycle is returned by TopologicalOrder when the links of the diagram form a cycle
+var ErrCycle = errors.New("the diagram contains a cycle")
+
+// HasCycle returns true if following the links of the diagram from their sources to their targets leads back to a
+// node that has already been visited, such as a circular dependency in a workflow. A link from a node to itself is
+// a cycle. Only links that connect two nodes are followed, links with a floating end or connected to another link
+// are ignored.
+func (dw *DiagramWidget) HasCycle() bool {
+	_, err := dw.TopologicalOrder()
+	return err != nil
+}
+
+// TopologicalOrder returns the nodes of the diagram ordered so that the source of each link comes before its
+// target, which is the order in which the steps of a workflow can be carried out. Nodes that are not ordered by
+// the links keep the order in which they are drawn. Only links that connect two nodes are followed, as in HasCycle.
+// If the links form a cycle there is no such order and ErrCycle is returned.
+func (dw *DiagramWidget) TopologicalOrder() ([]DiagramElement, error) {
+	nodes := dw.GetDiagramNodes()
+	// the pads of a node extending BaseDiagramNode are owned by the base node, so the nodes are matched by ID
+	indices := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		indices[node.GetDiagramElementID()] = i
+	}
+
+	successors := make([][]int, len(nodes))
+	predecessorCount := make([]int, len(nodes))
+	for _, link := range dw.GetDiagramLinks() {
+		sourcePad, targetPad := link.GetSourcePad(), link.GetTargetPad()
+		if sourcePad == nil || targetPad == nil {
+			continue
+		}
+		source, sourceIsNode := indices[sourcePad.GetPadOwner().GetDiagramElementID()]
+		target, targetIsNode := indices[targetPad.GetPadOwner().GetDiagramElementID()]
+		if !sourceIsNode || !targetIsNode {
+			continue
+		}
+		successors[source] = append(successors[source], target)
+		predecessorCount[target]++
+	}
+
+	// repeatedly take the first node in drawing order all of whose predecessors have been taken
+	order := make([]DiagramElement, 0, len(nodes))
+	taken := make([]bool, len(nodes))
+	for len(order) < len(nodes) {
+		next := -1
+		for i := range nodes {
+			if !taken[i] && predecessorCount[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			return nil, ErrCycle
+		}
+		taken[next] = true
+		order = append(order, nodes[next])
+		for _, successor := range successors[next] {
+			predecessorCount[successor]--
+		}
+	}
+	return order, nil
+}
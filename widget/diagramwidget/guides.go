@@ -0,0 +1,121 @@
+package diagramwidget
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+)
+
+// defaultAlignmentGuideThreshold is the distance within which a dragged node snaps into alignment
+const defaultAlignmentGuideThreshold float32 = 5
+
+// alignmentGuides snaps a dragged node into line with the edges and centers of the other nodes,
+// showing a guide line along each alignment that it snaps to
+type alignmentGuides struct {
+	enabled   bool
+	threshold float32
+	// vertical is shown where the node is aligned horizontally, horizontal where it is aligned vertically
+	vertical   *canvas.Line
+	horizontal *canvas.Line
+	// dragged is the node being dragged and unsnapped is where it would be without snapping
+	dragged   *BaseDiagramNode
+	unsnapped fyne.Position
+}
+
+func newAlignmentGuides() *alignmentGuides {
+	guides := &alignmentGuides{
+		threshold:  defaultAlignmentGuideThreshold,
+		vertical:   canvas.NewLine(theme.PrimaryColor()),
+		horizontal: canvas.NewLine(theme.PrimaryColor()),
+	}
+	guides.vertical.Hide()
+	guides.horizontal.Hide()
+	return guides
+}
+
+// SetAlignmentGuidesEnabled sets whether a node being dragged snaps into line with the edges and centers of the
+// other nodes when it comes within the threshold of them. A guide line is shown along each alignment while dragging.
+func (dw *DiagramWidget) SetAlignmentGuidesEnabled(enabled bool) {
+	dw.guides.enabled = enabled
+}
+
+// SetAlignmentGuideThreshold sets the distance within which a dragged node snaps into alignment. Defaults to 5.
+func (dw *DiagramWidget) SetAlignmentGuideThreshold(threshold float32) {
+	dw.guides.threshold = threshold
+}
+
+// alignedPosition returns the position to which the dragged node is moved, snapping the unsnapped position into
+// line with the other nodes, and shows the guides for the alignments
+func (dw *DiagramWidget) alignedPosition(node *BaseDiagramNode, delta fyne.Position) fyne.Position {
+	guides := dw.guides
+	if guides.dragged != node {
+		guides.dragged = node
+		guides.unsnapped = node.Position()
+	}
+	guides.unsnapped = guides.unsnapped.Add(delta)
+
+	size := node.Size()
+	position := guides.unsnapped
+	bestX, bestY := guides.threshold, guides.threshold
+	var alignedX, alignedY DiagramNode
+	var guideX, guideY float32
+	for _, other := range dw.GetDiagramNodes() {
+		if other.getBaseDiagramNode() == node || !other.Visible() {
+			continue
+		}
+		otherPosition, otherSize := other.Position(), other.Size()
+		for _, offsetX := range []float32{0, size.Width / 2, size.Width} {
+			for _, lineX := range []float32{otherPosition.X, otherPosition.X + otherSize.Width/2, otherPosition.X + otherSize.Width} {
+				if distance := abs(guides.unsnapped.X + offsetX - lineX); distance < bestX {
+					bestX, alignedX, guideX = distance, other, lineX
+					position.X = lineX - offsetX
+				}
+			}
+		}
+		for _, offsetY := range []float32{0, size.Height / 2, size.Height} {
+			for _, lineY := range []float32{otherPosition.Y, otherPosition.Y + otherSize.Height/2, otherPosition.Y + otherSize.Height} {
+				if distance := abs(guides.unsnapped.Y + offsetY - lineY); distance < bestY {
+					bestY, alignedY, guideY = distance, other, lineY
+					position.Y = lineY - offsetY
+				}
+			}
+		}
+	}
+
+	// each guide spans both of the aligned nodes
+	guides.vertical.Hidden = alignedX == nil
+	if alignedX != nil {
+		top := fyne.Min(position.Y, alignedX.Position().Y)
+		bottom := fyne.Max(position.Y+size.Height, alignedX.Position().Y+alignedX.Size().Height)
+		guides.vertical.Position1 = fyne.NewPos(guideX, top)
+		guides.vertical.Position2 = fyne.NewPos(guideX, bottom)
+	}
+	guides.horizontal.Hidden = alignedY == nil
+	if alignedY != nil {
+		left := fyne.Min(position.X, alignedY.Position().X)
+		right := fyne.Max(position.X+size.Width, alignedY.Position().X+alignedY.Size().Width)
+		guides.horizontal.Position1 = fyne.NewPos(left, guideY)
+		guides.horizontal.Position2 = fyne.NewPos(right, guideY)
+	}
+	guides.vertical.Refresh()
+	guides.horizontal.Refresh()
+	return position
+}
+
+// hideAlignmentGuides hides the guides at the end of a drag
+func (dw *DiagramWidget) hideAlignmentGuides() {
+	guides := dw.guides
+	guides.dragged = nil
+	if guides.vertical.Visible() || guides.horizontal.Visible() {
+		guides.vertical.Hide()
+		guides.horizontal.Hide()
+		dw.drawingArea.Refresh()
+	}
+}
+
+func abs(value float32) float32 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -44,11 +44,17 @@ func (h *Handle) CreateRenderer() fyne.WidgetRenderer {
 // Dragged respondss to drag events, passing them on to the owning DiagramElement. It is the
 // DiagramElement that determines what to do as a result of the drag.
 func (h *Handle) Dragged(event *fyne.DragEvent) {
+	if !h.de.GetDiagram().IsEditable() {
+		return
+	}
 	h.de.handleDragged(h, event)
 }
 
 // DragEnd passes the event on to the owning DiagramElement
 func (h *Handle) DragEnd() {
+	if !h.de.GetDiagram().IsEditable() {
+		return
+	}
 	h.de.handleDragEnd(h)
 }
 
@@ -0,0 +1,48 @@
+package diagramwidget
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// defaultImageSize is the size at which the image of an image node is drawn
+const defaultImageSize float32 = 48
+
+var _ DiagramNode = (*imageNode)(nil)
+
+// imageNode is a DiagramNode showing an image with a caption beneath it
+type imageNode struct {
+	BaseDiagramNode
+	resource fyne.Resource
+	caption  string
+}
+
+// NewImageNode creates a DiagramNode showing the image with the label as a caption beneath it and adds it to the
+// DiagramWidget. The node sizes itself to fit the image and label, and can be connected to using its edge pad.
+// The node's ID is derived from the label, with a numeric suffix added if the label is already in use as an ID.
+func NewImageNode(diagram *DiagramWidget, res fyne.Resource, label string) DiagramNode {
+	id := label
+	for i := 2; id == "" || diagram.GetDiagramElement(id) != nil; i++ {
+		id = label + "-" + strconv.Itoa(i)
+	}
+	return newImageNode(diagram, res, label, id)
+}
+
+func newImageNode(diagram *DiagramWidget, res fyne.Resource, label string, nodeID string) DiagramNode {
+	image := canvas.NewImageFromResource(res)
+	image.FillMode = canvas.ImageFillContain
+	image.SetMinSize(fyne.NewSize(defaultImageSize, defaultImageSize))
+	caption := widget.NewLabel(label)
+	caption.Alignment = fyne.TextAlignCenter
+
+	in := &imageNode{resource: res, caption: label}
+	InitializeBaseDiagramNode(in, diagram, container.NewVBox(image, caption), nodeID)
+	// Size to the image and caption rather than the default size
+	in.InnerSize = fyne.Size{}
+	in.Refresh()
+	return in
+}
@@ -0,0 +1,172 @@
+package diagramwidget
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Validate that diagramInspector is a widget
+var _ fyne.Widget = (*diagramInspector)(nil)
+
+// diagramInspector shows the properties of the primary selection of a diagram in a collapsible form
+type diagramInspector struct {
+	widget.BaseWidget
+	diagram *DiagramWidget
+	element DiagramElement
+	// updating is true while the fields are filled from the element, so that their changes are not applied to it
+	updating bool
+
+	id                  *widget.Label
+	x, y, width, height *widget.Entry
+	form                *widget.Form
+	// customRows is the number of rows of the form provided by the application, which follow the standard ones
+	customRows int
+	empty      *widget.Label
+	content    *fyne.Container
+	accordion  *widget.Accordion
+}
+
+// NewDiagramInspector creates a panel showing the ID, position and size of the element selected in the diagram,
+// followed by the rows that the application provides with SetInspectorFields. The panel follows the selection,
+// and editing the position or size of a node moves or resizes it straight away. The position and size of a link
+// follow its pads, so they are shown but cannot be edited. The properties can be collapsed to save space.
+func NewDiagramInspector(d *DiagramWidget) fyne.Widget {
+	di := &diagramInspector{diagram: d}
+	di.id = widget.NewLabel("")
+	di.x = di.newNumberEntry(func(node DiagramNode, value float32) {
+		d.DisplaceNode(node, fyne.NewPos(value-node.Position().X, 0))
+	})
+	di.y = di.newNumberEntry(func(node DiagramNode, value float32) {
+		d.DisplaceNode(node, fyne.NewPos(0, value-node.Position().Y))
+	})
+	di.width = di.newNumberEntry(func(node DiagramNode, value float32) {
+		di.resizeNode(node, fyne.NewSize(value, node.Size().Height))
+	})
+	di.height = di.newNumberEntry(func(node DiagramNode, value float32) {
+		di.resizeNode(node, fyne.NewSize(node.Size().Width, value))
+	})
+	di.form = widget.NewForm(
+		widget.NewFormItem("ID", di.id),
+		widget.NewFormItem("X", di.x),
+		widget.NewFormItem("Y", di.y),
+		widget.NewFormItem("Width", di.width),
+		widget.NewFormItem("Height", di.height),
+	)
+	di.empty = widget.NewLabel("No element selected")
+	di.content = container.NewStack(di.empty, di.form)
+	di.accordion = widget.NewAccordion(widget.NewAccordionItem("Properties", di.content))
+	di.accordion.Open(0)
+	di.ExtendBaseWidget(di)
+
+	d.selectionListeners = append(d.selectionListeners, di.selectionChanged)
+	di.selectionChanged()
+	return di
+}
+
+// SetInspectorFields sets the function returning the rows that the inspectors of the diagram show for an element
+// below its ID, position and size, for example to edit the properties of the model object it represents.
+// The function is called each time an element is selected and may return nil to add no rows.
+func (dw *DiagramWidget) SetInspectorFields(fields func(DiagramElement) []*widget.FormItem) {
+	dw.inspectorFields = fields
+	dw.notifySelectionListeners()
+}
+
+// notifySelectionListeners informs the listeners, such as inspectors, that the selection may have changed
+func (dw *DiagramWidget) notifySelectionListeners() {
+	for _, listener := range dw.selectionListeners {
+		listener()
+	}
+}
+
+func (di *diagramInspector) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(di.accordion)
+}
+
+// newNumberEntry returns an entry for a number that applies valid values to the selected node
+func (di *diagramInspector) newNumberEntry(apply func(DiagramNode, float32)) *widget.Entry {
+	entry := widget.NewEntry()
+	entry.OnChanged = func(text string) {
+		node, ok := di.element.(DiagramNode)
+		if di.updating || !ok {
+			return
+		}
+		value, err := strconv.ParseFloat(text, 32)
+		if err != nil {
+			return
+		}
+		apply(node, float32(value))
+		// the other fields may change too, for example if the diagram is shifted to keep the node in view
+		di.updating = true
+		di.updatePosition(entry)
+		di.updating = false
+	}
+	return entry
+}
+
+// resizeNode sets the inner size of the node so that the node has the size, as far as its inner object allows
+func (di *diagramInspector) resizeNode(node DiagramNode, size fyne.Size) {
+	bdn := node.getBaseDiagramNode()
+	padding := 2 * bdn.properties.Padding
+	bdn.InnerSize = fyne.NewSize(size.Width-padding, size.Height-padding).Max(fyne.NewSize(0, 0))
+	node.Refresh()
+}
+
+// selectionChanged shows the properties of the primary selection of the diagram
+func (di *diagramInspector) selectionChanged() {
+	di.element = di.diagram.GetPrimarySelection()
+	di.updating = true
+	defer func() {
+		di.updating = false
+	}()
+
+	if di.element == nil {
+		di.form.Hide()
+		di.empty.Show()
+		di.setCustomFields(nil)
+		di.content.Refresh()
+		return
+	}
+	di.empty.Hide()
+	di.form.Show()
+	di.id.SetText(di.element.GetDiagramElementID())
+	editable := di.element.IsNode() && di.diagram.IsEditable()
+	for _, entry := range []*widget.Entry{di.x, di.y, di.width, di.height} {
+		if editable {
+			entry.Enable()
+		} else {
+			entry.Disable()
+		}
+	}
+	di.updatePosition(nil)
+	di.setCustomFields(di.element)
+	di.content.Refresh()
+}
+
+// setCustomFields replaces the rows of the application with those for the element
+func (di *diagramInspector) setCustomFields(element DiagramElement) {
+	di.form.Items = di.form.Items[:len(di.form.Items)-di.customRows]
+	di.customRows = 0
+	if element != nil && di.diagram.inspectorFields != nil {
+		items := di.diagram.inspectorFields(element)
+		di.form.Items = append(di.form.Items, items...)
+		di.customRows = len(items)
+	}
+	di.form.Refresh()
+}
+
+// updatePosition fills the position and size fields from the element, except for the field being edited
+func (di *diagramInspector) updatePosition(editing *widget.Entry) {
+	position := di.element.Position()
+	size := di.element.Size()
+	for entry, value := range map[*widget.Entry]float32{
+		di.x: position.X, di.y: position.Y, di.width: size.Width, di.height: size.Height,
+	} {
+		text := strconv.FormatFloat(float64(value), 'f', -1, 32)
+		if entry != editing && entry.Text != text {
+			entry.SetText(text)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package diagramwidget
 
 import (
+	"image/color"
 	"math"
+	"sync"
 
 	"fyne.io/x/fyne/widget/diagramwidget/geometry/r2"
 
@@ -40,12 +42,17 @@ func (le LinkEnd) ToString() string {
 type DiagramLink interface {
 	DiagramElement
 	getBaseDiagramLink() *BaseDiagramLink
+	GetFloatingEndpoint(LinkEnd) (fyne.Position, bool)
 	GetLinkPoints() []*LinkPoint
 	GetSourcePad() ConnectionPad
 	GetSourceHandle() *Handle
 	GetTargetPad() ConnectionPad
 	GetTargetHandle() *Handle
 	isConnectionAllowed(*LinkPoint, ConnectionPad) bool
+	SetEndpointInset(float32)
+	SetFloatingEndpoint(LinkEnd, fyne.Position)
+	SetMinVisibleLength(float32)
+	SetStrokeDashPattern([]float32)
 	SetSourcePad(ConnectionPad)
 	SetTargetPad(ConnectionPad)
 }
@@ -80,11 +87,29 @@ type BaseDiagramLink struct {
 	midpointAnchoredText map[string]*AnchoredText
 	// We keep the typed link so that when extensions are created the callbacks are called with the correct type
 	typedLink DiagramLink
+	// fadeLock guards opacity and animating, which the edit animation changes on its own goroutine
+	fadeLock sync.Mutex
+	// opacity is used to fade the link in and out, 1 is fully visible
+	opacity float32
+	// animating is true while the link is fading in or out, during which it does not respond to the user
+	animating bool
+	// endpointInset is the gap left between a connected end of the link and its pad
+	endpointInset float32
+	// minVisibleLength is the length below which the link is lengthened so that it is not hidden by its nodes
+	minVisibleLength float32
+	// dashPattern holds alternating on and off lengths used to draw the link, nil for a solid line
+	dashPattern []float32
+	// parallelPads are the pads the link was connected to when it was last fanned out from parallel links
+	parallelPads [2]ConnectionPad
+	// floating is true for each end, indexed by LinkEnd, that is fixed at the diagram coordinates in floatingEnds
+	// rather than connected to a pad
+	floating     [2]bool
+	floatingEnds [2]fyne.Position
 }
 
 // NewDiagramLink creates a DiagramLink widget connecting the two indicated ConnectionPads. It adds itself to the
-// DiagramWidget, indexed by the supplied LinkID. This id must be unique across all of the DiagramElements in the Diagram.
-// It can be used to retrieve the DiagramLink from the Diagram. The ID is intended to be used to facilitate mapping the
+// DiagramWidget, indexed by the supplied LinkID. This id must be unique across all of the DiagramElements in the Diagram;
+// a link with an ID that is already in use is logged as an error and not added. It can be used to retrieve the DiagramLink from the Diagram. The ID is intended to be used to facilitate mapping the
 // DiagramLink to the information it represents in the application. The DiagramLink uses the DiagramWidget's ForegroundColor
 // as the default color for the line segments.
 func NewDiagramLink(diagram *DiagramWidget, linkID string) *BaseDiagramLink {
@@ -97,6 +122,7 @@ func NewDiagramLink(diagram *DiagramWidget, linkID string) *BaseDiagramLink {
 func InitializeBaseDiagramLink(diagramLink DiagramLink, diagram *DiagramWidget, linkID string) {
 	bdl := diagramLink.getBaseDiagramLink()
 	bdl.linkPoints = []*LinkPoint{}
+	bdl.opacity = 1
 	bdl.linkSegments = []*LinkSegment{}
 	bdl.sourceAnchoredText = make(map[string]*AnchoredText)
 	bdl.midpointAnchoredText = make(map[string]*AnchoredText)
@@ -117,7 +143,9 @@ func InitializeBaseDiagramLink(diagramLink DiagramLink, diagram *DiagramWidget,
 		bdl.handles[linkEnd.ToString()] = newHandle
 		newHandle.Hide()
 	}
-	bdl.diagram.addLink(diagramLink)
+	if err := bdl.diagram.addLink(diagramLink); err != nil {
+		fyne.LogError("adding diagram link "+linkID, err)
+	}
 	diagramLink.Refresh()
 }
 
@@ -254,6 +282,39 @@ func (bdl *BaseDiagramLink) GetSourceHandle() *Handle {
 	return bdl.handles[SOURCE.ToString()]
 }
 
+// GetFloatingEndpoint returns the position in diagram coordinates of the end of the link and true if the end is
+// floating, or false if it is connected to a pad or is being connected
+func (bdl *BaseDiagramLink) GetFloatingEndpoint(end LinkEnd) (fyne.Position, bool) {
+	if !bdl.floating[end] || bdl.endPad(end) != nil {
+		return fyne.Position{}, false
+	}
+	return bdl.floatingEnds[end], true
+}
+
+// SetFloatingEndpoint disconnects the end of the link from its pad and fixes it at the position in diagram
+// coordinates, where it stays when elements move, for example to draw an arrow that points at nothing. Dragging the
+// handle of a floating end moves it, or connects it if it is dropped on a pad. SetSourcePad and SetTargetPad also
+// connect a floating end.
+func (bdl *BaseDiagramLink) SetFloatingEndpoint(end LinkEnd, position fyne.Position) {
+	bdl.floating[end] = true
+	bdl.floatingEnds[end] = position
+	if oldPad := bdl.endPad(end); oldPad != nil {
+		bdl.setPadSilently(end, nil)
+		if bdl.diagram.LinkConnectionChangedCallback != nil {
+			bdl.diagram.LinkConnectionChangedCallback(bdl.typedLink, end.ToString(), oldPad, nil)
+		}
+	}
+	bdl.Refresh()
+}
+
+// endPad returns the pad to which the end of the link is connected
+func (bdl *BaseDiagramLink) endPad(end LinkEnd) ConnectionPad {
+	if end == TARGET {
+		return bdl.targetPad
+	}
+	return bdl.sourcePad
+}
+
 // GetSourcePad returns the pad (on another DiagramElement) to which the source end is connected
 func (bdl *BaseDiagramLink) GetSourcePad() ConnectionPad {
 	return bdl.sourcePad
@@ -278,24 +339,31 @@ func (bdl *BaseDiagramLink) getTargetPosition() fyne.Position {
 }
 
 func (bdl *BaseDiagramLink) handleDragged(handle *Handle, event *fyne.DragEvent) {
+	if bdl.isAnimating() {
+		return
+	}
 	handleKey := bdl.getHandleKey(handle)
 	var linkPoint *LinkPoint
 	var pad ConnectionPad
+	var end LinkEnd
 	switch handleKey {
 	case SOURCE.ToString():
 		linkPoint = bdl.linkPoints[0]
 		pad = bdl.sourcePad
-		bdl.sourcePad = nil
+		end = SOURCE
 	case TARGET.ToString():
 		linkPoint = bdl.linkPoints[len(bdl.linkPoints)-1]
 		pad = bdl.targetPad
-		bdl.targetPad = nil
+		end = TARGET
 	}
 	if linkPoint == nil {
 		return
 	}
 	connTrans := bdl.diagram.ConnectionTransaction
 	if connTrans == nil {
+		if pad != nil && !bdl.diagram.AllowLinkReconnection {
+			return
+		}
 		connTrans = NewConnectionTransaction(linkPoint, bdl, pad, linkPoint.Position())
 		bdl.diagram.ConnectionTransaction = connTrans
 		// TODO remove this after fyne Issue #3906 has been resolved
@@ -305,9 +373,18 @@ func (bdl *BaseDiagramLink) handleDragged(handle *Handle, event *fyne.DragEvent)
 		// The existing transaction is for a different linkPoint
 		return
 	}
+	switch handleKey {
+	case SOURCE.ToString():
+		bdl.sourcePad = nil
+	case TARGET.ToString():
+		bdl.targetPad = nil
+	}
 	currentPosition := linkPoint.Position()
 	newPosition := fyne.NewPos(currentPosition.X+event.Dragged.DX, currentPosition.Y+event.Dragged.DY)
 	linkPoint.Move(newPosition)
+	if bdl.floating[end] {
+		bdl.floatingEnds[end] = newPosition.Add(bdl.Position())
+	}
 	bdl.Refresh()
 }
 
@@ -315,35 +392,35 @@ func (bdl *BaseDiagramLink) handleDragEnd(handle *Handle) {
 	connTrans := bdl.diagram.ConnectionTransaction
 	handleKey := bdl.getHandleKey(handle)
 	if connTrans != nil {
-		if connTrans.PendingPad != nil {
+		linkPointPosition := connTrans.LinkPoint.Position().Add(bdl.Position())
+		if connTrans.PendingPad == nil {
+			connTrans.PendingPad = bdl.diagram.findNearestPad(connTrans.LinkPoint, linkPointPosition)
+		} else if _, isPort := connTrans.PendingPad.(*PointPad); !isPort {
+			// a drop on the outline of an element next to one of its ports connects to the port
+			port := bdl.diagram.findNearestPort(connTrans.LinkPoint, linkPointPosition, connTrans.PendingPad.GetPadOwner())
+			if port != nil {
+				connTrans.PendingPad = port
+			}
+		}
+		if connTrans.PendingPad != nil && connTrans.PendingPad != connTrans.InitialPad {
 			// We have a new pad for connection
 			if connTrans.InitialPad != nil {
 				bdl.diagram.removeLinkDependency(connTrans.InitialPad.GetPadOwner(), bdl, connTrans.InitialPad)
 			}
 			bdl.diagram.addLinkDependency(connTrans.PendingPad.GetPadOwner(), bdl, connTrans.PendingPad)
 			switch handleKey {
-			case "source":
-				bdl.sourcePad = connTrans.PendingPad
-			case "target":
-				bdl.targetPad = connTrans.PendingPad
-			}
-			switch handleKey {
 			case SOURCE.ToString():
 				bdl.sourcePad = connTrans.PendingPad
+				bdl.floating[SOURCE] = false
 			case TARGET.ToString():
 				bdl.targetPad = connTrans.PendingPad
+				bdl.floating[TARGET] = false
 			}
 			if bdl.diagram.LinkConnectionChangedCallback != nil {
 				bdl.diagram.LinkConnectionChangedCallback(bdl.typedLink, handleKey, connTrans.InitialPad, connTrans.PendingPad)
 			}
 		} else {
-			// We revert to the original pad.
-			switch handleKey {
-			case "source":
-				bdl.sourcePad = connTrans.InitialPad
-			case "target":
-				bdl.targetPad = connTrans.InitialPad
-			}
+			// We revert to the original pad, which snaps the end back to it when the link is refreshed
 			switch handleKey {
 			case SOURCE.ToString():
 				bdl.sourcePad = connTrans.InitialPad
@@ -396,20 +473,78 @@ func (bdl *BaseDiagramLink) IsNode() bool {
 	return false
 }
 
-// MouseIn responds to the mouse entering the bounding rectangle of the Link
+// IsResizable returns false because the size of a link follows its ends
+func (bdl *BaseDiagramLink) IsResizable() bool {
+	return false
+}
+
+// MouseIn responds to the mouse entering the bounding rectangle of the Link by scheduling the tooltip
 func (bdl *BaseDiagramLink) MouseIn(event *desktop.MouseEvent) {
+	bdl.diagram.scheduleTooltip(bdl, bdl.Position().Add(event.Position))
 }
 
 // MouseMoved responds to the mouse moving while within the bounding rectangle of the Link
+// by restarting the tooltip delay
 func (bdl *BaseDiagramLink) MouseMoved(event *desktop.MouseEvent) {
+	bdl.diagram.scheduleTooltip(bdl, bdl.Position().Add(event.Position))
 }
 
-// MouseOut responds to the mouse leaving the bounding rectangle of the Link
+// MouseOut responds to the mouse leaving the bounding rectangle of the Link by hiding the tooltip
 func (bdl *BaseDiagramLink) MouseOut() {
+	bdl.diagram.hideTooltip()
+}
+
+// renderForegroundColor returns the foreground color used to render the link, taking any fade into account
+func (bdl *BaseDiagramLink) renderForegroundColor() color.Color {
+	c := bdl.diagramElement.renderForegroundColor()
+	bdl.fadeLock.Lock()
+	opacity := bdl.opacity
+	bdl.fadeLock.Unlock()
+	if opacity >= 1 {
+		return c
+	}
+	return scaleAlpha(c, opacity)
+}
+
+// SetEndpointInset sets the gap left between each connected end of the link and the pad it is connected to,
+// measured along the link. This keeps decorations such as arrowheads clear of thick node borders.
+func (bdl *BaseDiagramLink) SetEndpointInset(inset float32) {
+	bdl.endpointInset = inset
+	bdl.Refresh()
+}
+
+// SetMinVisibleLength sets the length below which a link is lengthened so that it stays legible, for example
+// when the nodes it connects are very close or overlap. The link is then drawn with this length, centered on
+// the gap between its connection points and pointing from the center of the source pad to the center of the
+// target pad, so that it and its decorations such as arrowheads extend beyond the nodes. Zero, the default,
+// draws the link between its connection points whatever its length.
+func (bdl *BaseDiagramLink) SetMinVisibleLength(length float32) {
+	bdl.minVisibleLength = length
+	bdl.Refresh()
+}
+
+// SetRotation has no effect on a link, which is always drawn between its pads
+func (bdl *BaseDiagramLink) SetRotation(degrees float32) {
+}
+
+// SetStrokeDashPattern sets the alternating on and off lengths used to draw the line segments of the link,
+// for example []float32{6, 3} for a dashed line or []float32{1, 3} for a dotted one. The pattern continues
+// from one segment to the next. Passing nil restores a solid line. Decorations are always drawn solid.
+func (bdl *BaseDiagramLink) SetStrokeDashPattern(pattern []float32) {
+	bdl.dashPattern = nil
+	total := float32(0)
+	for _, length := range pattern {
+		total += length
+	}
+	if total > 0 {
+		bdl.dashPattern = append([]float32{}, pattern...)
+	}
+	bdl.Refresh()
 }
 
 // SetSourcePad sets the source pad (belonging to another DiagramElement) and adds the link dependency to the diagram
 func (bdl *BaseDiagramLink) SetSourcePad(pad ConnectionPad) {
+	bdl.floating[SOURCE] = false
 	oldPad := bdl.sourcePad
 	if oldPad != pad {
 		if oldPad != nil {
@@ -424,8 +559,36 @@ func (bdl *BaseDiagramLink) SetSourcePad(pad ConnectionPad) {
 	}
 }
 
+// setPadSilently connects the end of the link to the pad, or disconnects it if the pad is nil, without invoking the
+// LinkConnectionChangedCallback. It is used when the diagram itself temporarily reroutes the link.
+func (bdl *BaseDiagramLink) setPadSilently(end LinkEnd, pad ConnectionPad) {
+	oldPad := bdl.sourcePad
+	if end == TARGET {
+		oldPad = bdl.targetPad
+	}
+	if oldPad == pad {
+		return
+	}
+	if oldPad != nil {
+		bdl.diagram.removeLinkDependency(oldPad.GetPadOwner(), bdl, oldPad)
+	}
+	if pad != nil {
+		bdl.floating[end] = false
+	}
+	if end == TARGET {
+		bdl.targetPad = pad
+	} else {
+		bdl.sourcePad = pad
+	}
+	if pad != nil {
+		bdl.diagram.addLinkDependency(pad.GetPadOwner(), bdl, pad)
+	}
+	bdl.Refresh()
+}
+
 // SetTargetPad sets the target pad (belonging to another DiagramElement) and adds the link dependency to the diagram
 func (bdl *BaseDiagramLink) SetTargetPad(pad ConnectionPad) {
+	bdl.floating[TARGET] = false
 	oldPad := bdl.targetPad
 	if oldPad != pad {
 		if oldPad != nil {
@@ -513,7 +676,7 @@ func (dlr *diagramLinkRenderer) Objects() []fyne.CanvasObject {
 
 func (dlr *diagramLinkRenderer) Refresh() {
 	// The pads to which the link is connected can be nil during a connection transaction, in which case we leave the end points
-	// at their present location. Note that the initial computations are done in diagram coordinates,
+	// at their present location. Floating ends have no pad and are placed at their fixed positions. Note that the initial computations are done in diagram coordinates,
 	// then link coordinates
 	var sourceDiagramCoordinateReferencePoint fyne.Position
 	var targetDiagramCoordinateReferencePoint fyne.Position
@@ -521,6 +684,12 @@ func (dlr *diagramLinkRenderer) Refresh() {
 	var targetDiagramCoordinatePosition fyne.Position
 	currentSourceDiagramCoordinatePosition := dlr.link.getSourcePosition().Add(dlr.link.Position())
 	currentTargetDiagramCoordinatePosition := dlr.link.getTargetPosition().Add(dlr.link.Position())
+	if position, ok := dlr.link.GetFloatingEndpoint(SOURCE); ok {
+		currentSourceDiagramCoordinatePosition = position
+	}
+	if position, ok := dlr.link.GetFloatingEndpoint(TARGET); ok {
+		currentTargetDiagramCoordinatePosition = position
+	}
 	if dlr.link.sourcePad != nil {
 		sourceDiagramCoordinateReferencePoint = dlr.link.sourcePad.GetCenterInDiagramCoordinates()
 	} else {
@@ -543,6 +712,19 @@ func (dlr *diagramLinkRenderer) Refresh() {
 	} else {
 		targetDiagramCoordinatePosition = currentTargetDiagramCoordinatePosition
 	}
+	if dlr.link.sourcePad != nil && dlr.link.targetPad != nil {
+		if offset := dlr.parallelOffset(sourceDiagramCoordinateReferencePoint, targetDiagramCoordinateReferencePoint); !offset.IsZero() {
+			sourceDiagramCoordinatePosition = offsetConnectionPoint(dlr.link.sourcePad, targetDiagramCoordinateReferencePoint, offset)
+			targetDiagramCoordinatePosition = offsetConnectionPoint(dlr.link.targetPad, sourceDiagramCoordinateReferencePoint, offset)
+		}
+	}
+	if dlr.link.endpointInset > 0 {
+		sourceDiagramCoordinatePosition, targetDiagramCoordinatePosition = dlr.insetEndpoints(sourceDiagramCoordinatePosition, targetDiagramCoordinatePosition)
+	}
+	if dlr.link.minVisibleLength > 0 {
+		sourceDiagramCoordinatePosition, targetDiagramCoordinatePosition = dlr.lengthenEndpoints(sourceDiagramCoordinatePosition,
+			targetDiagramCoordinatePosition, sourceDiagramCoordinateReferencePoint, targetDiagramCoordinateReferencePoint)
+	}
 	// The Position of the link is the upper left hand corner of a bounding box surrounding the source and target positions
 	linkPosition := fyne.NewPos(float32(math.Min(float64(sourceDiagramCoordinatePosition.X), float64(targetDiagramCoordinatePosition.X))),
 		float32(math.Min(float64(sourceDiagramCoordinatePosition.Y), float64(targetDiagramCoordinatePosition.Y))))
@@ -554,11 +736,15 @@ func (dlr *diagramLinkRenderer) Refresh() {
 	// TODO adjust the position of the other points based on the change in link position
 	// Now resize the link - note that MinSize is derived from the point positions
 	dlr.link.Resize(dlr.MinSize())
+	dlr.link.diagram.elementIndex.update(&dlr.link.diagramElement, linkPosition, dlr.link.Size())
 
 	// Position segments only after all points have been positioned
+	dashOffset := float32(0)
 	for i := 0; i < len(dlr.link.linkPoints)-1; i++ {
 		linkSegment := dlr.link.linkSegments[i]
+		linkSegment.dashOffset = dashOffset
 		linkSegment.SetPoints(dlr.link.linkPoints[i].Position(), dlr.link.linkPoints[i+1].Position())
+		dashOffset += linkSegment.length()
 	}
 
 	// Have to change the sign of Y since the window inverts the Y axis
@@ -624,19 +810,19 @@ func (dlr *diagramLinkRenderer) Refresh() {
 		linkSegment.Refresh()
 	}
 	for _, decoration := range dlr.link.SourceDecorations {
-		decoration.SetStrokeColor(dlr.link.properties.ForegroundColor)
+		decoration.SetStrokeColor(dlr.link.renderForegroundColor())
 		decoration.SetStrokeWidth(dlr.link.properties.StrokeWidth)
 		decoration.SetFillColor(dlr.link.diagram.GetBackgroundColor())
 		decoration.Refresh()
 	}
 	for _, decoration := range dlr.link.MidpointDecorations {
-		decoration.SetStrokeColor(dlr.link.properties.ForegroundColor)
+		decoration.SetStrokeColor(dlr.link.renderForegroundColor())
 		decoration.SetStrokeWidth(dlr.link.properties.StrokeWidth)
 		decoration.SetFillColor(dlr.link.diagram.GetBackgroundColor())
 		decoration.Refresh()
 	}
 	for _, decoration := range dlr.link.TargetDecorations {
-		decoration.SetStrokeColor(dlr.link.properties.ForegroundColor)
+		decoration.SetStrokeColor(dlr.link.renderForegroundColor())
 		decoration.SetStrokeWidth(dlr.link.properties.StrokeWidth)
 		decoration.SetFillColor(dlr.link.diagram.GetBackgroundColor())
 		decoration.Refresh()
@@ -655,6 +841,47 @@ func (dlr *diagramLinkRenderer) Refresh() {
 	}
 
 	dlr.link.diagram.refreshDependentLinks(dlr.link)
+	dlr.link.diagram.refreshLinkJumps(dlr.link)
+	dlr.link.diagram.refreshParallelLinks(dlr.link)
+}
+
+// insetEndpoints moves each connected endpoint towards the other by the endpoint inset. If the link is too short
+// for both insets, the endpoints are left unchanged.
+func (dlr *diagramLinkRenderer) insetEndpoints(source, target fyne.Position) (fyne.Position, fyne.Position) {
+	direction := r2.MakeVec2(float64(target.X-source.X), float64(target.Y-source.Y))
+	inset := float64(dlr.link.endpointInset)
+	if direction.Length() <= 2*inset {
+		return source, target
+	}
+	offset := direction.ScaleToLength(inset)
+	if dlr.link.sourcePad != nil {
+		source = source.AddXY(float32(offset.X), float32(offset.Y))
+	}
+	if dlr.link.targetPad != nil {
+		target = target.AddXY(-float32(offset.X), -float32(offset.Y))
+	}
+	return source, target
+}
+
+// lengthenEndpoints moves the endpoints apart to the minimum visible length if they are closer than that. They are
+// placed either side of their midpoint along the direction from the source reference point to the target reference
+// point, which still points from the source to the target when the connection points have crossed because the
+// nodes overlap.
+func (dlr *diagramLinkRenderer) lengthenEndpoints(source, target, sourceReference, targetReference fyne.Position) (fyne.Position, fyne.Position) {
+	length := float64(dlr.link.minVisibleLength)
+	if r2.MakeVec2(float64(target.X-source.X), float64(target.Y-source.Y)).Length() >= length {
+		return source, target
+	}
+	direction := r2.MakeVec2(float64(targetReference.X-sourceReference.X), float64(targetReference.Y-sourceReference.Y))
+	if direction.Length() == 0 {
+		direction = r2.MakeVec2(float64(target.X-source.X), float64(target.Y-source.Y))
+	}
+	if direction.Length() == 0 {
+		direction = r2.MakeVec2(1, 0)
+	}
+	offset := direction.ScaleToLength(length / 2)
+	mid := fyne.NewPos((source.X+target.X)/2, (source.Y+target.Y)/2)
+	return mid.SubtractXY(float32(offset.X), float32(offset.Y)), mid.AddXY(float32(offset.X), float32(offset.Y))
 }
 
 // ConnectionTransaction holds transient data during the creation of a link. It is public for testing purposes only
@@ -664,6 +891,10 @@ type ConnectionTransaction struct {
 	InitialPad      ConnectionPad
 	InitialPosition fyne.Position
 	PendingPad      ConnectionPad
+	// sourceChanged is true if the transaction was begun with BeginConnection, which connected the source of the
+	// link to a pad, and previousSourcePad is the pad it was connected to before
+	sourceChanged     bool
+	previousSourcePad ConnectionPad
 }
 
 // NewConnectionTransaction returns an instance of ConnectionTransaction
@@ -676,3 +907,18 @@ func NewConnectionTransaction(linkPoint *LinkPoint, link DiagramLink, initialPad
 	}
 	return ct
 }
+
+// isAnimating returns true while the link is fading in or out
+func (bdl *BaseDiagramLink) isAnimating() bool {
+	bdl.fadeLock.Lock()
+	defer bdl.fadeLock.Unlock()
+	return bdl.animating
+}
+
+// setFade records the opacity of the link and whether it is still fading
+func (bdl *BaseDiagramLink) setFade(opacity float32, animating bool) {
+	bdl.fadeLock.Lock()
+	defer bdl.fadeLock.Unlock()
+	bdl.opacity = opacity
+	bdl.animating = animating
+}
@@ -0,0 +1,139 @@
+package diagramwidget
+
+import (
+	"math"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/x/fyne/widget/diagramwidget/geometry/r2"
+)
+
+// LinkCrossingStyle determines how links are drawn where they cross other links
+type LinkCrossingStyle int
+
+// Specify the enumerated values for LinkCrossingStyle
+const (
+	// CrossingNone draws crossing links straight through each other
+	CrossingNone LinkCrossingStyle = iota
+	// CrossingJump draws a small arc in the upper link where it crosses a link below it, making it clear
+	// that the two links are not connected
+	CrossingJump
+)
+
+const (
+	// linkJumpRadius is the radius of the arc drawn where one link jumps over another
+	linkJumpRadius float32 = 5
+	// linkJumpSteps is the number of straight lines used to draw each arc
+	linkJumpSteps = 6
+)
+
+// SetLinkCrossingStyle sets how links are drawn where they cross. With CrossingJump, the link that is higher
+// in the display order hops over the one below it at each crossing.
+func (dw *DiagramWidget) SetLinkCrossingStyle(style LinkCrossingStyle) {
+	dw.linkCrossingStyle = style
+	dw.drawingArea.Refresh()
+}
+
+// refreshLinkJumps refreshes the segments of the links above the indicated link so that their jumps follow it
+func (dw *DiagramWidget) refreshLinkJumps(link *BaseDiagramLink) {
+	if dw.linkCrossingStyle != CrossingJump {
+		return
+	}
+	above := false
+	for _, other := range dw.GetDiagramLinks() {
+		otherLink := other.getBaseDiagramLink()
+		if otherLink == link {
+			above = true
+		} else if above {
+			for _, segment := range otherLink.linkSegments {
+				segment.Refresh()
+			}
+		}
+	}
+}
+
+// jumpDistances returns the distances from p1 at which the segment crosses the visible links below its own link,
+// in increasing order. Crossings close to the end of either segment, and crossings that would overlap the
+// jump before them, are left out.
+func (ls *LinkSegment) jumpDistances() []float32 {
+	diagram := ls.link.diagram
+	length := ls.length()
+	if diagram.linkCrossingStyle != CrossingJump || length <= 2*linkJumpRadius {
+		return nil
+	}
+	segment := ls.diagramSegment()
+	distances := []float32{}
+	for _, other := range diagram.GetDiagramLinks() {
+		otherLink := other.getBaseDiagramLink()
+		if otherLink == ls.link {
+			break
+		}
+		if !otherLink.Visible() {
+			continue
+		}
+		for _, otherSegment := range otherLink.linkSegments {
+			if otherSegment.length() <= 2*linkJumpRadius {
+				continue
+			}
+			crossed := otherSegment.diagramSegment()
+			direction, crossedDirection := segment.Direction(), crossed.Direction()
+			if math.Abs(direction.X*crossedDirection.Y-direction.Y*crossedDirection.X) < 1e-6 {
+				// parallel segments never cross at a single point
+				continue
+			}
+			point, ok := segment.IntersectSegment(crossed)
+			if !ok {
+				continue
+			}
+			distance := float32(point.Add(segment.P1.Scale(-1)).Length())
+			crossedDistance := float32(point.Add(crossed.P1.Scale(-1)).Length())
+			if distance < linkJumpRadius || distance > length-linkJumpRadius ||
+				crossedDistance < linkJumpRadius || crossedDistance > otherSegment.length()-linkJumpRadius {
+				continue
+			}
+			distances = append(distances, distance)
+		}
+	}
+
+	sort.Slice(distances, func(i, j int) bool {
+		return distances[i] < distances[j]
+	})
+	jumps := []float32{}
+	for _, distance := range distances {
+		if len(jumps) == 0 || distance-jumps[len(jumps)-1] >= 2*linkJumpRadius {
+			jumps = append(jumps, distance)
+		}
+	}
+	return jumps
+}
+
+// diagramSegment returns the segment in diagram coordinates
+func (ls *LinkSegment) diagramSegment() r2.LineSegment {
+	p1 := ls.p1.Add(ls.link.Position())
+	p2 := ls.p2.Add(ls.link.Position())
+	return r2.MakeLineSegment(r2.MakeVec2(float64(p1.X), float64(p1.Y)), r2.MakeVec2(float64(p2.X), float64(p2.Y)))
+}
+
+// path returns the points through which the segment is drawn from start to end, including an arc at each jump.
+// The arcs bulge upwards or, for vertical segments, to the left.
+func (lsr *linkSegmentRenderer) path(start, end fyne.Position) []fyne.Position {
+	path := []fyne.Position{start}
+	jumps := lsr.ls.jumpDistances()
+	if len(jumps) > 0 {
+		length := lsr.ls.length()
+		dx, dy := (end.X-start.X)/length, (end.Y-start.Y)/length
+		nx, ny := dy, -dx
+		if ny > 0 || (ny == 0 && nx > 0) {
+			nx, ny = -nx, -ny
+		}
+		for _, distance := range jumps {
+			for step := 0; step <= linkJumpSteps; step++ {
+				angle := math.Pi * float64(step) / linkJumpSteps
+				along := distance - linkJumpRadius*float32(math.Cos(angle))
+				across := linkJumpRadius * float32(math.Sin(angle))
+				path = append(path, start.AddXY(dx*along+nx*across, dy*along+ny*across))
+			}
+		}
+	}
+	return append(path, end)
+}
@@ -0,0 +1,44 @@
+package diagramwidget
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+)
+
+// defaultLinkHitTolerance is how far beyond the stroke of a link a tap still selects the link
+const defaultLinkHitTolerance float32 = 3
+
+// SetLinkHitTolerance sets how far a tap may be from the stroke of a link, measured perpendicular to the line, and
+// still select the link. This makes thin links easier to select. When several links are within the tolerance,
+// the nearest is selected. Defaults to 3.
+func (dw *DiagramWidget) SetLinkHitTolerance(tolerance float32) {
+	dw.linkHitTolerance = tolerance
+}
+
+// linkAt returns the link nearest to the position, in diagram coordinates, if it is within the hit tolerance.
+// Of two equally near links the topmost is returned.
+func (dw *DiagramWidget) linkAt(position fyne.Position) DiagramLink {
+	var nearest DiagramLink
+	nearestDistance := float64(dw.linkHitTolerance)
+	// the bounds of the links in the index are enlarged by the size of a point pad, which covers the strokes
+	candidates := dw.elementIndex.near(position, dw.linkHitTolerance)
+	point := geom.Coord{float64(position.X), float64(position.Y)}
+	for _, link := range dw.GetDiagramLinks() {
+		bdl := link.getBaseDiagramLink()
+		if !candidates[baseElement(link)] || !link.Visible() || bdl.isAnimating() {
+			continue
+		}
+		origin := link.Position()
+		for _, segment := range bdl.linkSegments {
+			p1 := geom.Coord{float64(origin.X + segment.p1.X), float64(origin.Y + segment.p1.Y)}
+			p2 := geom.Coord{float64(origin.X + segment.p2.X), float64(origin.Y + segment.p2.Y)}
+			distance := xy.DistanceFromPointToLine(point, p1, p2) - float64(bdl.properties.StrokeWidth/2)
+			if distance <= nearestDistance {
+				nearest = link
+				nearestDistance = distance
+			}
+		}
+	}
+	return nearest
+}
@@ -7,8 +7,6 @@ import (
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
-	"github.com/twpayne/go-geom"
-	"github.com/twpayne/go-geom/xy"
 )
 
 // LinkSegment is a widget representing a single line segment belonging to a link
@@ -19,6 +17,9 @@ type LinkSegment struct {
 	p1                fyne.Position
 	p2                fyne.Position
 	mouseDownPosition fyne.Position
+	// dashOffset is the distance along the link at which this segment starts, so that dash patterns
+	// continue from one segment to the next
+	dashOffset float32
 }
 
 // NewLinkSegment returns a LinkSegment belonging to the indicated Link
@@ -46,6 +47,9 @@ func (ls *LinkSegment) CreateRenderer() fyne.WidgetRenderer {
 // MouseDown in preparation for a MouseUp at the same location, which will trigger Tapped() behavior. Otherwise, if
 // it is the seconday button and a callback is present, it will invoke the callback
 func (ls *LinkSegment) MouseDown(event *desktop.MouseEvent) {
+	if ls.link.isAnimating() {
+		return
+	}
 	if event.Button == desktop.MouseButtonPrimary {
 		ls.mouseDownPosition = event.Position
 	} else if event.Button == desktop.MouseButtonSecondary && ls.link.diagram.LinkSegmentMouseDownSecondaryCallback != nil {
@@ -56,18 +60,24 @@ func (ls *LinkSegment) MouseDown(event *desktop.MouseEvent) {
 // MouseUp behavior depends on the mouse event. If it is the primary button and it is at the same location as the MouseDown,
 // the Tapped() behavior is invoked. Otherwise, if there is a callback present, the callback is invoked.
 func (ls *LinkSegment) MouseUp(event *desktop.MouseEvent) {
+	if ls.link.isAnimating() {
+		return
+	}
 	if event.Button == desktop.MouseButtonPrimary && ls.mouseDownPosition == event.Position {
-		clickPoint := geom.Coord{float64(event.Position.X), float64(event.Position.Y)}
-		p1 := geom.Coord{float64(ls.p1.X), float64(ls.p1.Y)}
-		p2 := geom.Coord{float64(ls.p2.X), float64(ls.p2.Y)}
-		if xy.DistanceFromPointToLine(clickPoint, p1, p2) <= float64(ls.link.properties.StrokeWidth/2)+3 {
-			ls.link.diagram.DiagramElementTapped(ls.link)
+		// the tap selects the nearest link within the hit tolerance, which may not be this one where links cross
+		position := ls.link.Position().Add(ls.Position()).Add(event.Position)
+		if link := ls.link.diagram.linkAt(position); link != nil {
+			ls.link.diagram.DiagramElementTapped(link)
 		}
 	} else if ls.link.diagram.LinkSegmentMouseUpCallback != nil {
 		ls.link.diagram.LinkSegmentMouseUpCallback(ls.link.typedLink, event)
 	}
 }
 
+func (ls *LinkSegment) length() float32 {
+	return float32(math.Hypot(float64(ls.p2.X-ls.p1.X), float64(ls.p2.Y-ls.p1.Y)))
+}
+
 // SetPoints sets the endpoints of the LinkSegment
 func (ls *LinkSegment) SetPoints(p1 fyne.Position, p2 fyne.Position) {
 	ls.p1 = p1
@@ -77,8 +87,9 @@ func (ls *LinkSegment) SetPoints(p1 fyne.Position, p2 fyne.Position) {
 
 // linkSegmentRenderer
 type linkSegmentRenderer struct {
-	ls   *LinkSegment
-	line *canvas.Line
+	ls     *LinkSegment
+	line   *canvas.Line
+	dashes []*canvas.Line
 }
 
 func (lsr *linkSegmentRenderer) Destroy() {
@@ -96,6 +107,9 @@ func (lsr *linkSegmentRenderer) Objects() []fyne.CanvasObject {
 	obj := []fyne.CanvasObject{
 		lsr.line,
 	}
+	for _, dash := range lsr.dashes {
+		obj = append(obj, dash)
+	}
 	return obj
 }
 
@@ -107,7 +121,84 @@ func (lsr *linkSegmentRenderer) Refresh() {
 	lsr.ls.Resize(lsr.MinSize())
 	lsr.line.Position1 = lsr.ls.p1.AddXY(-widgetPosition.X, -widgetPosition.Y)
 	lsr.line.Position2 = lsr.ls.p2.AddXY(-widgetPosition.X, -widgetPosition.Y)
-	lsr.line.StrokeColor = lsr.ls.link.properties.ForegroundColor
+	lsr.line.StrokeColor = lsr.ls.link.renderForegroundColor()
 	lsr.line.StrokeWidth = lsr.ls.link.properties.StrokeWidth
+	path := lsr.path(lsr.line.Position1, lsr.line.Position2)
+	if len(lsr.ls.link.dashPattern) == 0 && len(path) == 2 {
+		lsr.dashes = nil
+		lsr.line.Show()
+	} else {
+		lsr.line.Hide()
+		lsr.refreshDashes(path)
+	}
 	lsr.line.Refresh()
 }
+
+// refreshDashes draws the path as a series of lines. If the link has a dash pattern, only the "on" parts
+// of the pattern are drawn.
+func (lsr *linkSegmentRenderer) refreshDashes(path []fyne.Position) {
+	count := 0
+	addLine := func(start, end fyne.Position) {
+		if count == len(lsr.dashes) {
+			lsr.dashes = append(lsr.dashes, canvas.NewLine(lsr.line.StrokeColor))
+		}
+		dash := lsr.dashes[count]
+		dash.Position1 = start
+		dash.Position2 = end
+		dash.StrokeColor = lsr.line.StrokeColor
+		dash.StrokeWidth = lsr.line.StrokeWidth
+		dash.Refresh()
+		count++
+	}
+
+	pattern := lsr.ls.link.dashPattern
+	if len(pattern) == 0 {
+		for i := 0; i < len(path)-1; i++ {
+			addLine(path[i], path[i+1])
+		}
+		lsr.dashes = lsr.dashes[:count]
+		return
+	}
+
+	patternLength := float32(0)
+	for _, length := range pattern {
+		patternLength += length
+	}
+	// find where in the pattern this segment starts
+	phase := float32(math.Mod(float64(lsr.ls.dashOffset), float64(patternLength)))
+	index := 0
+	for phase >= pattern[index] {
+		phase -= pattern[index]
+		index = (index + 1) % len(pattern)
+	}
+
+	for i := 0; i < len(path)-1; i++ {
+		start, end := path[i], path[i+1]
+		pieceLength := float32(math.Hypot(float64(end.X-start.X), float64(end.Y-start.Y)))
+		if pieceLength == 0 {
+			continue
+		}
+		direction := fyne.NewPos((end.X-start.X)/pieceLength, (end.Y-start.Y)/pieceLength)
+		pointAt := func(distance float32) fyne.Position {
+			return start.AddXY(direction.X*distance, direction.Y*distance)
+		}
+		for distance := float32(0); ; {
+			remaining := pattern[index] - phase
+			if distance+remaining >= pieceLength {
+				// the pattern entry continues into the next piece
+				if index%2 == 0 && pieceLength > distance {
+					addLine(pointAt(distance), pointAt(pieceLength))
+				}
+				phase += pieceLength - distance
+				break
+			}
+			if index%2 == 0 && remaining > 0 {
+				addLine(pointAt(distance), pointAt(distance+remaining))
+			}
+			distance += remaining
+			phase = 0
+			index = (index + 1) % len(pattern)
+		}
+	}
+	lsr.dashes = lsr.dashes[:count]
+}
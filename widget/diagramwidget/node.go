@@ -2,6 +2,7 @@ package diagramwidget
 
 import (
 	"image/color"
+	"math"
 
 	"fyne.io/x/fyne/widget/diagramwidget/geometry/r2"
 
@@ -22,6 +23,7 @@ type DiagramNode interface {
 // Validate that BaseDiagramNode implements DiagramElement and Tappable
 var _ DiagramElement = (*BaseDiagramNode)(nil)
 var _ fyne.Tappable = (*BaseDiagramNode)(nil)
+var _ desktop.Hoverable = (*BaseDiagramNode)(nil)
 
 var _ fyne.Widget = (*BaseDiagramNode)(nil)
 var _ fyne.Widget = (DiagramNode)(nil)
@@ -45,10 +47,18 @@ type BaseDiagramNode struct {
 	innerObject fyne.CanvasObject
 	// MovedCallback, if present, is invoked when the node is moved
 	MovedCallback func()
+	// hovered is true while the mouse is over the node, which is within its box if it is rotated
+	hovered bool
+	// dragging is true during a drag, and ignoreDrag if the drag started outside of the rotated box
+	dragging, ignoreDrag bool
+	// resizing is true while a handle is dragged, and resizeAspect is the ratio of width to height when it started
+	resizing     bool
+	resizeAspect float32
 }
 
 // NewDiagramNode creates a DiagramNode widget and adds it to the DiagramWidget. The user-supplied
-// nodeID string must be unique across all of the DiagramElements in the diagram. It can be used
+// nodeID string must be unique across all of the DiagramElements in the diagram; a node with an ID that is
+// already in use is logged as an error and not added. The ID can be used
 // to retrieve the DiagramNode from the DiagramWidget. It is permissible for the canvas object to
 // be nil when this function is called and then add the canvas object later.
 func NewDiagramNode(diagram *DiagramWidget, obj fyne.CanvasObject, nodeID string) DiagramNode {
@@ -71,15 +81,18 @@ func InitializeBaseDiagramNode(diagramNode DiagramNode, diagram *DiagramWidget,
 		newHandle.Hide()
 	}
 	bdn.ExtendBaseWidget(diagramNode)
-	bdn.diagram.addNode(diagramNode)
+	if err := bdn.diagram.addNode(diagramNode); err != nil {
+		fyne.LogError("adding diagram node "+nodeID, err)
+	}
 	diagramNode.Refresh()
 }
 
 // CreateRenderer creates the renderer for the diagram node
 func (bdn *BaseDiagramNode) CreateRenderer() fyne.WidgetRenderer {
 	dnr := diagramNodeRenderer{
-		node: bdn,
-		box:  canvas.NewRectangle(bdn.diagram.GetForegroundColor()),
+		node:       bdn,
+		box:        canvas.NewRectangle(bdn.diagram.GetForegroundColor()),
+		rotatedBox: newRotatedShape(bdn),
 	}
 
 	dnr.box.StrokeWidth = bdn.properties.StrokeWidth
@@ -95,17 +108,38 @@ func (bdn *BaseDiagramNode) Center() fyne.Position {
 	return fyne.Position{X: float32(bdn.R2Center().X), Y: float32(bdn.R2Center().Y)}
 }
 
-// Cursor returns the desktop default cursor
+// Cursor returns the move cursor to show that the node can be dragged, unless the diagram cannot be edited
 func (bdn *BaseDiagramNode) Cursor() desktop.Cursor {
-	return desktop.DefaultCursor
+	return cursorFor(bdn.diagram)
+}
+
+// containsPoint returns true if the position relative to the node lies within its box, taking its rotation
+// into account. The events of a node that is not rotated are always within its box.
+func (bdn *BaseDiagramNode) containsPoint(position fyne.Position) bool {
+	return bdn.rotation == 0 || inRotatedRectangle(position, bdn.Size(), bdn.rotation)
 }
 
-// DragEnd is presently a no-op
+// DragEnd hides any alignment guides shown while dragging
 func (bdn *BaseDiagramNode) DragEnd() {
+	bdn.dragging = false
+	if bdn.ignoreDrag {
+		bdn.ignoreDrag = false
+		return
+	}
+	bdn.diagram.DiagramNodeDragEnded(bdn)
 }
 
-// Dragged passes the DragEvent to the diagram for processing
+// Dragged passes the DragEvent to the diagram for processing. A drag of a rotated node that starts outside
+// of its box is ignored.
 func (bdn *BaseDiagramNode) Dragged(event *fyne.DragEvent) {
+	if !bdn.dragging {
+		bdn.dragging = true
+		start := event.Position.SubtractXY(event.Dragged.DX, event.Dragged.DY)
+		bdn.ignoreDrag = !bdn.containsPoint(start)
+	}
+	if !bdn.diagram.IsEditable() || bdn.ignoreDrag {
+		return
+	}
 	bdn.diagram.DiagramNodeDragged(bdn, event)
 }
 
@@ -116,6 +150,14 @@ func (bdn *BaseDiagramNode) effectiveInnerSize() fyne.Size {
 	return bdn.InnerSize.Max(bdn.innerObject.MinSize())
 }
 
+// minInnerSize returns the smallest size to which the handles can shrink the inner object
+func (bdn *BaseDiagramNode) minInnerSize() fyne.Size {
+	if bdn.innerObject == nil {
+		return fyne.NewSize(0, 0)
+	}
+	return bdn.innerObject.MinSize()
+}
+
 func (bdn *BaseDiagramNode) findKeyForHandle(handle *Handle) string {
 	for k, v := range bdn.handles {
 		if v == handle {
@@ -140,31 +182,43 @@ func (bdn *BaseDiagramNode) GetEdgePad() ConnectionPad {
 }
 
 func (bdn *BaseDiagramNode) handleDragged(handle *Handle, event *fyne.DragEvent) {
-	// determine which handle it is
-	currentInnerSize := bdn.effectiveInnerSize()
+	if bdn.fixedSize {
+		return
+	}
+	if !bdn.resizing {
+		bdn.resizing = true
+		if start := bdn.effectiveInnerSize(); start.Height > 0 {
+			bdn.resizeAspect = start.Width / start.Height
+		}
+	}
+	if bdn.rotation != 0 {
+		// the handles resize the box along its own rotated axes
+		delta := rotateAbout(fyne.NewPos(event.Dragged.DX, event.Dragged.DY), fyne.NewPos(0, 0), -bdn.rotation)
+		event = &fyne.DragEvent{PointEvent: event.PointEvent, Dragged: fyne.NewDelta(delta.X, delta.Y)}
+	}
+	// determine which handle it is, and which sides of the box it moves
 	handleKey := bdn.findKeyForHandle(handle)
-	positionChange := fyne.Position{X: 0, Y: 0}
+	var movesLeft, movesTop bool
 	sizeChange := fyne.Size{Height: 0, Width: 0}
 	switch handleKey {
 	case "upperLeft":
-		positionChange.X = event.Dragged.DX
+		movesLeft, movesTop = true, true
 		sizeChange.Width = -event.Dragged.DX
-		positionChange.Y = event.Dragged.DY
 		sizeChange.Height = -event.Dragged.DY
 	case "upperMiddle":
-		positionChange.Y = event.Dragged.DY
+		movesTop = true
 		sizeChange.Height = -event.Dragged.DY
 	case "upperRight":
-		positionChange.Y = event.Dragged.DY
+		movesTop = true
 		sizeChange.Height = -event.Dragged.DY
 		sizeChange.Width = event.Dragged.DX
 	case "leftMiddle":
-		positionChange.X = event.Dragged.DX
+		movesLeft = true
 		sizeChange.Width = -event.Dragged.DX
 	case "rightMiddle":
 		sizeChange.Width = event.Dragged.DX
 	case "lowerLeft":
-		positionChange.X = event.Dragged.DX
+		movesLeft = true
 		sizeChange.Width = -event.Dragged.DX
 		sizeChange.Height = event.Dragged.DY
 	case "lowerMiddle":
@@ -173,27 +227,33 @@ func (bdn *BaseDiagramNode) handleDragged(handle *Handle, event *fyne.DragEvent)
 		sizeChange.Height = event.Dragged.DY
 		sizeChange.Width = event.Dragged.DX
 	}
+	currentInnerSize := bdn.effectiveInnerSize()
 	trialInnerSize := bdn.InnerSize.Add(sizeChange)
-	bdn.InnerSize = bdn.innerObject.MinSize().Max(trialInnerSize)
-	if trialInnerSize.Height < bdn.InnerSize.Height {
-		sizeChange.Height = bdn.InnerSize.Height - currentInnerSize.Height
-		if positionChange.Y != 0 {
-			positionChange.Y = -sizeChange.Height
-		}
+	if sizeChange.Width != 0 && sizeChange.Height != 0 && shiftPressed() {
+		trialInnerSize = keepAspectRatio(bdn.InnerSize, trialInnerSize, bdn.resizeAspect)
 	}
-	if trialInnerSize.Width < bdn.InnerSize.Width {
-		sizeChange.Width = bdn.InnerSize.Width - currentInnerSize.Width
-		if positionChange.X != 0 {
-			positionChange.X = -sizeChange.Width
-		}
+	bdn.InnerSize = bdn.minInnerSize().Max(trialInnerSize)
+	sizeChange = bdn.effectiveInnerSize().Subtract(currentInnerSize)
+	if sizeChange.IsZero() {
+		return
+	}
+	positionChange := fyne.Position{X: 0, Y: 0}
+	if movesLeft {
+		positionChange.X = -sizeChange.Width
+	}
+	if movesTop {
+		positionChange.Y = -sizeChange.Height
 	}
 	bdn.Resize(bdn.Size().Add(sizeChange))
 	bdn.Move(bdn.Position().Add(positionChange))
 	bdn.Refresh()
+	if bdn.diagram.OnResizedCallback != nil {
+		bdn.diagram.OnResizedCallback(bdn.diagram.GetDiagramElement(bdn.id))
+	}
 }
 
 func (bdn *BaseDiagramNode) handleDragEnd(handle *Handle) {
-
+	bdn.resizing = false
 }
 
 func (bdn *BaseDiagramNode) innerPos() fyne.Position {
@@ -213,6 +273,37 @@ func (bdn *BaseDiagramNode) IsNode() bool {
 	return true
 }
 
+// MouseIn highlights the node and its connections if the diagram is configured to do so.
+// The mouse is only over a rotated node while it is within the node's box.
+func (bdn *BaseDiagramNode) MouseIn(event *desktop.MouseEvent) {
+	if !bdn.containsPoint(event.Position) {
+		return
+	}
+	bdn.hovered = true
+	bdn.diagram.highlightConnected(bdn)
+	bdn.diagram.scheduleTooltip(bdn, bdn.Position().Add(event.Position))
+}
+
+// MouseMoved restarts the tooltip delay at the new mouse position, or responds as the mouse enters or leaves
+// the box of a rotated node
+func (bdn *BaseDiagramNode) MouseMoved(event *desktop.MouseEvent) {
+	inside := bdn.containsPoint(event.Position)
+	if inside && !bdn.hovered {
+		bdn.MouseIn(event)
+	} else if !inside && bdn.hovered {
+		bdn.MouseOut()
+	} else if inside {
+		bdn.diagram.scheduleTooltip(bdn, bdn.Position().Add(event.Position))
+	}
+}
+
+// MouseOut restores the normal rendering of the diagram if the node was highlighted and hides the tooltip
+func (bdn *BaseDiagramNode) MouseOut() {
+	bdn.hovered = false
+	bdn.diagram.clearHighlight()
+	bdn.diagram.hideTooltip()
+}
+
 // Move moves the node and invokes the callback if present.
 func (bdn *BaseDiagramNode) Move(position fyne.Position) {
 	bdn.BaseWidget.Move(position)
@@ -243,6 +334,24 @@ func (bdn *BaseDiagramNode) R2Position() r2.Vec2 {
 	return r2.V2(float64(bdn.Position().X), float64(bdn.Position().Y))
 }
 
+// SetResizable sets whether the node shows its handles when selected so that it can be resized, hiding them if not
+func (bdn *BaseDiagramNode) SetResizable(resizable bool) {
+	bdn.diagramElement.SetResizable(resizable)
+	if !resizable {
+		bdn.HideHandles()
+	} else if bdn.diagram.IsSelected(bdn) {
+		bdn.ShowHandles()
+	}
+}
+
+// ShowHandles shows the handles that resize the node, unless it is not resizable
+func (bdn *BaseDiagramNode) ShowHandles() {
+	if bdn.fixedSize {
+		return
+	}
+	bdn.diagramElement.ShowHandles()
+}
+
 // SetInnerObject makes the skupplied canvas object the center of the node
 func (bdn *BaseDiagramNode) SetInnerObject(obj fyne.CanvasObject) {
 	bdn.innerObject = obj
@@ -250,15 +359,19 @@ func (bdn *BaseDiagramNode) SetInnerObject(obj fyne.CanvasObject) {
 	bdn.diagram.refreshDependentLinks(bdn)
 }
 
-// Tapped passes the tapped event on to the Diagram
+// Tapped passes the tapped event on to the Diagram, unless a rotated node is tapped outside of its box
 func (bdn *BaseDiagramNode) Tapped(event *fyne.PointEvent) {
+	if !bdn.containsPoint(event.Position) {
+		return
+	}
 	bdn.diagram.DiagramElementTapped(bdn)
 }
 
 // diagramNodeRenderer
 type diagramNodeRenderer struct {
-	node *BaseDiagramNode
-	box  *canvas.Rectangle
+	node       *BaseDiagramNode
+	box        *canvas.Rectangle
+	rotatedBox *rotatedShape
 }
 
 func (dnr *diagramNodeRenderer) ApplyTheme(size fyne.Size) {
@@ -286,6 +399,7 @@ func (dnr *diagramNodeRenderer) Layout(size fyne.Size) {
 func (dnr *diagramNodeRenderer) Objects() []fyne.CanvasObject {
 	obj := make([]fyne.CanvasObject, 0)
 	obj = append(obj, dnr.box)
+	obj = append(obj, dnr.rotatedBox)
 	obj = append(obj, dnr.node.innerObject)
 	for _, pad := range dnr.node.pads {
 		obj = append(obj, pad)
@@ -299,6 +413,9 @@ func (dnr *diagramNodeRenderer) Objects() []fyne.CanvasObject {
 func (dnr *diagramNodeRenderer) Refresh() {
 	nodeSize := dnr.MinSize()
 	dnr.node.Resize(nodeSize)
+	rotation := dnr.node.rotation
+	boundsOffset, boundsSize := rotatedBounds(nodeSize, rotation)
+	dnr.node.diagram.elementIndex.update(&dnr.node.diagramElement, dnr.node.Position().Add(boundsOffset), boundsSize)
 	dnr.node.pads["default"].Resize(nodeSize)
 	dnr.node.pads["default"].Move(fyne.NewPos(0, 0))
 	dnr.node.pads["default"].Refresh()
@@ -310,39 +427,73 @@ func (dnr *diagramNodeRenderer) Refresh() {
 
 	dnr.box.Resize(nodeSize)
 
+	// the box of a rotated node is drawn by the rotated shape, which extends by the stroke width to fit the outline
+	stroke := dnr.node.properties.StrokeWidth
+	dnr.box.Hidden = rotation != 0
+	dnr.rotatedBox.Hidden = rotation == 0
+	dnr.rotatedBox.Move(boundsOffset.SubtractXY(stroke, stroke))
+	dnr.rotatedBox.Resize(boundsSize.AddWidthHeight(2*stroke, 2*stroke))
+	dnr.rotatedBox.Refresh()
+
 	// calculate the handle positions
 	width := nodeSize.Width
 	height := nodeSize.Height
+	center := fyne.NewPos(width/2, height/2)
 	for key, handle := range dnr.node.handles {
+		var position fyne.Position
 		switch key {
 		case "upperLeft":
-			handle.Move(fyne.NewPos(0, 0))
+			position = fyne.NewPos(0, 0)
 		case "upperMiddle":
-			handle.Move(fyne.Position{X: width / 2, Y: 0})
+			position = fyne.Position{X: width / 2, Y: 0}
 		case "upperRight":
-			handle.Move(fyne.Position{X: width, Y: 0})
+			position = fyne.Position{X: width, Y: 0}
 		case "leftMiddle":
-			handle.Move(fyne.Position{X: 0, Y: height / 2})
+			position = fyne.Position{X: 0, Y: height / 2}
 		case "rightMiddle":
-			handle.Move(fyne.Position{X: width, Y: height / 2})
+			position = fyne.Position{X: width, Y: height / 2}
 		case "lowerLeft":
-			handle.Move(fyne.Position{X: 0, Y: height})
+			position = fyne.Position{X: 0, Y: height}
 		case "lowerMiddle":
-			handle.Move(fyne.Position{X: width / 2, Y: height})
+			position = fyne.Position{X: width / 2, Y: height}
 		case "lowerRight":
-			handle.Move(fyne.Position{X: width, Y: height})
+			position = fyne.Position{X: width, Y: height}
 		}
+		handle.Move(rotateAbout(position, center, rotation))
 		handle.Resize(fyne.NewSize(handle.handleSize, handle.handleSize))
 		handle.Refresh()
 	}
 
 	dnr.box.StrokeWidth = dnr.node.properties.StrokeWidth
 	dnr.box.FillColor = dnr.node.properties.BackgroundColor
-	dnr.box.StrokeColor = dnr.node.properties.ForegroundColor
+	dnr.box.StrokeColor = dnr.node.renderForegroundColor()
 	dnr.box.Refresh()
 
 	for _, pad := range dnr.node.pads {
+		if pointPad, ok := pad.(*PointPad); ok && pointPad.relative {
+			pointPad.place()
+		}
 		pad.Refresh()
 	}
 	dnr.node.diagram.refreshDependentLinks(dnr.node)
 }
+
+// keepAspectRatio returns the size to which a corner handle resizes the box, with the ratio of width to height kept.
+// The dimension that changed the most in proportion to its old size is kept and the other follows it.
+func keepAspectRatio(oldSize, size fyne.Size, aspect float32) fyne.Size {
+	if aspect <= 0 || oldSize.Width <= 0 || oldSize.Height <= 0 {
+		return size
+	}
+	widthChange := float32(math.Abs(float64(size.Width-oldSize.Width))) / oldSize.Width
+	heightChange := float32(math.Abs(float64(size.Height-oldSize.Height))) / oldSize.Height
+	if widthChange >= heightChange {
+		return fyne.NewSize(size.Width, size.Width/aspect)
+	}
+	return fyne.NewSize(size.Height*aspect, size.Height)
+}
+
+// shiftPressed returns true if the shift key is held down on a desktop driver
+func shiftPressed() bool {
+	drv, ok := fyne.CurrentApp().Driver().(desktop.Driver)
+	return ok && drv.CurrentKeyModifiers()&fyne.KeyModifierShift != 0
+}
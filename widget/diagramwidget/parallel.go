@@ -0,0 +1,103 @@
+package diagramwidget
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/x/fyne/widget/diagramwidget/geometry/r2"
+)
+
+// SetParallelLinkSpacing sets the distance between links that connect the same pair of pads. The links are
+// fanned out side by side, centered on the line between the pads, so that each can be seen. Decorations and
+// anchored texts move with their links. A spacing of 0 (the default) draws such links on top of each other.
+func (dw *DiagramWidget) SetParallelLinkSpacing(spacing float32) {
+	dw.parallelLinkSpacing = spacing
+	dw.drawingArea.Refresh()
+}
+
+// linksBetween returns the links connecting the two pads, in either direction, in display order
+func (dw *DiagramWidget) linksBetween(pad1, pad2 ConnectionPad) []*BaseDiagramLink {
+	links := []*BaseDiagramLink{}
+	if pad1 == nil || pad2 == nil {
+		return links
+	}
+	for _, link := range dw.GetDiagramLinks() {
+		bdl := link.getBaseDiagramLink()
+		if (bdl.sourcePad == pad1 && bdl.targetPad == pad2) || (bdl.sourcePad == pad2 && bdl.targetPad == pad1) {
+			links = append(links, bdl)
+		}
+	}
+	return links
+}
+
+// refreshParallelLinks refreshes the other links between the same pads as the link when the link is connected
+// to different pads, so that they close up the gap it left or make room for it
+func (dw *DiagramWidget) refreshParallelLinks(link *BaseDiagramLink) {
+	if link.parallelPads == [2]ConnectionPad{link.sourcePad, link.targetPad} {
+		return
+	}
+	previous := link.parallelPads
+	link.parallelPads = [2]ConnectionPad{link.sourcePad, link.targetPad}
+	if dw.parallelLinkSpacing == 0 {
+		return
+	}
+	for _, pads := range [][2]ConnectionPad{previous, link.parallelPads} {
+		for _, other := range dw.linksBetween(pads[0], pads[1]) {
+			if other != link {
+				other.Refresh()
+			}
+		}
+	}
+}
+
+// parallelOffset returns the displacement of the link from the line between the centers of its pads
+func (dlr *diagramLinkRenderer) parallelOffset(source, target fyne.Position) fyne.Position {
+	spacing := dlr.link.diagram.parallelLinkSpacing
+	if spacing == 0 {
+		return fyne.Position{}
+	}
+	links := dlr.link.diagram.linksBetween(dlr.link.sourcePad, dlr.link.targetPad)
+	if len(links) < 2 {
+		return fyne.Position{}
+	}
+	index := 0
+	for i, link := range links {
+		if link == dlr.link {
+			index = i
+		}
+	}
+
+	direction := r2.MakeVec2(float64(target.X-source.X), float64(target.Y-source.Y))
+	if direction.Length() == 0 {
+		return fyne.Position{}
+	}
+	// links running the other way from the first link are offset to the other side of their own direction,
+	// so that all of the links are fanned out on the same line
+	if links[0].sourcePad != dlr.link.sourcePad {
+		direction = direction.Scale(-1)
+	}
+	distance := (float64(index) - float64(len(links)-1)/2) * float64(spacing)
+	normal := r2.MakeVec2(-direction.Y, direction.X).ScaleToLength(math.Abs(distance))
+	if distance < 0 {
+		normal = normal.Scale(-1)
+	}
+	return fyne.NewPos(float32(normal.X), float32(normal.Y))
+}
+
+// offsetConnectionPoint returns the point at which a link displaced by the offset meets the pad. For a rectangle
+// pad this is where the displaced line crosses the rectangle, otherwise the connection point is displaced.
+func offsetConnectionPoint(pad ConnectionPad, otherReference fyne.Position, offset fyne.Position) fyne.Position {
+	if rectangle, ok := pad.(*RectanglePad); ok {
+		box := rectangle.makeBox()
+		start := pad.GetCenterInDiagramCoordinates().Add(offset)
+		end := otherReference.Add(offset)
+		r2Start := r2.MakeVec2(float64(start.X), float64(start.Y))
+		r2End := r2.MakeVec2(float64(end.X), float64(end.Y))
+		if box.Contains(r2Start) && !box.Contains(r2End) {
+			if point, ok := box.Intersect(r2.MakeLineFromEndpoints(r2Start, r2End)); ok {
+				return fyne.NewPos(float32(point.X), float32(point.Y))
+			}
+		}
+	}
+	return pad.getConnectionPointInDiagramCoordinates(otherReference).Add(offset)
+}
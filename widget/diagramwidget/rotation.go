@@ -0,0 +1,168 @@
+package diagramwidget
+
+import (
+	"image"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+	"github.com/srwiley/rasterx"
+	"golang.org/x/image/math/fixed"
+)
+
+// Validate that rotatedShape passes the events of a rotated node on
+var _ fyne.Tappable = (*rotatedShape)(nil)
+var _ fyne.Draggable = (*rotatedShape)(nil)
+var _ desktop.Hoverable = (*rotatedShape)(nil)
+
+// rotateAbout returns the point rotated clockwise on screen by the degrees about the center
+func rotateAbout(point, center fyne.Position, degrees float32) fyne.Position {
+	if degrees == 0 {
+		return point
+	}
+	sin, cos := math.Sincos(float64(degrees) * math.Pi / 180)
+	dx, dy := float64(point.X-center.X), float64(point.Y-center.Y)
+	return fyne.NewPos(center.X+float32(dx*cos-dy*sin), center.Y+float32(dx*sin+dy*cos))
+}
+
+// elementCenter returns the center of the element in diagram coordinates, which is the point it is rotated about
+func elementCenter(de DiagramElement) fyne.Position {
+	size := de.Size()
+	return de.Position().AddXY(size.Width/2, size.Height/2)
+}
+
+// rotatedCorners returns the corners of a rectangle of the size, with its top-left at the origin, after rotating
+// it about its center
+func rotatedCorners(size fyne.Size, degrees float32) [4]fyne.Position {
+	center := fyne.NewPos(size.Width/2, size.Height/2)
+	return [4]fyne.Position{
+		rotateAbout(fyne.NewPos(0, 0), center, degrees),
+		rotateAbout(fyne.NewPos(size.Width, 0), center, degrees),
+		rotateAbout(fyne.NewPos(size.Width, size.Height), center, degrees),
+		rotateAbout(fyne.NewPos(0, size.Height), center, degrees),
+	}
+}
+
+// rotatedBounds returns the position and size of the box bounding a rectangle of the size after rotating it about
+// its center. The position is relative to the top-left of the unrotated rectangle.
+func rotatedBounds(size fyne.Size, degrees float32) (fyne.Position, fyne.Size) {
+	if degrees == 0 {
+		return fyne.NewPos(0, 0), size
+	}
+	corners := rotatedCorners(size, degrees)
+	min, max := corners[0], corners[0]
+	for _, corner := range corners[1:] {
+		min = fyne.NewPos(float32(math.Min(float64(min.X), float64(corner.X))), float32(math.Min(float64(min.Y), float64(corner.Y))))
+		max = fyne.NewPos(float32(math.Max(float64(max.X), float64(corner.X))), float32(math.Max(float64(max.Y), float64(corner.Y))))
+	}
+	return min, fyne.NewSize(max.X-min.X, max.Y-min.Y)
+}
+
+// inRotatedRectangle returns true if the point, relative to the top-left of the unrotated rectangle, lies within
+// the rectangle of the size after rotating it about its center
+func inRotatedRectangle(point fyne.Position, size fyne.Size, degrees float32) bool {
+	unrotated := rotateAbout(point, fyne.NewPos(size.Width/2, size.Height/2), -degrees)
+	return unrotated.X >= 0 && unrotated.Y >= 0 && unrotated.X <= size.Width && unrotated.Y <= size.Height
+}
+
+// rotatedShape draws the box of a rotated node. It covers the bounds of the rotated box, which may reach beyond
+// those of the node, and passes the events within them on to the node, which ignores those outside of the box.
+type rotatedShape struct {
+	widget.BaseWidget
+	node   *BaseDiagramNode
+	raster *canvas.Raster
+}
+
+func newRotatedShape(node *BaseDiagramNode) *rotatedShape {
+	rs := &rotatedShape{node: node}
+	rs.raster = canvas.NewRaster(rs.draw)
+	rs.ExtendBaseWidget(rs)
+	return rs
+}
+
+func (rs *rotatedShape) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(rs.raster)
+}
+
+func (rs *rotatedShape) Cursor() desktop.Cursor {
+	return rs.node.Cursor()
+}
+
+func (rs *rotatedShape) DragEnd() {
+	rs.node.DragEnd()
+}
+
+func (rs *rotatedShape) Dragged(event *fyne.DragEvent) {
+	nodeEvent := *event
+	nodeEvent.Position = rs.toNode(event.Position)
+	rs.node.Dragged(&nodeEvent)
+}
+
+func (rs *rotatedShape) MouseIn(event *desktop.MouseEvent) {
+	nodeEvent := *event
+	nodeEvent.Position = rs.toNode(event.Position)
+	rs.node.MouseIn(&nodeEvent)
+}
+
+func (rs *rotatedShape) MouseMoved(event *desktop.MouseEvent) {
+	nodeEvent := *event
+	nodeEvent.Position = rs.toNode(event.Position)
+	rs.node.MouseMoved(&nodeEvent)
+}
+
+func (rs *rotatedShape) MouseOut() {
+	rs.node.MouseOut()
+}
+
+func (rs *rotatedShape) Tapped(event *fyne.PointEvent) {
+	nodeEvent := *event
+	nodeEvent.Position = rs.toNode(event.Position)
+	rs.node.Tapped(&nodeEvent)
+}
+
+// toNode converts a position relative to the shape to one relative to the node
+func (rs *rotatedShape) toNode(position fyne.Position) fyne.Position {
+	return position.Add(rs.Position())
+}
+
+// draw renders the rotated box of the node with the fill and stroke of its rectangle
+func (rs *rotatedShape) draw(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	size := rs.Size()
+	if size.Width <= 0 || size.Height <= 0 {
+		return img
+	}
+	scale := float64(w) / float64(size.Width)
+	points := []fixed.Point26_6{}
+	for _, corner := range rotatedCorners(rs.node.Size(), rs.node.rotation) {
+		p := corner.Subtract(rs.Position())
+		points = append(points, rasterx.ToFixedP(float64(p.X)*scale, float64(p.Y)*scale))
+	}
+
+	scanner := rasterx.NewScannerGV(w, h, img, img.Bounds())
+	properties := rs.node.properties
+	if properties.BackgroundColor != nil {
+		filler := rasterx.NewFiller(w, h, scanner)
+		filler.SetColor(properties.BackgroundColor)
+		filler.Start(points[0])
+		for _, point := range points[1:] {
+			filler.Line(point)
+		}
+		filler.Stop(true)
+		filler.Draw()
+	}
+	if stroke := rs.node.renderForegroundColor(); stroke != nil && properties.StrokeWidth > 0 {
+		dasher := rasterx.NewDasher(w, h, scanner)
+		dasher.SetColor(stroke)
+		dasher.SetStroke(fixed.Int26_6(float64(properties.StrokeWidth)*scale*64), 0, nil, nil, nil, 0, nil, 0)
+		dasher.Start(points[0])
+		for _, point := range points[1:] {
+			dasher.Line(point)
+		}
+		dasher.Stop(true)
+		dasher.Draw()
+	}
+	return img
+}
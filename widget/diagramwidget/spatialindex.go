@@ -0,0 +1,116 @@
+package diagramwidget
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+)
+
+// spatialIndexCellSize is the size of the square cells into which the spatial index divides the diagram
+const spatialIndexCellSize float32 = 100
+
+type gridCell struct {
+	x, y int
+}
+
+// gridRange is the rectangle of cells, inclusive, covered by the bounds of an element
+type gridRange struct {
+	min, max gridCell
+}
+
+// spatialIndex is a uniform grid recording which cells the bounds of each diagram element overlap, so that the
+// elements near a point can be found without checking every element in the diagram. The bounds are enlarged
+// by the size of a point pad so that pads that stick out from their element are found too.
+type spatialIndex struct {
+	cells    map[gridCell]map[*diagramElement]bool
+	elements map[*diagramElement]gridRange
+}
+
+func newSpatialIndex() *spatialIndex {
+	return &spatialIndex{
+		cells:    map[gridCell]map[*diagramElement]bool{},
+		elements: map[*diagramElement]gridRange{},
+	}
+}
+
+// insert adds the element to the index with the given bounds, in diagram coordinates
+func (si *spatialIndex) insert(de *diagramElement, position fyne.Position, size fyne.Size) {
+	cells := cellsCovering(position.SubtractXY(pointPadSize, pointPadSize), size.AddWidthHeight(2*pointPadSize, 2*pointPadSize))
+	si.elements[de] = cells
+	for x := cells.min.x; x <= cells.max.x; x++ {
+		for y := cells.min.y; y <= cells.max.y; y++ {
+			cell := gridCell{x, y}
+			if si.cells[cell] == nil {
+				si.cells[cell] = map[*diagramElement]bool{}
+			}
+			si.cells[cell][de] = true
+		}
+	}
+}
+
+// near returns the elements whose bounds may lie within the distance of the position
+func (si *spatialIndex) near(position fyne.Position, distance float32) map[*diagramElement]bool {
+	found := map[*diagramElement]bool{}
+	cells := cellsCovering(position.SubtractXY(distance, distance), fyne.NewSize(2*distance, 2*distance))
+	for x := cells.min.x; x <= cells.max.x; x++ {
+		for y := cells.min.y; y <= cells.max.y; y++ {
+			for de := range si.cells[gridCell{x, y}] {
+				found[de] = true
+			}
+		}
+	}
+	return found
+}
+
+// remove takes the element out of the index
+func (si *spatialIndex) remove(de *diagramElement) {
+	cells, ok := si.elements[de]
+	if !ok {
+		return
+	}
+	delete(si.elements, de)
+	for x := cells.min.x; x <= cells.max.x; x++ {
+		for y := cells.min.y; y <= cells.max.y; y++ {
+			cell := gridCell{x, y}
+			delete(si.cells[cell], de)
+			if len(si.cells[cell]) == 0 {
+				delete(si.cells, cell)
+			}
+		}
+	}
+}
+
+// update records new bounds for an element that is in the index. Elements that are not in the index,
+// such as those that have been removed from the diagram, are ignored.
+func (si *spatialIndex) update(de *diagramElement, position fyne.Position, size fyne.Size) {
+	if _, ok := si.elements[de]; !ok {
+		return
+	}
+	si.remove(de)
+	si.insert(de, position, size)
+}
+
+// cellsCovering returns the cells overlapped by the rectangle
+func cellsCovering(position fyne.Position, size fyne.Size) gridRange {
+	cell := func(x, y float32) gridCell {
+		return gridCell{
+			x: int(math.Floor(float64(x / spatialIndexCellSize))),
+			y: int(math.Floor(float64(y / spatialIndexCellSize))),
+		}
+	}
+	return gridRange{
+		min: cell(position.X, position.Y),
+		max: cell(position.X+size.Width, position.Y+size.Height),
+	}
+}
+
+// baseElement returns the diagramElement embedded in a node or link
+func baseElement(element DiagramElement) *diagramElement {
+	switch typed := element.(type) {
+	case DiagramNode:
+		return &typed.getBaseDiagramNode().diagramElement
+	case DiagramLink:
+		return &typed.getBaseDiagramLink().diagramElement
+	}
+	return nil
+}
@@ -12,6 +12,10 @@ import (
 func adjacent(dw *DiagramWidget, n1, n2 DiagramNode) bool {
 	// TODO: expensive, may be worth caching?
 	for _, e := range dw.GetDiagramLinks() {
+		if e.GetSourcePad() == nil || e.GetTargetPad() == nil {
+			// floating ends are not connected to any node
+			continue
+		}
 		if ((e.GetSourcePad().GetPadOwner() == n1) && (e.GetTargetPad().GetPadOwner() == n2)) || ((e.GetSourcePad().GetPadOwner() == n2) && (e.GetTargetPad().GetPadOwner() == n1)) {
 			return true
 		}
@@ -0,0 +1,87 @@
+package diagramwidget
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// themeColors are the colors of the application theme from which the default colors of a diagram are derived
+type themeColors struct {
+	foreground color.Color
+	background color.Color
+	primary    color.Color
+}
+
+// currentThemeColors returns the colors of the current application theme and variant
+func currentThemeColors() themeColors {
+	appTheme := fyne.CurrentApp().Settings().Theme()
+	appVariant := fyne.CurrentApp().Settings().ThemeVariant()
+	return themeColors{
+		foreground: appTheme.Color(theme.ColorNameForeground, appVariant),
+		background: appTheme.Color(theme.ColorNameBackground, appVariant),
+		primary:    appTheme.Color(theme.ColorNamePrimary, appVariant),
+	}
+}
+
+// equal returns true if both sets of colors are the same
+func (tc themeColors) equal(other themeColors) bool {
+	return sameColor(tc.foreground, other.foreground) && sameColor(tc.background, other.background) &&
+		sameColor(tc.primary, other.primary)
+}
+
+// applyTo returns the properties with each color that still has the value derived from the old theme colors
+// replaced by the one derived from these colors. Colors that have been overridden are left unchanged.
+func (tc themeColors) applyTo(properties DiagramElementProperties, old themeColors) DiagramElementProperties {
+	properties.ForegroundColor = followTheme(properties.ForegroundColor, old.foreground, tc.foreground)
+	properties.HandleColor = followTheme(properties.HandleColor, old.foreground, tc.foreground)
+	properties.BackgroundColor = followTheme(properties.BackgroundColor, old.background, tc.background)
+	properties.PadColor = followTheme(properties.PadColor, old.primary, tc.primary)
+	return properties
+}
+
+// followTheme returns the new theme color if the color is the old one, otherwise the color is an override and is
+// returned unchanged
+func followTheme(c, oldThemeColor, newThemeColor color.Color) color.Color {
+	if sameColor(c, oldThemeColor) {
+		return newThemeColor
+	}
+	return c
+}
+
+// sameColor returns true if the colors look the same, whatever their color models
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+// updateThemeColors updates the default colors of the diagram and the colors of its elements if the application
+// theme has changed since they were derived from it. Colors that have been set to something else are kept.
+func (dw *DiagramWidget) updateThemeColors() {
+	current := currentThemeColors()
+	old := dw.themeColors
+	if current.equal(old) {
+		return
+	}
+	dw.themeColors = current
+	dw.DefaultDiagramElementProperties = current.applyTo(dw.DefaultDiagramElementProperties, old)
+
+	for listElement := dw.DiagramElements.Front(); listElement != nil; listElement = listElement.Next() {
+		element := listElement.Value.(DiagramElement)
+		element.SetProperties(current.applyTo(element.GetProperties(), old))
+		if link, ok := element.(DiagramLink); ok {
+			bdl := link.getBaseDiagramLink()
+			for _, texts := range []map[string]*AnchoredText{bdl.sourceAnchoredText, bdl.midpointAnchoredText, bdl.targetAnchoredText} {
+				for _, text := range texts {
+					text.ForegroundColor = followTheme(text.ForegroundColor, old.foreground, current.foreground)
+				}
+			}
+		}
+		element.Refresh()
+	}
+}
@@ -0,0 +1,77 @@
+package diagramwidget
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	// tooltipDelay is the time the mouse has to rest on an element before its tooltip is shown
+	tooltipDelay = 750 * time.Millisecond
+	// tooltipOffset is the distance of the tooltip from the cursor
+	tooltipOffset float32 = 16
+)
+
+// startTooltipTimer calls the function once the tooltip delay has passed, tests replace it to show tooltips at once
+var startTooltipTimer = func(show func()) *time.Timer {
+	return time.AfterFunc(tooltipDelay, show)
+}
+
+// diagramTooltip is drawn on top of the diagram elements. It is not hoverable, so it does not interfere
+// with the hover events of the elements underneath it.
+type diagramTooltip struct {
+	content *fyne.Container
+	label   *widget.Label
+	timer   *time.Timer
+}
+
+func newDiagramTooltip() *diagramTooltip {
+	background := canvas.NewRectangle(theme.OverlayBackgroundColor())
+	background.StrokeColor = theme.ShadowColor()
+	background.StrokeWidth = 1
+	label := widget.NewLabel("")
+	content := container.NewStack(background, label)
+	content.Hide()
+	return &diagramTooltip{content: content, label: label}
+}
+
+// hideTooltip cancels any pending tooltip and hides the one being shown
+func (dw *DiagramWidget) hideTooltip() {
+	if dw.tooltip.timer != nil {
+		dw.tooltip.timer.Stop()
+		dw.tooltip.timer = nil
+	}
+	if dw.tooltip.content.Visible() {
+		dw.tooltip.content.Hide()
+		dw.drawingArea.Refresh()
+	}
+}
+
+// scheduleTooltip shows the element's tooltip near the position (in diagram coordinates) once the mouse has
+// rested there for the tooltip delay. Any tooltip already shown or scheduled is hidden first.
+func (dw *DiagramWidget) scheduleTooltip(de DiagramElement, position fyne.Position) {
+	dw.hideTooltip()
+	text := de.GetTooltip()
+	if text == "" {
+		return
+	}
+
+	var timer *time.Timer
+	timer = startTooltipTimer(func() {
+		if dw.tooltip.timer != timer {
+			return
+		}
+		dw.tooltip.timer = nil
+		dw.tooltip.label.SetText(text)
+		dw.tooltip.content.Resize(dw.tooltip.content.MinSize())
+		dw.tooltip.content.Move(position.AddXY(tooltipOffset, tooltipOffset))
+		dw.tooltip.content.Show()
+		dw.drawingArea.Refresh()
+	})
+	dw.tooltip.timer = timer
+}
@@ -0,0 +1,134 @@
+package diagramwidget
+
+import (
+	"fyne.io/fyne/v2"
+)
+
+// LayoutDirection is the direction in which LayoutTree places the layers of a tree
+type LayoutDirection int
+
+// Specify the enumerated values for LayoutDirection
+const (
+	// TopDown places the root at the top with each layer of children below the previous one
+	TopDown LayoutDirection = iota
+	// LeftRight places the root at the left with each layer of children to the right of the previous one
+	LeftRight
+)
+
+// LayoutTree arranges the nodes reachable from the root in layers, such as for an org chart or syntax tree.
+// The children of a node are the targets of the links for which it is the source, and each node is placed in the
+// layer given by its distance from the root. Where a node can be reached along more than one path, as in a
+// directed acyclic graph, it is placed under the first parent found. Each parent is centered on its children and
+// subtrees do not overlap. The hGap and vGap are the horizontal and vertical spaces between the nodes, so in a
+// TopDown layout hGap separates siblings and vGap separates layers. The root stays where it is, and nodes that
+// cannot be reached from it are left in place. Links follow the nodes.
+func (dw *DiagramWidget) LayoutTree(root DiagramElement, direction LayoutDirection, hGap, vGap float32) {
+	rootNode, ok := root.(DiagramNode)
+	if !ok {
+		return
+	}
+	order, children, ranks := dw.spanningTree(rootNode)
+
+	// breadth is the extent of a node along its layer, depth its extent from one layer to the next
+	breadth := func(node DiagramNode) float32 {
+		if direction == LeftRight {
+			return node.Size().Height
+		}
+		return node.Size().Width
+	}
+	depth := func(node DiagramNode) float32 {
+		if direction == LeftRight {
+			return node.Size().Width
+		}
+		return node.Size().Height
+	}
+	siblingGap, layerGap := hGap, vGap
+	if direction == LeftRight {
+		siblingGap, layerGap = vGap, hGap
+	}
+
+	thickness := []float32{}
+	for _, node := range order {
+		rank := ranks[node]
+		if rank == len(thickness) {
+			thickness = append(thickness, 0)
+		}
+		thickness[rank] = fyne.Max(thickness[rank], depth(node))
+	}
+	layerStart := make([]float32, len(thickness))
+	for i := 1; i < len(thickness); i++ {
+		layerStart[i] = layerStart[i-1] + thickness[i-1] + layerGap
+	}
+
+	// the breadth of each subtree, which is wide enough for the node and for the subtrees of its children
+	subtrees := map[DiagramNode]float32{}
+	childrenBreadth := func(node DiagramNode) float32 {
+		total := float32(0)
+		for i, child := range children[node] {
+			if i > 0 {
+				total += siblingGap
+			}
+			total += subtrees[child]
+		}
+		return total
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		node := order[i]
+		subtrees[node] = fyne.Max(breadth(node), childrenBreadth(node))
+	}
+
+	// place each node centered in its subtree, and the subtrees of its children side by side beneath it
+	targets := map[DiagramNode]fyne.Position{}
+	starts := map[DiagramNode]float32{rootNode: 0}
+	for _, node := range order {
+		start := starts[node]
+		rank := ranks[node]
+		along := start + (subtrees[node]-breadth(node))/2
+		across := layerStart[rank] + (thickness[rank]-depth(node))/2
+		if direction == LeftRight {
+			targets[node] = fyne.NewPos(across, along)
+		} else {
+			targets[node] = fyne.NewPos(along, across)
+		}
+
+		next := start + (subtrees[node]-childrenBreadth(node))/2
+		for _, child := range children[node] {
+			starts[child] = next
+			next += subtrees[child] + siblingGap
+		}
+	}
+
+	shift := rootNode.Position().Subtract(targets[rootNode])
+	for _, node := range order {
+		dw.DisplaceNode(node, targets[node].Add(shift).Subtract(node.Position()))
+	}
+}
+
+// spanningTree returns the nodes reachable from the root along the direction of the links in breadth first order,
+// along with the children of each node in the tree and the distance of each node from the root
+func (dw *DiagramWidget) spanningTree(root DiagramNode) ([]DiagramNode, map[DiagramNode][]DiagramNode, map[DiagramNode]int) {
+	links := dw.GetDiagramLinks()
+	order := []DiagramNode{root}
+	children := map[DiagramNode][]DiagramNode{}
+	ranks := map[DiagramNode]int{root: 0}
+	for i := 0; i < len(order); i++ {
+		parent := order[i]
+		for _, link := range links {
+			if link.GetSourcePad() == nil || link.GetTargetPad() == nil ||
+				link.GetSourcePad().GetPadOwner() != parent {
+				continue
+			}
+			child, ok := link.GetTargetPad().GetPadOwner().(DiagramNode)
+			if !ok {
+				continue
+			}
+			if _, found := ranks[child]; found {
+				continue
+			}
+			ranks[child] = ranks[parent] + 1
+			children[parent] = append(children[parent], child)
+			order = append(order, child)
+		}
+	}
+	return order, children, ranks
+}
@@ -0,0 +1,74 @@
+package diagramwidget
+
+import (
+	"sort"
+
+	"fyne.io/fyne/v2"
+)
+
+// ViewportState describes how the user is viewing the diagram, as opposed to the content of the diagram.
+// It can be saved along with a document so that the view is restored when the document is reopened.
+type ViewportState struct {
+	// Center is the point of the drawing area shown at the center of the visible area
+	Center fyne.Position
+	// Zoom is the magnification of the diagram. The diagram cannot currently be zoomed,
+	// so this is always 1 and it is ignored when the state is restored.
+	Zoom float32
+	// SelectedIDs holds the IDs of the selected elements, starting with the primary selection
+	SelectedIDs []string
+}
+
+// SetViewportState scrolls the diagram so that the center of the state is shown at the center of the visible area,
+// as far as the size of the diagram allows, and selects the elements of the state. IDs of elements that are no longer
+// in the diagram are ignored. The PrimaryDiagramElementSelectionChangedCallback is invoked if the primary selection changes.
+func (dw *DiagramWidget) SetViewportState(state ViewportState) {
+	visible := dw.scrollingContainer.Size()
+	content := dw.drawingArea.Size()
+	offset := state.Center.SubtractXY(visible.Width/2, visible.Height/2)
+	offset.X = fyne.Max(0, fyne.Min(offset.X, content.Width-visible.Width))
+	offset.Y = fyne.Max(0, fyne.Min(offset.Y, content.Height-visible.Height))
+	dw.scrollingContainer.Offset = offset
+	dw.scrollingContainer.Refresh()
+
+	oldPrimary := dw.primarySelection
+	dw.ClearSelectionNoCallback()
+	for _, id := range state.SelectedIDs {
+		element := dw.GetDiagramElement(id)
+		if element == nil || dw.IsSelected(element) {
+			continue
+		}
+		if dw.primarySelection == nil {
+			dw.primarySelection = element
+		}
+		dw.selection[id] = element
+		element.ShowHandles()
+	}
+	if dw.primarySelection != oldPrimary && dw.PrimaryDiagramElementSelectionChangedCallback != nil {
+		primaryID := ""
+		if dw.primarySelection != nil {
+			primaryID = dw.primarySelection.GetDiagramElementID()
+		}
+		dw.PrimaryDiagramElementSelectionChangedCallback(primaryID)
+	}
+}
+
+// ViewportState returns the current scroll position and selection of the diagram
+func (dw *DiagramWidget) ViewportState() ViewportState {
+	visible := dw.scrollingContainer.Size()
+	state := ViewportState{
+		Center: dw.scrollingContainer.Offset.AddXY(visible.Width/2, visible.Height/2),
+		Zoom:   1,
+	}
+
+	others := []string{}
+	for id, element := range dw.selection {
+		if element == dw.primarySelection {
+			state.SelectedIDs = append(state.SelectedIDs, id)
+		} else {
+			others = append(others, id)
+		}
+	}
+	sort.Strings(others)
+	state.SelectedIDs = append(state.SelectedIDs, others...)
+	return state
+}
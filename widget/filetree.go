@@ -1,13 +1,22 @@
 package widget
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/fsnotify/fsnotify"
 )
 
 // FileTree extends widget.Tree to display a file system hierarchy.
@@ -17,68 +26,227 @@ type FileTree struct {
 	ShowRootPath bool
 	Sorter       func(fyne.URI, fyne.URI) bool
 
+	asyncLoading      bool
+	checked           map[widget.TreeNodeID]bool
+	checkParents      map[widget.TreeNodeID]widget.TreeNodeID
+	filterFunc        func(fyne.URI) bool
+	hideIcons         bool
+	independentChecks bool
+	multiSelect       bool
+	showSize          bool
+	sortComparator    func(fyne.URI, fyne.URI) int
+
+	onDrop      func(src, destDir fyne.URI, copy bool) error
+	dragHandles map[widget.TreeNodeID]*fileTreeDragHandle
+	dragPreview *widget.PopUp
+	dragSource  widget.TreeNodeID
+	dropTarget  *fileTreeDragHandle
+
+	onRename        func(uri fyne.URI, newName string) error
+	renameHandles   map[widget.TreeNodeID]*fileTreeRenameHandle
+	renameCandidate widget.TreeNodeID // the node that F2 renames
+	renameError     *widget.PopUp
+	renaming        widget.TreeNodeID
+
+	// listLock guards the directory content and the state derived from it: the checks, the file sizes
+	// and the drag and rename handles showing each node
+	listLock      sync.RWMutex
 	listCache     map[widget.TreeNodeID][]widget.TreeNodeID
+	loading       map[widget.TreeNodeID]bool
+	sizeCache     map[widget.TreeNodeID]string
+	expanding     bool
+	pendingSelect widget.TreeNodeID
+
+	// loadLock is held while a directory read in the background is applied to the tree, and while the
+	// methods that open or close several branches update it, so that they refresh the tree one at a time
+	loadLock sync.Mutex
+	loads    sync.WaitGroup // the directories being read in the background
+
+	uriLock       sync.RWMutex
 	listableCache map[widget.TreeNodeID]fyne.ListableURI
 	uriCache      map[widget.TreeNodeID]fyne.URI
+	roots         []FileTreeRoot
+
+	watchLock sync.RWMutex
+	watcher   *fsnotify.Watcher
+	watched   map[string]watchedDir
+	stopWatch chan struct{}
+}
+
+// FileTreeRoot is one of the starting points shown at the top level of a FileTree, see SetRoots.
+type FileTreeRoot struct {
+	// Label is shown in place of the name of the URI, if set
+	Label string
+	// Icon is shown in place of the folder or file icon, if set
+	Icon fyne.Resource
+	URI  fyne.URI
 }
 
+// watchedDir is a loaded directory that is being watched for changes.
+type watchedDir struct {
+	id       widget.TreeNodeID
+	listable fyne.ListableURI
+}
+
+// pollInterval is how often directories are checked for changes when the file system cannot be watched.
+const pollInterval = time.Second
+
+// rootsID is the ID of the hidden node holding the roots set by SetRoots.
+// The tree does not show its root node when the ID is empty.
+const rootsID = ""
+
+// loadingSuffix is appended to a branch ID to form the ID of its placeholder node.
+// It uses a character that cannot appear in a valid URI.
+const loadingSuffix = "\x00loading"
+
+type checkState int
+
+const (
+	checkNone checkState = iota
+	checkPartial
+	checkAll
+)
+
 // NewFileTree creates a new FileTree from the given root URI.
 func NewFileTree(root fyne.URI) *FileTree {
 	tree := &FileTree{
 		Tree: widget.Tree{
 			Root: root.String(),
-			CreateNode: func(branch bool) fyne.CanvasObject {
-				var icon fyne.CanvasObject
-				if branch {
-					icon = widget.NewIcon(nil)
-				} else {
-					icon = widget.NewFileIcon(nil)
-				}
-				return container.NewBorder(nil, nil, icon, nil, widget.NewLabel("Template Object"))
-			},
 		},
+		checked:       make(map[widget.TreeNodeID]bool),
+		checkParents:  make(map[widget.TreeNodeID]widget.TreeNodeID),
 		listCache:     make(map[widget.TreeNodeID][]widget.TreeNodeID),
+		loading:       make(map[widget.TreeNodeID]bool),
 		listableCache: make(map[widget.TreeNodeID]fyne.ListableURI),
 		uriCache:      make(map[widget.TreeNodeID]fyne.URI),
+		sizeCache:     make(map[widget.TreeNodeID]string),
+		dragHandles:   make(map[widget.TreeNodeID]*fileTreeDragHandle),
+		renameHandles: make(map[widget.TreeNodeID]*fileTreeRenameHandle),
+	}
+	tree.CreateNode = func(branch bool) fyne.CanvasObject {
+		var icon fyne.CanvasObject
+		if branch {
+			icon = widget.NewIcon(nil)
+		} else {
+			icon = widget.NewFileIcon(nil)
+		}
+		check := newFileTreeCheck()
+		check.Hide()
+		rootIcon := widget.NewIcon(nil)
+		rootIcon.Hide()
+		size := widget.NewLabel("")
+		size.Hide()
+		drag := newFileTreeDragHandle(tree)
+		drag.Hide()
+		node := container.NewBorder(nil, nil, container.NewHBox(check, icon, rootIcon), size, widget.NewLabel("Template Object"))
+		// added last so that it is drawn over the name, the rename handle is added after it while renaming is enabled
+		node.Objects = append(node.Objects, drag)
+		return node
 	}
 	tree.IsBranch = func(id widget.TreeNodeID) bool {
+		if isLoadingNode(id) {
+			return false
+		}
+		if id == rootsID && tree.Root == rootsID {
+			return true
+		}
 		_, err := tree.toListable(id)
 		return err == nil
 	}
 	tree.ChildUIDs = func(id widget.TreeNodeID) (c []string) {
+		if id == rootsID && tree.Root == rootsID {
+			return tree.rootIDs()
+		}
 		listable, err := tree.toListable(id)
 		if err != nil {
 			fyne.LogError("Unable to get lister for "+id, err)
 			return
 		}
 
-		ids, ok := tree.listCache[id]
+		ids, ok := tree.cachedChildren(id)
 		if ok {
+			if tree.multiSelect {
+				tree.trackChecks(id, ids)
+			}
+			tree.watch(id, listable)
 			return ids
 		}
 
-		uris, err := listable.List()
-		if err != nil {
-			fyne.LogError("Unable to list "+listable.String(), err)
-			return
-		}
-
-		for _, u := range tree.sort(tree.filter(uris)) {
-			// Convert to String
-			c = append(c, u.String())
+		if tree.asyncLoading {
+			tree.loadChildren(id, listable)
+			return []string{id + loadingSuffix}
 		}
 
+		c = tree.listChildren(listable)
+		tree.listLock.Lock()
 		tree.listCache[id] = c
+		tree.listLock.Unlock()
+		if tree.multiSelect {
+			tree.trackChecks(id, c)
+		}
+		tree.watch(id, listable)
 		return
 	}
 	tree.UpdateNode = func(id widget.TreeNodeID, branch bool, node fyne.CanvasObject) {
+		c := node.(*fyne.Container)
+		left := c.Objects[1].(*fyne.Container)
+		check := left.Objects[0].(*fileTreeCheck)
+		icon := left.Objects[1]
+		rootIcon := left.Objects[2].(*widget.Icon)
+		rootIcon.Hide()
+		drag := c.Objects[3].(*fileTreeDragHandle)
+		tree.showDragHandle(drag, id)
+		editing := tree.renaming == id
+		if isLoadingNode(id) || tree.onDrop == nil || editing {
+			drag.Hide()
+		} else {
+			drag.Show()
+		}
+		rename := tree.nodeRenameHandle(c)
+		if rename != nil {
+			tree.showRenameHandle(rename, id)
+			if isLoadingNode(id) || tree.isRoot(id) {
+				rename.Hide()
+			} else {
+				rename.Show()
+			}
+		}
+		if isLoadingNode(id) {
+			check.Hide()
+			icon.Hide()
+			c.Objects[2].Hide()
+			c.Objects[0].(*widget.Label).SetText("Loading…")
+			return
+		}
+
 		uri, err := tree.toURI(id)
 		if err != nil {
 			fyne.LogError("Unable to parse URI", err)
 			return
 		}
 
-		c := node.(*fyne.Container)
+		if tree.multiSelect {
+			check.setState(tree.checkState(id))
+			check.onTapped = func() {
+				tree.toggleChecked(id)
+			}
+			check.Show()
+		} else {
+			check.Hide()
+		}
+		if tree.hideIcons {
+			icon.Hide()
+		} else {
+			icon.Show()
+		}
+		size := c.Objects[2].(*widget.Label)
+		if tree.showSize && !branch {
+			size.SetText(tree.fileSize(id, uri))
+			size.Show()
+		} else {
+			size.Hide()
+		}
+
 		if branch {
 			var r fyne.Resource
 			if tree.IsBranchOpen(id) {
@@ -88,10 +256,10 @@ func NewFileTree(root fyne.URI) *FileTree {
 				// Set folder icon
 				r = theme.FolderIcon()
 			}
-			c.Objects[1].(*widget.Icon).SetResource(r)
+			icon.(*widget.Icon).SetResource(r)
 		} else {
 			// Set file uri to update icon
-			c.Objects[1].(*widget.FileIcon).SetURI(uri)
+			icon.(*widget.FileIcon).SetURI(uri)
 		}
 
 		var l string
@@ -100,11 +268,32 @@ func NewFileTree(root fyne.URI) *FileTree {
 		} else {
 			l = uri.Name()
 		}
+		if root, ok := tree.root(id); ok {
+			if root.Label != "" {
+				l = root.Label
+			}
+			if root.Icon != nil && !tree.hideIcons {
+				icon.Hide()
+				rootIcon.SetResource(root.Icon)
+				rootIcon.Show()
+			}
+		}
 		c.Objects[0].(*widget.Label).SetText(l)
+		if rename != nil {
+			rename.setEditing(editing, l)
+		}
 	}
 
 	// reset sorted child ID cache if the branch is closed - in the future we do FS watch
 	tree.OnBranchClosed = func(id widget.TreeNodeID) {
+		tree.unwatch(id)
+		tree.listLock.Lock()
+		defer tree.listLock.Unlock()
+		for _, child := range tree.listCache[id] {
+			delete(tree.sizeCache, child)
+			delete(tree.dragHandles, child)
+			delete(tree.renameHandles, child)
+		}
 		delete(tree.listCache, id)
 	}
 
@@ -112,21 +301,639 @@ func NewFileTree(root fyne.URI) *FileTree {
 	return tree
 }
 
+// SetAsyncLoading sets whether directory content is read on a background goroutine.
+// While a directory is being read it shows a single "Loading…" placeholder child,
+// which keeps the interface responsive for large directories or slow file systems.
+// Note that OpenAllBranches only opens directories that have already been loaded.
+func (t *FileTree) SetAsyncLoading(async bool) {
+	t.asyncLoading = async
+}
+
+// SetRoots replaces the root of the tree with several starting points, such as for a bookmarks
+// or recent files panel. Each root is shown at the top level with its own label and icon,
+// and the content of a directory root is loaded as usual. The URIs of the roots must be different.
+func (t *FileTree) SetRoots(roots []FileTreeRoot) {
+	t.uriLock.Lock()
+	t.roots = roots
+	t.uriLock.Unlock()
+	t.Root = rootsID
+	t.resetListCache()
+	t.Refresh()
+}
+
+// CollapseAll closes every directory in the tree, stopping an expansion started by ExpandAll.
+func (t *FileTree) CollapseAll() {
+	t.listLock.Lock()
+	t.expanding = false
+	t.listLock.Unlock()
+	t.loadLock.Lock()
+	defer t.loadLock.Unlock()
+	t.CloseAllBranches()
+}
+
+// ExpandAll opens every directory in the tree.
+// With async loading the directories are read in the background and the tree expands one level at a time
+// as their content arrives, so that huge trees do not freeze the interface.
+// Otherwise the whole tree is read at once.
+func (t *FileTree) ExpandAll() {
+	t.listLock.Lock()
+	t.expanding = true
+	t.listLock.Unlock()
+	t.loadLock.Lock()
+	defer t.loadLock.Unlock()
+	t.expandLoaded()
+}
+
+// SelectPath selects the file or directory at the URI, opening the directories that contain it
+// and scrolling it into view. With async loading the selection is made once the directories are read.
+func (t *FileTree) SelectPath(uri fyne.URI) {
+	ancestors, err := t.ancestors(uri)
+	if err != nil {
+		fyne.LogError("Unable to select "+uri.String(), err)
+		return
+	}
+	t.loadLock.Lock()
+	defer t.loadLock.Unlock()
+	for _, id := range ancestors {
+		if !t.IsBranchOpen(id) {
+			t.OpenBranch(id)
+		}
+	}
+
+	t.listLock.Lock()
+	t.pendingSelect = uri.String()
+	t.listLock.Unlock()
+	t.selectPending()
+}
+
+// SetSortComparator sets a function used to order the entries of each directory.
+// It should return a negative number when a sorts before b, a positive number when a sorts after b
+// and zero when they are equal. When set it takes precedence over Sorter.
+// Passing nil restores the default ordering.
+func (t *FileTree) SetSortComparator(cmp func(a, b fyne.URI) int) {
+	t.sortComparator = cmp
+	t.resetListCache()
+	t.Refresh()
+}
+
+// SetMultiSelect sets whether a checkbox is shown for each entry so that multiple files can be selected.
+// Checking a directory also checks everything inside it, and a directory shows a partial state
+// when only some of its content is checked. Use SetSelectDescendants to change this.
+func (t *FileTree) SetMultiSelect(multi bool) {
+	t.multiSelect = multi
+	t.Refresh()
+}
+
+// SetSelectDescendants sets whether checking a directory in multi-select mode also checks its content.
+// This is enabled by default.
+func (t *FileTree) SetSelectDescendants(descendants bool) {
+	t.independentChecks = !descendants
+	t.Refresh()
+}
+
+// SelectedURIs returns the URIs of all files and directories that are checked in multi-select mode.
+// The content of a checked directory is only included once it has been loaded.
+func (t *FileTree) SelectedURIs() []fyne.URI {
+	t.listLock.RLock()
+	ids := make([]string, 0, len(t.checked))
+	for id := range t.checked {
+		ids = append(ids, id)
+	}
+	t.listLock.RUnlock()
+	sort.Strings(ids)
+
+	uris := make([]fyne.URI, 0, len(ids))
+	for _, id := range ids {
+		uri, err := t.toURI(id)
+		if err != nil {
+			fyne.LogError("Unable to parse URI", err)
+			continue
+		}
+		uris = append(uris, uri)
+	}
+	return uris
+}
+
+// SetShowIcons sets whether an icon is displayed before each entry.
+// Files show an icon matching their type and directories show an open or closed folder.
+// Icons are shown by default.
+func (t *FileTree) SetShowIcons(show bool) {
+	t.hideIcons = !show
+	t.Refresh()
+}
+
+// SetShowSize sets whether the size of each file is displayed after its name.
+// Sizes are only looked up when a file becomes visible.
+func (t *FileTree) SetShowSize(show bool) {
+	t.showSize = show
+	t.Refresh()
+}
+
+// SetAutoRefresh sets whether loaded directories are watched so that files which are
+// added or removed outside of the application appear or disappear automatically.
+// If the file system cannot be watched the directories are polled for changes instead.
+// Only directories on the local file system are watched.
+func (t *FileTree) SetAutoRefresh(auto bool) {
+	t.watchLock.Lock()
+	if auto == (t.stopWatch != nil) {
+		t.watchLock.Unlock()
+		return
+	}
+
+	if !auto {
+		close(t.stopWatch)
+		t.stopWatch = nil
+		if t.watcher != nil {
+			t.watcher.Close()
+			t.watcher = nil
+		}
+		t.watched = nil
+		t.watchLock.Unlock()
+		return
+	}
+
+	t.stopWatch = make(chan struct{})
+	t.watched = make(map[string]watchedDir)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		fyne.LogError("Unable to watch file system, polling for changes instead", err)
+		go t.pollChanges(t.stopWatch)
+	} else {
+		t.watcher = watcher
+		go t.watchChanges(watcher, t.stopWatch)
+	}
+	t.watchLock.Unlock()
+
+	t.listLock.RLock()
+	loaded := make([]widget.TreeNodeID, 0, len(t.listCache))
+	for id := range t.listCache {
+		loaded = append(loaded, id)
+	}
+	t.listLock.RUnlock()
+	for _, id := range loaded {
+		if listable, err := t.toListable(id); err == nil {
+			t.watch(id, listable)
+		}
+	}
+}
+
+// SetFilter sets a function that decides which files are visible in the tree.
+// Directories that the function rejects are shown while they contain a visible entry, so that matching files
+// inside them can be reached, and their content is filtered when the branch is expanded.
+// Passing nil removes the filter.
+func (t *FileTree) SetFilter(filter func(fyne.URI) bool) {
+	t.filterFunc = filter
+	t.resetListCache()
+	t.Refresh()
+}
+
+// SetExtensionFilter is a convenience for SetFilter that only shows files with one of the given extensions.
+// Extensions should include the leading dot, for example ".go".
+func (t *FileTree) SetExtensionFilter(extensions []string) {
+	filter := storage.NewExtensionFileFilter(extensions)
+	t.SetFilter(filter.Matches)
+}
+
+// ancestors returns the IDs of the directories from the root of the tree down to the parent of the URI.
+func (t *FileTree) ancestors(uri fyne.URI) ([]widget.TreeNodeID, error) {
+	var ids []widget.TreeNodeID
+	id := uri.String()
+	for !t.isRoot(id) {
+		parent, err := storage.Parent(uri)
+		if err != nil {
+			if t.Root == rootsID {
+				return nil, errors.New("not inside any of the tree roots")
+			}
+			return nil, errors.New("not inside the tree root " + t.Root)
+		}
+		uri = parent
+		// directories are listed without the trailing slash that Parent adds
+		id = strings.TrimSuffix(parent.String(), "/")
+		if t.isRoot(id + "/") {
+			id += "/"
+		}
+		ids = append([]widget.TreeNodeID{id}, ids...)
+	}
+	return ids, nil
+}
+
+// isRoot returns true if the ID is the root of the tree or one of the roots set by SetRoots.
+func (t *FileTree) isRoot(id widget.TreeNodeID) bool {
+	if t.Root != rootsID {
+		return id == t.Root
+	}
+	_, ok := t.root(id)
+	return ok
+}
+
+// root returns the root set by SetRoots with the ID, if there is one.
+func (t *FileTree) root(id widget.TreeNodeID) (FileTreeRoot, bool) {
+	t.uriLock.RLock()
+	defer t.uriLock.RUnlock()
+	for _, root := range t.roots {
+		if root.URI != nil && root.URI.String() == id {
+			return root, true
+		}
+	}
+	return FileTreeRoot{}, false
+}
+
+// rootIDs returns the IDs of the roots set by SetRoots.
+func (t *FileTree) rootIDs() []widget.TreeNodeID {
+	t.uriLock.RLock()
+	defer t.uriLock.RUnlock()
+	ids := make([]widget.TreeNodeID, 0, len(t.roots))
+	for _, root := range t.roots {
+		if root.URI != nil {
+			ids = append(ids, root.URI.String())
+		}
+	}
+	return ids
+}
+
+func (t *FileTree) cachedChildren(id widget.TreeNodeID) ([]widget.TreeNodeID, bool) {
+	t.listLock.RLock()
+	defer t.listLock.RUnlock()
+	ids, ok := t.listCache[id]
+	return ids, ok
+}
+
+func (t *FileTree) checkState(id widget.TreeNodeID) checkState {
+	t.listLock.RLock()
+	defer t.listLock.RUnlock()
+	return t.checkStateLocked(id)
+}
+
+// checkStateLocked returns the check state of the node, the caller must hold listLock
+func (t *FileTree) checkStateLocked(id widget.TreeNodeID) checkState {
+	children := t.listCache[id]
+	if t.independentChecks || len(children) == 0 {
+		if t.checked[id] {
+			return checkAll
+		}
+		return checkNone
+	}
+
+	all, any := true, false
+	for _, child := range children {
+		switch t.checkStateLocked(child) {
+		case checkAll:
+			any = true
+		case checkPartial:
+			all, any = false, true
+		default:
+			all = false
+		}
+	}
+	if all {
+		return checkAll
+	} else if any {
+		return checkPartial
+	}
+	return checkNone
+}
+
+// setCheckedLocked checks or unchecks the node and, unless checks are independent, its loaded content.
+// The caller must hold listLock.
+func (t *FileTree) setCheckedLocked(id widget.TreeNodeID, checked bool) {
+	if checked {
+		t.checked[id] = true
+	} else {
+		delete(t.checked, id)
+	}
+	if t.independentChecks {
+		return
+	}
+
+	for _, child := range t.listCache[id] {
+		t.setCheckedLocked(child, checked)
+	}
+}
+
+// trackChecks remembers the parent of each child and checks the content of a checked directory once it is loaded.
+func (t *FileTree) trackChecks(id widget.TreeNodeID, children []widget.TreeNodeID) {
+	t.listLock.Lock()
+	defer t.listLock.Unlock()
+	inherit := t.checked[id] && !t.independentChecks
+	for _, child := range children {
+		t.checkParents[child] = id
+		if inherit {
+			t.checked[child] = true
+		}
+	}
+}
+
+func (t *FileTree) toggleChecked(id widget.TreeNodeID) {
+	t.listLock.Lock()
+	t.setCheckedLocked(id, t.checkStateLocked(id) != checkAll)
+	if !t.independentChecks {
+		t.updateParentChecksLocked(id)
+	}
+	t.listLock.Unlock()
+	t.Refresh()
+}
+
+// updateParentChecksLocked marks each parent of the given node as checked only if all of its content is checked.
+// The caller must hold listLock.
+func (t *FileTree) updateParentChecksLocked(id widget.TreeNodeID) {
+	for id != t.Root {
+		parent, ok := t.checkParents[id]
+		if !ok {
+			return
+		}
+		id = parent
+		children, ok := t.listCache[id]
+		if !ok {
+			return
+		}
+
+		all := true
+		for _, child := range children {
+			if !t.checked[child] {
+				all = false
+				break
+			}
+		}
+		if all {
+			t.checked[id] = true
+		} else {
+			delete(t.checked, id)
+		}
+	}
+}
+
+func (t *FileTree) fileSize(id widget.TreeNodeID, uri fyne.URI) string {
+	t.listLock.RLock()
+	size, ok := t.sizeCache[id]
+	t.listLock.RUnlock()
+	if ok {
+		return size
+	}
+	if uri.Scheme() != "file" {
+		return ""
+	}
+	info, err := os.Stat(uri.Path())
+	if err != nil {
+		fyne.LogError("Unable to stat "+uri.String(), err)
+		return ""
+	}
+
+	size = formatFileSize(info.Size())
+	t.listLock.Lock()
+	t.sizeCache[id] = size
+	t.listLock.Unlock()
+	return size
+}
+
+func (t *FileTree) watch(id widget.TreeNodeID, listable fyne.ListableURI) {
+	if listable.Scheme() != "file" {
+		return
+	}
+	path := filepath.Clean(listable.Path())
+
+	t.watchLock.Lock()
+	defer t.watchLock.Unlock()
+	if t.stopWatch == nil {
+		return
+	}
+	if _, ok := t.watched[path]; ok {
+		return
+	}
+
+	if t.watcher != nil {
+		if err := t.watcher.Add(path); err != nil {
+			fyne.LogError("Unable to watch "+path, err)
+			return
+		}
+	}
+	t.watched[path] = watchedDir{id: id, listable: listable}
+}
+
+func (t *FileTree) unwatch(id widget.TreeNodeID) {
+	t.watchLock.Lock()
+	defer t.watchLock.Unlock()
+	for path, dir := range t.watched {
+		if dir.id != id {
+			continue
+		}
+		if t.watcher != nil {
+			_ = t.watcher.Remove(path)
+		}
+		delete(t.watched, path)
+	}
+}
+
+func (t *FileTree) pollChanges(stop chan struct{}) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			t.watchLock.RLock()
+			dirs := make([]watchedDir, 0, len(t.watched))
+			for _, dir := range t.watched {
+				dirs = append(dirs, dir)
+			}
+			t.watchLock.RUnlock()
+
+			changed := false
+			for _, dir := range dirs {
+				if t.reload(dir) {
+					changed = true
+				}
+			}
+			if changed {
+				t.Refresh()
+			}
+		}
+	}
+}
+
 func (t *FileTree) filter(uris []fyne.URI) []fyne.URI {
 	filter := t.Filter
-	if filter == nil {
+	filterFunc := t.filterFunc
+	if filter == nil && filterFunc == nil {
 		return uris
 	}
 	var filtered []fyne.URI
 	for _, u := range uris {
-		if filter.Matches(u) {
-			filtered = append(filtered, u)
+		if filter != nil && !filter.Matches(u) {
+			continue
+		}
+		if filterFunc != nil && !t.visible(u, filterFunc) {
+			continue
 		}
+		filtered = append(filtered, u)
 	}
 	return filtered
 }
 
+// visible returns true if the filter function accepts the URI, or if it is a directory that contains a visible
+// entry. Only the URIs that the function rejects are checked for being a directory, and the search of a
+// directory stops at its first visible entry.
+func (t *FileTree) visible(u fyne.URI, filterFunc func(fyne.URI) bool) bool {
+	if filterFunc(u) {
+		return true
+	}
+	listable, err := t.toListable(u.String())
+	if err != nil {
+		return false
+	}
+	children, err := listable.List()
+	if err != nil {
+		return false
+	}
+	for _, child := range children {
+		if t.visible(child, filterFunc) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *FileTree) listChildren(listable fyne.ListableURI) (c []widget.TreeNodeID) {
+	uris, err := listable.List()
+	if err != nil {
+		fyne.LogError("Unable to list "+listable.String(), err)
+		return
+	}
+
+	for _, u := range t.sort(t.filter(uris)) {
+		// Convert to String
+		c = append(c, u.String())
+	}
+	return
+}
+
+// loadChildren lists the directory in the background and refreshes the tree once it is available.
+// Only reading the directory happens in the background, the result is then applied and the tree refreshed
+// under loadLock.
+func (t *FileTree) loadChildren(id widget.TreeNodeID, listable fyne.ListableURI) {
+	t.listLock.Lock()
+	if t.loading[id] {
+		t.listLock.Unlock()
+		return
+	}
+	t.loading[id] = true
+	t.loads.Add(1)
+	t.listLock.Unlock()
+
+	go func() {
+		defer t.loads.Done()
+		c := t.listChildren(listable)
+
+		t.loadLock.Lock()
+		defer t.loadLock.Unlock()
+		t.listLock.Lock()
+		delete(t.loading, id)
+		t.listCache[id] = c
+		expanding := t.expanding
+		t.listLock.Unlock()
+		if expanding {
+			t.expandChildren(c)
+		}
+		t.Refresh()
+		t.selectPending()
+	}()
+}
+
+// expandChildren continues an expansion started by ExpandAll, opening the directories among the children
+// that have just been read and starting to read them in turn. The expansion finishes when no directory is
+// left to read.
+func (t *FileTree) expandChildren(children []widget.TreeNodeID) {
+	for _, child := range children {
+		listable, err := t.toListable(child)
+		if err != nil {
+			continue
+		}
+		if _, ok := t.cachedChildren(child); !ok {
+			t.loadChildren(child, listable)
+		}
+		t.OpenBranch(child)
+	}
+
+	t.listLock.Lock()
+	if len(t.loading) == 0 {
+		t.expanding = false
+	}
+	t.listLock.Unlock()
+}
+
+// expandLoaded opens every directory that has been read, which starts reading the directories inside them.
+// The expansion finishes when there are no more directories to read.
+func (t *FileTree) expandLoaded() {
+	t.OpenAllBranches()
+
+	t.listLock.Lock()
+	if len(t.loading) == 0 {
+		t.expanding = false
+	}
+	t.listLock.Unlock()
+}
+
+// selectPending selects the node requested by SelectPath once the directories containing it have been read.
+func (t *FileTree) selectPending() {
+	t.listLock.RLock()
+	id := t.pendingSelect
+	t.listLock.RUnlock()
+	if id == "" {
+		return
+	}
+	uri, err := t.toURI(id)
+	if err != nil {
+		return
+	}
+	ancestors, err := t.ancestors(uri)
+	if err != nil {
+		return
+	}
+	for _, ancestor := range ancestors {
+		children := t.ChildUIDs(ancestor)
+		if len(children) == 1 && isLoadingNode(children[0]) {
+			return
+		}
+	}
+
+	t.listLock.Lock()
+	if t.pendingSelect != id {
+		t.listLock.Unlock()
+		return
+	}
+	t.pendingSelect = ""
+	t.listLock.Unlock()
+	t.Select(id)
+}
+
+// reload lists the directory again and refreshes the tree if its content changed.
+func (t *FileTree) reload(dir watchedDir) bool {
+	c := t.listChildren(dir.listable)
+
+	t.listLock.Lock()
+	old, ok := t.listCache[dir.id]
+	if !ok || equalIDs(old, c) {
+		t.listLock.Unlock()
+		return false
+	}
+	t.listCache[dir.id] = c
+	t.listLock.Unlock()
+	return true
+}
+
+func (t *FileTree) resetListCache() {
+	t.listLock.Lock()
+	t.listCache = make(map[widget.TreeNodeID][]widget.TreeNodeID)
+	t.listLock.Unlock()
+}
+
 func (t *FileTree) sort(uris []fyne.URI) []fyne.URI {
+	if cmp := t.sortComparator; cmp != nil {
+		sort.SliceStable(uris, func(i, j int) bool {
+			return cmp(uris[i], uris[j]) < 0
+		})
+		return uris
+	}
 	if sorter := t.Sorter; sorter != nil {
 		sort.Slice(uris, func(i, j int) bool {
 			return sorter(uris[i], uris[j])
@@ -136,7 +943,9 @@ func (t *FileTree) sort(uris []fyne.URI) []fyne.URI {
 }
 
 func (t *FileTree) toListable(id widget.TreeNodeID) (fyne.ListableURI, error) {
+	t.uriLock.RLock()
 	listable, ok := t.listableCache[id]
+	t.uriLock.RUnlock()
 	if ok {
 		return listable, nil
 	}
@@ -149,12 +958,16 @@ func (t *FileTree) toListable(id widget.TreeNodeID) (fyne.ListableURI, error) {
 	if err != nil {
 		return nil, err
 	}
+	t.uriLock.Lock()
 	t.listableCache[id] = listable
+	t.uriLock.Unlock()
 	return listable, nil
 }
 
 func (t *FileTree) toURI(id widget.TreeNodeID) (fyne.URI, error) {
+	t.uriLock.RLock()
 	uri, ok := t.uriCache[id]
+	t.uriLock.RUnlock()
 	if ok {
 		return uri, nil
 	}
@@ -163,6 +976,67 @@ func (t *FileTree) toURI(id widget.TreeNodeID) (fyne.URI, error) {
 	if err != nil {
 		return nil, err
 	}
+	t.uriLock.Lock()
 	t.uriCache[id] = uri
+	t.uriLock.Unlock()
 	return uri, nil
 }
+
+// fileTreeCheck is a tappable checkbox icon that can also show a partially checked state.
+type fileTreeCheck struct {
+	widget.Icon
+	onTapped func()
+}
+
+func newFileTreeCheck() *fileTreeCheck {
+	c := &fileTreeCheck{}
+	c.ExtendBaseWidget(c)
+	c.setState(checkNone)
+	return c
+}
+
+func (c *fileTreeCheck) Tapped(*fyne.PointEvent) {
+	if f := c.onTapped; f != nil {
+		f()
+	}
+}
+
+func (c *fileTreeCheck) setState(state checkState) {
+	switch state {
+	case checkAll:
+		c.SetResource(theme.CheckButtonCheckedIcon())
+	case checkPartial:
+		c.SetResource(theme.ContentRemoveIcon())
+	default:
+		c.SetResource(theme.CheckButtonIcon())
+	}
+}
+
+func equalIDs(a, b []widget.TreeNodeID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isLoadingNode(id widget.TreeNodeID) bool {
+	return strings.HasSuffix(id, loadingSuffix)
+}
+
+func formatFileSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
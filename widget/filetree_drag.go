@@ -0,0 +1,208 @@
+package widget
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SetOnDrop sets the function called when a file or directory is dragged onto a directory in the tree.
+// The function performs the file system operation, moving the source into the destination directory,
+// or copying it if copy is true. Copying is requested by holding the shortcut modifier (Control or Command)
+// when the item is dropped. If the function returns an error the tree is left unchanged.
+// Dragging is only enabled while a function is set.
+func (t *FileTree) SetOnDrop(onDrop func(src, destDir fyne.URI, copy bool) error) {
+	t.onDrop = onDrop
+	t.Refresh()
+}
+
+// canDrop returns true if the source can be dropped onto the destination, which must be a directory
+// other than the source, its current parent or anything inside it.
+func (t *FileTree) canDrop(src, dest widget.TreeNodeID) bool {
+	if src == dest || isLoadingNode(dest) || strings.HasPrefix(dest, strings.TrimSuffix(src, "/")+"/") {
+		return false
+	}
+	if parent, ok := t.parentID(src); ok && parent == dest {
+		return false
+	}
+	return t.IsBranch(dest)
+}
+
+// drop asks the app to move or copy the source into the destination directory,
+// reloading the affected directories if it succeeds.
+func (t *FileTree) drop(src, dest widget.TreeNodeID, copy bool) bool {
+	if t.onDrop == nil || !t.canDrop(src, dest) {
+		return false
+	}
+	srcURI, err := t.toURI(src)
+	if err != nil {
+		fyne.LogError("Unable to parse URI", err)
+		return false
+	}
+	destURI, err := t.toURI(dest)
+	if err != nil {
+		fyne.LogError("Unable to parse URI", err)
+		return false
+	}
+
+	if err := t.onDrop(srcURI, destURI, copy); err != nil {
+		fyne.LogError("Unable to drop "+src+" on "+dest, err)
+		return false
+	}
+
+	parent, hasParent := t.parentID(src)
+	t.listLock.Lock()
+	delete(t.listCache, dest)
+	if hasParent && !copy {
+		delete(t.listCache, parent)
+	}
+	t.listLock.Unlock()
+	t.Refresh()
+	return true
+}
+
+// dropTargetAt returns the handle of the row at the absolute position if the dragged item can be dropped on it
+func (t *FileTree) dropTargetAt(pos fyne.Position) *fileTreeDragHandle {
+	t.listLock.RLock()
+	handles := make([]*fileTreeDragHandle, 0, len(t.dragHandles))
+	for _, h := range t.dragHandles {
+		handles = append(handles, h)
+	}
+	t.listLock.RUnlock()
+
+	d := fyne.CurrentApp().Driver()
+	for _, h := range handles {
+		if !h.Visible() || d.CanvasForObject(h) == nil {
+			continue
+		}
+		top := d.AbsolutePositionForObject(h).Y
+		if pos.Y >= top && pos.Y < top+h.Size().Height {
+			if t.canDrop(t.dragSource, h.id) {
+				return h
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// showDragHandle records that the handle of a reused tree node now shows the node with the ID,
+// so that only the handles of shown nodes are remembered
+func (t *FileTree) showDragHandle(h *fileTreeDragHandle, id widget.TreeNodeID) {
+	t.listLock.Lock()
+	defer t.listLock.Unlock()
+	if t.dragHandles[h.id] == h {
+		delete(t.dragHandles, h.id)
+	}
+	h.id = id
+	if !isLoadingNode(id) {
+		t.dragHandles[id] = h
+	}
+}
+
+// parentID returns the ID of the loaded directory that contains the node
+func (t *FileTree) parentID(id widget.TreeNodeID) (widget.TreeNodeID, bool) {
+	t.listLock.RLock()
+	defer t.listLock.RUnlock()
+	for parent, children := range t.listCache {
+		for _, child := range children {
+			if child == id {
+				return parent, true
+			}
+		}
+	}
+	return "", false
+}
+
+// copyModifierPressed returns true if the shortcut modifier is held, requesting a copy rather than a move
+func copyModifierPressed() bool {
+	if d, ok := fyne.CurrentApp().Driver().(desktop.Driver); ok {
+		return d.CurrentKeyModifiers()&fyne.KeyModifierShortcutDefault != 0
+	}
+	return false
+}
+
+// fileTreeDragHandle covers the name of a tree node so that it can be dragged.
+// It also highlights the node while it is the target of a drop.
+type fileTreeDragHandle struct {
+	widget.BaseWidget
+
+	id        widget.TreeNodeID
+	tree      *FileTree
+	highlight *canvas.Rectangle
+}
+
+func newFileTreeDragHandle(tree *FileTree) *fileTreeDragHandle {
+	h := &fileTreeDragHandle{tree: tree, highlight: canvas.NewRectangle(theme.HoverColor())}
+	h.highlight.Hide()
+	h.ExtendBaseWidget(h)
+	return h
+}
+
+func (h *fileTreeDragHandle) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(h.highlight)
+}
+
+func (h *fileTreeDragHandle) Dragged(event *fyne.DragEvent) {
+	t := h.tree
+	if t.onDrop == nil {
+		return
+	}
+	c := fyne.CurrentApp().Driver().CanvasForObject(h)
+	if c == nil {
+		return
+	}
+
+	if t.dragSource == "" {
+		t.dragSource = h.id
+		name := h.id
+		if uri, err := t.toURI(h.id); err == nil {
+			name = uri.Name()
+		}
+		t.dragPreview = widget.NewPopUp(widget.NewLabel(name), c)
+	}
+
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(h).Add(event.Position)
+	t.dragPreview.ShowAtPosition(pos.AddXY(theme.Padding(), theme.Padding()))
+
+	target := t.dropTargetAt(pos)
+	if target != t.dropTarget {
+		if t.dropTarget != nil {
+			t.dropTarget.setHighlighted(false)
+		}
+		if target != nil {
+			target.setHighlighted(true)
+		}
+		t.dropTarget = target
+	}
+}
+
+func (h *fileTreeDragHandle) DragEnd() {
+	t := h.tree
+	if t.dragPreview != nil {
+		t.dragPreview.Hide()
+		t.dragPreview = nil
+	}
+	src, target := t.dragSource, t.dropTarget
+	t.dragSource, t.dropTarget = "", nil
+	if target == nil {
+		return
+	}
+
+	target.setHighlighted(false)
+	t.drop(src, target.id, copyModifierPressed())
+}
+
+func (h *fileTreeDragHandle) setHighlighted(highlighted bool) {
+	h.highlight.FillColor = theme.HoverColor()
+	if highlighted {
+		h.highlight.Show()
+	} else {
+		h.highlight.Hide()
+	}
+	h.highlight.Refresh()
+}
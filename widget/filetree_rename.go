@@ -0,0 +1,251 @@
+package widget
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SetOnRename sets the function called when a file or directory is renamed in the tree.
+// Double tapping the name of a node, or pressing F2 after tapping it, turns the name into an entry.
+// Pressing Enter calls the function with the new name to perform the rename and Escape cancels editing.
+// If the function returns an error the old name is restored and the error is shown below the node.
+// Renaming is only enabled while a function is set, otherwise the names of nodes do not handle double taps.
+func (t *FileTree) SetOnRename(onRename func(uri fyne.URI, newName string) error) {
+	t.onRename = onRename
+	t.Refresh()
+}
+
+// TypedKey starts renaming the node that was last tapped when F2 is pressed, if renaming is enabled.
+// Other keys are handled by the tree.
+//
+// Implements: fyne.Focusable
+func (t *FileTree) TypedKey(event *fyne.KeyEvent) {
+	if event.Name == fyne.KeyF2 && t.onRename != nil && t.renameCandidate != "" {
+		t.startRename(t.renameCandidate)
+		return
+	}
+	t.Tree.TypedKey(event)
+}
+
+// startRename shows the entry for editing the name of the node
+func (t *FileTree) startRename(id widget.TreeNodeID) {
+	if t.onRename == nil || isLoadingNode(id) || t.isRoot(id) {
+		return
+	}
+	previous := t.renaming
+	t.renaming = id
+	if previous != "" {
+		t.RefreshItem(previous)
+	}
+	t.RefreshItem(id)
+
+	// the entry is focused once the node has been updated, as the tree cannot be refreshed while it is updating
+	h := t.renameHandle(id)
+	if h == nil || !h.entry.Visible() {
+		return
+	}
+	if c := fyne.CurrentApp().Driver().CanvasForObject(h); c != nil {
+		c.Focus(h.entry)
+		h.entry.TypedShortcut(&fyne.ShortcutSelectAll{})
+	}
+}
+
+// cancelRename hides the entry, leaving the name unchanged
+func (t *FileTree) cancelRename() {
+	id := t.renaming
+	if id == "" {
+		return
+	}
+	t.renaming = ""
+	t.RefreshItem(id)
+}
+
+// commitRename asks the app to rename the node being edited, reloading its directory if it succeeds
+func (t *FileTree) commitRename(name string) {
+	id := t.renaming
+	if id == "" {
+		return
+	}
+	t.renaming = ""
+	uri, err := t.toURI(id)
+	name = strings.TrimSpace(name)
+	if err != nil || name == "" || name == uri.Name() || t.onRename == nil {
+		t.RefreshItem(id)
+		return
+	}
+
+	if err := t.onRename(uri, name); err != nil {
+		fyne.LogError("Unable to rename "+id+" to "+name, err)
+		t.RefreshItem(id)
+		t.showRenameError(id, err)
+		return
+	}
+
+	parent, hasParent := t.parentID(id)
+	t.listLock.Lock()
+	if hasParent {
+		delete(t.listCache, parent)
+	}
+	delete(t.listCache, id)
+	delete(t.sizeCache, id)
+	t.listLock.Unlock()
+	t.uriLock.Lock()
+	delete(t.uriCache, id)
+	delete(t.listableCache, id)
+	t.uriLock.Unlock()
+	t.Refresh()
+
+	if parentURI, err := storage.Parent(uri); err == nil {
+		if renamed, err := storage.Child(parentURI, name); err == nil {
+			t.renameCandidate = renamed.String()
+			t.Select(renamed.String())
+		}
+	}
+}
+
+// showRenameError shows the reason a rename failed below the node
+func (t *FileTree) showRenameError(id widget.TreeNodeID, err error) {
+	d := fyne.CurrentApp().Driver()
+	c := d.CanvasForObject(t)
+	if c == nil {
+		return
+	}
+	pos := d.AbsolutePositionForObject(t)
+	if h := t.renameHandle(id); h != nil && h.Visible() && d.CanvasForObject(h) != nil {
+		pos = d.AbsolutePositionForObject(h).AddXY(0, h.Size().Height)
+	}
+
+	message := widget.NewLabel(err.Error())
+	message.Importance = widget.DangerImportance
+	t.renameError = widget.NewPopUp(message, c)
+	t.renameError.ShowAtPosition(pos)
+}
+
+// renameHandle returns the handle of the shown node with the ID, or nil if it is not shown
+func (t *FileTree) renameHandle(id widget.TreeNodeID) *fileTreeRenameHandle {
+	t.listLock.RLock()
+	defer t.listLock.RUnlock()
+	return t.renameHandles[id]
+}
+
+// nodeRenameHandle returns the rename handle of a tree node, adding one while renaming is enabled and removing it
+// otherwise, so that the name of a node is only a double tap target when it can be renamed
+func (t *FileTree) nodeRenameHandle(node *fyne.Container) *fileTreeRenameHandle {
+	var h *fileTreeRenameHandle
+	if len(node.Objects) > 4 {
+		h = node.Objects[4].(*fileTreeRenameHandle)
+	}
+	if t.onRename == nil {
+		if h != nil {
+			t.listLock.Lock()
+			if t.renameHandles[h.id] == h {
+				delete(t.renameHandles, h.id)
+			}
+			t.listLock.Unlock()
+			node.Remove(h)
+		}
+		return nil
+	}
+	if h == nil {
+		h = newFileTreeRenameHandle(t)
+		node.Add(h)
+	}
+	return h
+}
+
+// showRenameHandle records that the handle of a reused tree node now shows the node with the ID,
+// so that only the handles of shown nodes are remembered
+func (t *FileTree) showRenameHandle(h *fileTreeRenameHandle, id widget.TreeNodeID) {
+	t.listLock.Lock()
+	defer t.listLock.Unlock()
+	if t.renameHandles[h.id] == h {
+		delete(t.renameHandles, h.id)
+	}
+	h.id = id
+	if !isLoadingNode(id) {
+		t.renameHandles[id] = h
+	}
+}
+
+// fileTreeRenameHandle covers the name of a tree node so that it can be double tapped to rename it.
+// While the node is being renamed it shows an entry in place of the name.
+type fileTreeRenameHandle struct {
+	widget.BaseWidget
+
+	id    widget.TreeNodeID
+	tree  *FileTree
+	entry *fileTreeRenameEntry
+}
+
+func newFileTreeRenameHandle(tree *FileTree) *fileTreeRenameHandle {
+	h := &fileTreeRenameHandle{tree: tree}
+	h.entry = &fileTreeRenameEntry{handle: h}
+	h.entry.ExtendBaseWidget(h.entry)
+	h.entry.Hide()
+	h.ExtendBaseWidget(h)
+	return h
+}
+
+func (h *fileTreeRenameHandle) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(h.entry)
+}
+
+// Tapped selects the node, as tapping the name would if it were not covered
+func (h *fileTreeRenameHandle) Tapped(*fyne.PointEvent) {
+	t := h.tree
+	t.renameCandidate = h.id
+	t.Select(h.id)
+	if c := fyne.CurrentApp().Driver().CanvasForObject(t); c != nil && !fyne.CurrentDevice().IsMobile() {
+		c.Focus(t)
+	}
+}
+
+// DoubleTapped starts renaming the node
+func (h *fileTreeRenameHandle) DoubleTapped(*fyne.PointEvent) {
+	h.tree.renameCandidate = h.id
+	h.tree.startRename(h.id)
+}
+
+// setEditing shows the entry with the name while the node is being renamed, and hides it otherwise
+func (h *fileTreeRenameHandle) setEditing(editing bool, name string) {
+	if !editing {
+		if h.entry.Visible() {
+			h.entry.Hide()
+			h.Refresh()
+		}
+		return
+	}
+	if h.entry.Visible() {
+		return
+	}
+	h.entry.SetText(name)
+	h.entry.Show()
+	h.Refresh()
+}
+
+// fileTreeRenameEntry edits the name of a node, committing it when Enter is pressed and cancelling on Escape
+type fileTreeRenameEntry struct {
+	widget.Entry
+	handle *fileTreeRenameHandle
+}
+
+func (e *fileTreeRenameEntry) FocusLost() {
+	e.Entry.FocusLost()
+	if e.handle.tree.renaming == e.handle.id {
+		e.handle.tree.cancelRename()
+	}
+}
+
+func (e *fileTreeRenameEntry) TypedKey(event *fyne.KeyEvent) {
+	switch event.Name {
+	case fyne.KeyEscape:
+		e.handle.tree.cancelRename()
+	case fyne.KeyReturn, fyne.KeyEnter:
+		e.handle.tree.commitRename(e.Text)
+	default:
+		e.Entry.TypedKey(event)
+	}
+}
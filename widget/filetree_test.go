@@ -5,12 +5,15 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/stretchr/testify/assert"
@@ -78,6 +81,51 @@ func TestFileTree_filter(t *testing.T) {
 	assert.Equal(t, expected, tree.filter(given))
 }
 
+func TestFileTree_SetExtensionFilter(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+
+	branch1, err := storage.Child(root, "A")
+	assert.NoError(t, err)
+	branch2, err := storage.Child(root, "B")
+	assert.NoError(t, err)
+	leaf1, err := storage.Child(branch2, "C.txt")
+	assert.NoError(t, err)
+	leaf2, err := storage.Child(branch2, "D.md")
+	assert.NoError(t, err)
+
+	given := []fyne.URI{
+		branch1,
+		branch2,
+		leaf1,
+		leaf2,
+	}
+
+	// directories are only shown while they contain a matching file, however deep
+	nested := filepath.Join(tempDir, "E", "F")
+	assert.NoError(t, os.MkdirAll(nested, os.ModePerm))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tempDir, "A", "G"), os.ModePerm))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(nested, "H.txt"), []byte("h"), os.ModePerm))
+	branch3, err := storage.Child(root, "E")
+	assert.NoError(t, err)
+	given = append(given, branch3)
+
+	tree.SetExtensionFilter([]string{".txt"})
+	assert.Equal(t, []fyne.URI{branch2, leaf1, branch3}, tree.filter(given))
+
+	tree.SetFilter(func(fyne.URI) bool {
+		return false
+	})
+	assert.Empty(t, tree.filter(given))
+
+	tree.SetFilter(nil)
+	assert.Equal(t, given, tree.filter(given))
+}
+
 func TestFileTree_ShowRootPath(t *testing.T) {
 	testPath, _ := filepath.Abs("./testdata")
 	root, err := storage.ParseURI("file://" + testPath)
@@ -101,6 +149,40 @@ func TestFileTree_ShowRootPath(t *testing.T) {
 	assert.Equal(t, "file://", firstNodeContent().Text[:7])
 }
 
+func TestFileTree_ShowSize(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	tree.SetShowSize(true)
+
+	branch, err := storage.Child(root, "B")
+	assert.NoError(t, err)
+	leaf, err := storage.Child(branch, "C.txt")
+	assert.NoError(t, err)
+
+	node := tree.CreateNode(false).(*fyne.Container)
+	tree.UpdateNode(leaf.String(), false, node)
+	assert.Equal(t, "C.txt", node.Objects[0].(*widget.Label).Text)
+	assert.Equal(t, "1 B", node.Objects[2].(*widget.Label).Text)
+	assert.True(t, node.Objects[2].Visible())
+
+	tree.SetShowIcons(false)
+	tree.SetShowSize(false)
+	tree.UpdateNode(leaf.String(), false, node)
+	assert.False(t, node.Objects[1].(*fyne.Container).Objects[1].Visible())
+	assert.False(t, node.Objects[2].Visible())
+}
+
+func TestFileTree_formatFileSize(t *testing.T) {
+	assert.Equal(t, "0 B", formatFileSize(0))
+	assert.Equal(t, "1023 B", formatFileSize(1023))
+	assert.Equal(t, "1.0 KB", formatFileSize(1024))
+	assert.Equal(t, "1.5 MB", formatFileSize(1536*1024))
+}
+
 func TestFileTree_sort(t *testing.T) {
 	tempDir := createTempDir(t)
 	defer os.RemoveAll(tempDir)
@@ -138,6 +220,123 @@ func TestFileTree_sort(t *testing.T) {
 	assert.Equal(t, expected, tree.sort(given))
 }
 
+func TestFileTree_MultiSelect(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	tree.SetMultiSelect(true)
+
+	branch, err := storage.Child(root, "B")
+	assert.NoError(t, err)
+	leaf1, err := storage.Child(branch, "C.txt")
+	assert.NoError(t, err)
+	leaf2, err := storage.Child(branch, "D.txt")
+	assert.NoError(t, err)
+	tree.ChildUIDs(root.String())
+	tree.ChildUIDs(branch.String())
+
+	tree.toggleChecked(leaf1.String())
+	assert.Equal(t, []fyne.URI{leaf1}, tree.SelectedURIs())
+	assert.Equal(t, checkPartial, tree.checkState(branch.String()))
+
+	tree.toggleChecked(leaf2.String())
+	assert.Equal(t, checkAll, tree.checkState(branch.String()))
+	assert.Equal(t, []fyne.URI{branch, leaf1, leaf2}, tree.SelectedURIs())
+
+	tree.toggleChecked(branch.String())
+	assert.Equal(t, checkNone, tree.checkState(branch.String()))
+	assert.Empty(t, tree.SelectedURIs())
+
+	tree.SetSelectDescendants(false)
+	tree.toggleChecked(branch.String())
+	assert.Equal(t, []fyne.URI{branch}, tree.SelectedURIs())
+}
+
+func TestFileTree_SetAutoRefresh(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	tree.SetAutoRefresh(true)
+	defer tree.SetAutoRefresh(false)
+	assert.Len(t, tree.ChildUIDs(root.String()), 2)
+
+	err = ioutil.WriteFile(path.Join(tempDir, "E.txt"), []byte("e"), os.ModePerm)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		ids, _ := tree.cachedChildren(root.String())
+		return len(ids) == 3
+	}, 2*time.Second, 10*time.Millisecond)
+
+	err = os.Remove(path.Join(tempDir, "E.txt"))
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		ids, _ := tree.cachedChildren(root.String())
+		return len(ids) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestFileTree_SetSortComparator(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	tree.Sorter = func(u1, u2 fyne.URI) bool {
+		return u1.String() < u2.String()
+	}
+	tree.SetSortComparator(func(u1, u2 fyne.URI) int {
+		return strings.Compare(u2.Name(), u1.Name()) // Reverse alphabetical, overrides Sorter
+	})
+
+	branch, err := storage.Child(root, "B")
+	assert.NoError(t, err)
+	leaf1, err := storage.Child(branch, "C.txt")
+	assert.NoError(t, err)
+	leaf2, err := storage.Child(branch, "D.txt")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{leaf2.String(), leaf1.String()}, tree.ChildUIDs(branch.String()))
+}
+
+func TestFileTree_AsyncLoading(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	tree.SetAsyncLoading(true)
+
+	branch, err := storage.Child(root, "B")
+	assert.NoError(t, err)
+	leaf1, err := storage.Child(branch, "C.txt")
+	assert.NoError(t, err)
+	leaf2, err := storage.Child(branch, "D.txt")
+	assert.NoError(t, err)
+
+	ids := tree.ChildUIDs(branch.String())
+	if len(ids) == 1 && isLoadingNode(ids[0]) {
+		assert.False(t, tree.IsBranch(ids[0]))
+		node := tree.CreateNode(false).(*fyne.Container)
+		tree.UpdateNode(ids[0], false, node)
+		assert.Equal(t, "Loading…", node.Objects[0].(*widget.Label).Text)
+	}
+
+	assert.Eventually(t, func() bool {
+		ids, _ := tree.cachedChildren(branch.String())
+		return len(ids) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []string{leaf1.String(), leaf2.String()}, tree.ChildUIDs(branch.String()))
+	tree.loads.Wait()
+}
+
 func Test_NewFileTree(t *testing.T) {
 	test.NewApp()
 
@@ -175,3 +374,292 @@ func createTempDir(t *testing.T) string {
 	assert.NoError(t, err)
 	return tempDir
 }
+
+func TestFileTree_SetOnDrop(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branchB, "C.txt")
+	tree.ChildUIDs(root.String())
+	tree.ChildUIDs(branchA.String())
+	tree.ChildUIDs(branchB.String())
+
+	assert.False(t, tree.drop(leaf.String(), branchA.String(), false))
+
+	var dropped []string
+	fail := false
+	tree.SetOnDrop(func(src, destDir fyne.URI, copy bool) error {
+		dropped = append(dropped, src.Name()+">"+destDir.Name())
+		if fail {
+			return os.ErrPermission
+		}
+		dest, _ := storage.Child(destDir, src.Name())
+		return os.Rename(src.Path(), dest.Path())
+	})
+	assert.False(t, tree.drop(branchB.String(), branchB.String(), false))
+	assert.False(t, tree.drop(branchB.String(), leaf.String(), false))
+	assert.False(t, tree.drop(leaf.String(), branchB.String(), false))
+	assert.Nil(t, dropped)
+
+	fail = true
+	assert.False(t, tree.drop(leaf.String(), branchA.String(), false))
+	assert.Empty(t, tree.ChildUIDs(branchA.String()))
+
+	fail = false
+	assert.True(t, tree.drop(leaf.String(), branchA.String(), false))
+	assert.Equal(t, []string{"C.txt>A", "C.txt>A"}, dropped)
+	moved, _ := storage.Child(branchA, "C.txt")
+	assert.Equal(t, []string{moved.String()}, tree.ChildUIDs(branchA.String()))
+	assert.Len(t, tree.ChildUIDs(branchB.String()), 1)
+}
+
+func TestFileTree_DragHandles(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branchB, "C.txt")
+	tree := NewFileTree(root)
+	tree.SetOnDrop(func(src, destDir fyne.URI, copy bool) error {
+		return nil
+	})
+	w := test.NewWindow(tree)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 400))
+	tree.OpenBranch(branchB.String())
+
+	// the handle of each shown node can be found as a drop target
+	target := tree.dragHandles[branchA.String()]
+	if !assert.NotNil(t, target) {
+		return
+	}
+	assert.Equal(t, branchA.String(), target.id)
+	assert.Contains(t, tree.dragHandles, leaf.String())
+	tree.dragSource = leaf.String()
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(target).AddXY(1, 1)
+	assert.Equal(t, target, tree.dropTargetAt(pos))
+	tree.dragSource = ""
+
+	// the handles of hidden nodes are forgotten
+	tree.CloseBranch(branchB.String())
+	assert.NotContains(t, tree.dragHandles, leaf.String())
+	for id, h := range tree.dragHandles {
+		assert.Equal(t, id, h.id)
+	}
+}
+
+func TestFileTree_SetOnRename(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	branchB, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branchB, "C.txt")
+	fail := false
+	tree.SetOnRename(func(uri fyne.URI, newName string) error {
+		if fail {
+			return os.ErrPermission
+		}
+		dest, _ := storage.Child(branchB, newName)
+		return os.Rename(uri.Path(), dest.Path())
+	})
+	w := test.NewWindow(tree)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 400))
+	tree.OpenBranch(branchB.String())
+
+	h := tree.renameHandle(leaf.String())
+	if !assert.NotNil(t, h) {
+		return
+	}
+	assert.True(t, h.Visible())
+
+	// escape cancels editing
+	test.DoubleTap(h)
+	assert.Equal(t, leaf.String(), tree.renaming)
+	assert.True(t, h.entry.Visible())
+	assert.Equal(t, "C.txt", h.entry.Text)
+	h.entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	assert.Empty(t, tree.renaming)
+	assert.False(t, h.entry.Visible())
+
+	// an error restores the name and is shown
+	fail = true
+	test.Tap(h)
+	tree.TypedKey(&fyne.KeyEvent{Name: fyne.KeyF2})
+	assert.True(t, h.entry.Visible())
+	h.entry.SetText("E.txt")
+	h.entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.False(t, h.entry.Visible())
+	assert.FileExists(t, leaf.Path())
+	if assert.NotNil(t, tree.renameError) {
+		assert.True(t, tree.renameError.Visible())
+	}
+
+	fail = false
+	test.DoubleTap(h)
+	h.entry.SetText("E.txt")
+	h.entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	renamed, _ := storage.Child(branchB, "E.txt")
+	assert.FileExists(t, renamed.Path())
+	assert.Contains(t, tree.ChildUIDs(branchB.String()), renamed.String())
+	assert.NotContains(t, tree.ChildUIDs(branchB.String()), leaf.String())
+
+	// the handles of hidden nodes are forgotten
+	tree.CloseBranch(branchB.String())
+	assert.Nil(t, tree.renameHandle(renamed.String()))
+
+	// without a function the nodes have no double tap target
+	tree.OpenBranch(branchB.String())
+	assert.NotNil(t, tree.renameHandle(renamed.String()))
+	tree.SetOnRename(nil)
+	assert.Nil(t, tree.renameHandle(renamed.String()))
+	assert.Empty(t, tree.renameHandles)
+	for _, o := range test.LaidOutObjects(tree) {
+		_, ok := o.(*fileTreeRenameHandle)
+		assert.False(t, ok)
+	}
+}
+
+func TestFileTree_ExpandAll(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+
+	tree := NewFileTree(root)
+	tree.ExpandAll()
+	assert.True(t, tree.IsBranchOpen(root.String()))
+	assert.True(t, tree.IsBranchOpen(branchA.String()))
+	assert.True(t, tree.IsBranchOpen(branchB.String()))
+
+	tree.CollapseAll()
+	assert.False(t, tree.IsBranchOpen(branchA.String()))
+	assert.False(t, tree.IsBranchOpen(branchB.String()))
+
+	// with async loading each level is opened once the directories containing it have been read
+	tree = NewFileTree(root)
+	tree.SetAsyncLoading(true)
+	tree.ExpandAll()
+	assert.Eventually(t, func() bool {
+		return tree.IsBranchOpen(branchA.String()) && tree.IsBranchOpen(branchB.String())
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		tree.listLock.RLock()
+		defer tree.listLock.RUnlock()
+		return !tree.expanding
+	}, time.Second, 10*time.Millisecond)
+	tree.loads.Wait()
+}
+
+func TestFileTree_SelectPath(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	branch, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branch, "D.txt")
+
+	for _, async := range []bool{false, true} {
+		tree := NewFileTree(root)
+		selected := make(chan widget.TreeNodeID, 1)
+		tree.OnSelected = func(id widget.TreeNodeID) {
+			selected <- id
+		}
+		// the window is shown before loading asynchronously, so that only SelectPath reads in the background
+		w := test.NewWindow(tree)
+		w.Resize(fyne.NewSize(200, 100))
+		tree.SetAsyncLoading(async)
+
+		tree.SelectPath(leaf)
+		assert.True(t, tree.IsBranchOpen(root.String()))
+		assert.True(t, tree.IsBranchOpen(branch.String()))
+		select {
+		case id := <-selected:
+			assert.Equal(t, leaf.String(), id)
+		case <-time.After(time.Second):
+			t.Errorf("path not selected with async loading %v", async)
+		}
+		tree.loads.Wait()
+		w.Close()
+	}
+
+	outside, _ := storage.ParseURI("file:///outside/the/tree")
+	tree := NewFileTree(root)
+	tree.SelectPath(outside)
+	assert.False(t, tree.IsBranchOpen(branch.String()))
+}
+
+func TestFileTree_SetRoots(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branchB, "D.txt")
+
+	tree := NewFileTree(root)
+	tree.SetRoots([]FileTreeRoot{
+		{Label: "Bookmarks", Icon: theme.HomeIcon(), URI: branchB},
+		{URI: branchA},
+		{Label: "Recent", URI: leaf},
+	})
+	assert.Equal(t, []string{branchB.String(), branchA.String(), leaf.String()}, tree.ChildUIDs(tree.Root))
+	assert.True(t, tree.IsBranch(branchB.String()))
+	assert.False(t, tree.IsBranch(leaf.String()))
+	assert.Len(t, tree.ChildUIDs(branchB.String()), 2)
+
+	node := tree.CreateNode(true).(*fyne.Container)
+	left := node.Objects[1].(*fyne.Container)
+	tree.UpdateNode(branchB.String(), true, node)
+	assert.Equal(t, "Bookmarks", node.Objects[0].(*widget.Label).Text)
+	assert.False(t, left.Objects[1].Visible())
+	assert.True(t, left.Objects[2].Visible())
+	assert.Equal(t, theme.HomeIcon(), left.Objects[2].(*widget.Icon).Resource)
+
+	tree.UpdateNode(branchA.String(), true, node)
+	assert.Equal(t, "A", node.Objects[0].(*widget.Label).Text)
+	assert.True(t, left.Objects[1].Visible())
+	assert.False(t, left.Objects[2].Visible())
+
+	// a path is revealed inside the root that contains it
+	selected := ""
+	tree.OnSelected = func(id widget.TreeNodeID) {
+		selected = id
+	}
+	w := test.NewWindow(tree)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 200))
+	file, _ := storage.Child(branchB, "C.txt")
+	tree.SelectPath(file)
+	assert.True(t, tree.IsBranchOpen(branchB.String()))
+	assert.Equal(t, file.String(), selected)
+}
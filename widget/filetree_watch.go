@@ -0,0 +1,40 @@
+//go:build darwin || dragonfly || freebsd || openbsd || linux || netbsd || solaris || windows
+// +build darwin dragonfly freebsd openbsd linux netbsd solaris windows
+
+package widget
+
+import (
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func (t *FileTree) watchChanges(watcher *fsnotify.Watcher, stop chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+
+			t.watchLock.RLock()
+			dir, ok := t.watched[filepath.Dir(event.Name)]
+			t.watchLock.RUnlock()
+			if ok && t.reload(dir) {
+				t.Refresh()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			fyne.LogError("Error watching file system", err)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !darwin && !dragonfly && !freebsd && !openbsd && !linux && !netbsd && !solaris && !windows
+// +build !darwin,!dragonfly,!freebsd,!openbsd,!linux,!netbsd,!solaris,!windows
+
+package widget
+
+import "github.com/fsnotify/fsnotify"
+
+// watchChanges falls back to polling as file system events are not supported on this platform.
+func (t *FileTree) watchChanges(_ *fsnotify.Watcher, stop chan struct{}) {
+	t.pollChanges(stop)
+}
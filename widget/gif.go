@@ -2,6 +2,7 @@ package widget
 
 import (
 	"bytes"
+	"errors"
 	"image"
 	"image/draw"
 	"image/gif"
@@ -15,17 +16,30 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+var errInvalidSpeed = errors.New("speed must be greater than zero")
+
 // AnimatedGif widget shows a Gif image with many frames.
 type AnimatedGif struct {
 	widget.BaseWidget
 	min fyne.Size
 
 	src               *gif.GIF
+	frames            *animation
 	dst               *canvas.Image
+	buffer            *image.NRGBA
 	noDisposeIndex    int
+	current, next     int
 	remaining         int
+	loopCount         int
+	customLoopCount   bool
 	stopping, running bool
+	paused            bool
+	autoStart         bool
+	speed             float64
+	onFinished        func()
+	onLoop            func(int)
 	runLock           sync.RWMutex
+	pauseCond         *sync.Cond
 }
 
 // NewAnimatedGif creates a new widget loaded to show the specified image.
@@ -44,6 +58,15 @@ func NewAnimatedGifFromResource(r fyne.Resource) (*AnimatedGif, error) {
 	return ret, ret.LoadResource(r)
 }
 
+// NewAnimatedGifFromReader creates a new widget loaded to show the gif image read from r.
+// All frames are decoded before this function returns.
+// If there is an error loading the image it will be returned in the error value.
+func NewAnimatedGifFromReader(r io.Reader) (*AnimatedGif, error) {
+	ret := newGif()
+
+	return ret, ret.LoadReader(r)
+}
+
 // CreateRenderer loads the widget renderer for this widget. This is an internal requirement for Fyne.
 func (g *AnimatedGif) CreateRenderer() fyne.WidgetRenderer {
 	return &gifRenderer{gif: g}
@@ -79,18 +102,46 @@ func (g *AnimatedGif) LoadResource(r fyne.Resource) error {
 	return g.load(bytes.NewReader(r.Content()))
 }
 
+// LoadReader is used to change the gif shown to the image data read from r.
+// It will change the loaded content and prepare the new frames for animation.
+func (g *AnimatedGif) LoadReader(r io.Reader) error {
+	g.dst.Image = nil
+	g.dst.Refresh()
+
+	if r == nil {
+		return nil
+	}
+	return g.load(r)
+}
+
 func (g *AnimatedGif) load(read io.Reader) error {
 	pix, err := gif.DecodeAll(read)
 	if err != nil {
 		return err
 	}
+	g.runLock.Lock()
 	g.src = pix
+	g.frames = nil
+	g.buffer = nil
+	g.current, g.next = 0, 0
+	g.runLock.Unlock()
 	g.dst.Image = pix.Image[0]
 	g.dst.Refresh()
 
 	return nil
 }
 
+func (g *AnimatedGif) loadAnimation(anim *animation) {
+	g.runLock.Lock()
+	g.src = nil
+	g.frames = anim
+	g.buffer = nil
+	g.current, g.next = 0, 0
+	g.runLock.Unlock()
+	g.dst.Image = anim.frames[0]
+	g.dst.Refresh()
+}
+
 // MinSize returns the minimum size that this GIF can occupy.
 // Because gif images are measured in pixels we cannot use the dimensions, so this defaults to 0x0.
 // You can set a minimum size if required using SetMinSize.
@@ -106,6 +157,12 @@ func (g *AnimatedGif) SetMinSize(min fyne.Size) {
 
 func (g *AnimatedGif) draw(dst draw.Image, index int) {
 	defer g.dst.Refresh()
+	if g.frames != nil {
+		// frames of other formats are composed when they are decoded
+		draw.Draw(dst, dst.Bounds(), g.frames.frames[index], image.Point{}, draw.Src)
+		g.dst.Image = dst
+		return
+	}
 	if index == 0 {
 		// first frame
 		draw.Draw(dst, g.dst.Image.Bounds(), g.src.Image[index], image.Point{}, draw.Src)
@@ -139,45 +196,200 @@ func (g *AnimatedGif) draw(dst draw.Image, index int) {
 	}
 }
 
-// Start begins the animation. The speed of the transition is controlled by the loaded gif file.
-func (g *AnimatedGif) Start() {
-	if g.isRunning() {
+// CurrentFrame returns the index of the frame that is currently displayed.
+func (g *AnimatedGif) CurrentFrame() int {
+	g.runLock.RLock()
+	defer g.runLock.RUnlock()
+	return g.current
+}
+
+// Pause halts the animation on the current frame, it can be continued by calling Resume.
+func (g *AnimatedGif) Pause() {
+	g.runLock.Lock()
+	g.paused = true
+	g.runLock.Unlock()
+}
+
+// Resume continues a paused or stopped animation from the current frame.
+func (g *AnimatedGif) Resume() {
+	g.runLock.Lock()
+	g.paused = false
+	running := g.running
+	g.runLock.Unlock()
+	g.pauseCond.Broadcast()
+
+	if !running {
+		g.play(false)
+	}
+}
+
+// Seek displays the frame with the given index, which is clamped to the frames available.
+// If the animation is running it will continue from the new position.
+func (g *AnimatedGif) Seek(frame int) {
+	g.runLock.Lock()
+	defer g.runLock.Unlock()
+	count := g.frameCount()
+	if count == 0 {
 		return
 	}
+
+	if frame < 0 {
+		frame = 0
+	} else if frame >= count {
+		frame = count - 1
+	}
+	buffer := g.frameBuffer()
+	for i := 0; i <= frame; i++ {
+		g.draw(buffer, i)
+	}
+	g.current = frame
+	g.next = (frame + 1) % count
+}
+
+// SetAutoStart determines whether Start plays the animation straight away, which is the default.
+// When it is false, Start shows the first frame as a poster and the animation waits for Resume.
+// Calling Resume when the mouse enters and Pause when it leaves gives a thumbnail that animates on hover.
+func (g *AnimatedGif) SetAutoStart(auto bool) {
 	g.runLock.Lock()
-	g.running = true
+	g.autoStart = auto
+	g.runLock.Unlock()
+}
+
+// SetLoopCount sets how many times the animation plays before it stops, overriding the value in the file.
+// A count of 0 plays the animation forever. The new value is used the next time the animation starts.
+func (g *AnimatedGif) SetLoopCount(n int) {
+	g.runLock.Lock()
+	g.loopCount = n
+	g.customLoopCount = true
 	g.runLock.Unlock()
+}
+
+// SetOnFinished sets a function that is called when the animation has played the configured number of loops.
+// It is not called when the animation is stopped using Stop.
+// Fyne does not yet provide a way to run code on the main goroutine, so the callback runs on the animation goroutine.
+// Any application state it shares with other goroutines must be synchronised. The animation has stopped running
+// when it is called, so Start may be called from it to play the animation again.
+func (g *AnimatedGif) SetOnFinished(f func()) {
+	g.runLock.Lock()
+	g.onFinished = f
+	g.runLock.Unlock()
+}
+
+// SetOnLoop sets a function that is called each time the animation completes a loop.
+// The iteration passed is the number of loops completed since the animation was started.
+// Like the finished callback it runs on the animation goroutine, after the last frame of the loop has been shown,
+// and the next frame is not drawn until it returns.
+func (g *AnimatedGif) SetOnLoop(f func(iteration int)) {
+	g.runLock.Lock()
+	g.onLoop = f
+	g.runLock.Unlock()
+}
+
+// SetSpeed sets how fast the animation plays relative to the delays in the file.
+// A multiplier of 1.0 is the normal speed, 0.5 plays at half speed and 2.0 at double speed.
+// The change applies from the next frame. An error is returned if the multiplier is not positive.
+func (g *AnimatedGif) SetSpeed(multiplier float64) error {
+	if multiplier <= 0 {
+		return errInvalidSpeed
+	}
+
+	g.runLock.Lock()
+	g.speed = multiplier
+	g.runLock.Unlock()
+	return nil
+}
+
+// Start begins the animation from the first frame. The speed of the transition is controlled by the loaded gif file.
+// If auto start has been turned off, the first frame is shown until Resume is called.
+func (g *AnimatedGif) Start() {
+	g.play(true)
+}
+
+func (g *AnimatedGif) play(restart bool) {
+	g.runLock.Lock()
+	if g.running || g.frameCount() == 0 {
+		g.runLock.Unlock()
+		return
+	}
+	g.running = true
+	g.paused = restart && !g.autoStart
 
-	buffer := image.NewNRGBA(g.dst.Image.Bounds())
-	g.draw(buffer, 0)
+	buffer := g.frameBuffer()
+	if restart {
+		g.draw(buffer, 0)
+		g.current, g.next = 0, 0
+	}
+
+	switch {
+	case g.customLoopCount && g.loopCount <= 0:
+		g.remaining = -1
+	case g.customLoopCount:
+		g.remaining = g.loopCount
+	case g.frames != nil && g.frames.loopCount == 0:
+		g.remaining = -1
+	case g.frames != nil:
+		g.remaining = g.frames.loopCount
+	case g.src.LoopCount == -1: // don't loop
+		g.remaining = 1
+	case g.src.LoopCount == 0: // loop forever
+		g.remaining = -1
+	default:
+		g.remaining = g.src.LoopCount + 1
+	}
+	g.runLock.Unlock()
 
 	go func() {
-		switch g.src.LoopCount {
-		case -1: // don't loop
-			g.remaining = 1
-		case 0: // loop forever
-			g.remaining = -1
-		default:
-			g.remaining = g.src.LoopCount + 1
-		}
-	loop:
-		for g.remaining != 0 {
-			for c := range g.src.Image {
-				if g.isStopping() {
-					break loop
+		iteration, finished := 0, false
+		for {
+			g.runLock.Lock()
+			for g.paused && !g.stopping {
+				g.pauseCond.Wait()
+			}
+			if g.stopping {
+				g.runLock.Unlock()
+				break
+			}
+
+			index := g.next
+			g.draw(buffer, index)
+			g.current = index
+			g.next = index + 1
+			looped := false
+			if g.next == g.frameCount() {
+				g.next = 0
+				looped = true
+				if g.remaining > -1 { // don't underflow int
+					g.remaining--
 				}
-				g.draw(buffer, c)
+				finished = g.remaining == 0
+			}
+			onLoop := g.onLoop
+			delay := g.frameDelay(index)
+			if g.speed > 0 {
+				delay = time.Duration(float64(delay) / g.speed)
+			}
+			g.runLock.Unlock()
 
-				time.Sleep(time.Millisecond * time.Duration(g.src.Delay[c]) * 10)
+			time.Sleep(delay)
+			if looped {
+				iteration++
+				if onLoop != nil {
+					onLoop(iteration)
+				}
 			}
-			if g.remaining > -1 { // don't underflow int
-				g.remaining--
+			if finished {
+				break
 			}
 		}
 		g.runLock.Lock()
 		g.running = false
 		g.stopping = false
+		onFinished := g.onFinished
 		g.runLock.Unlock()
+
+		if finished && onFinished != nil {
+			onFinished()
+		}
 	}()
 }
 
@@ -189,6 +401,35 @@ func (g *AnimatedGif) Stop() {
 	g.runLock.Lock()
 	g.stopping = true
 	g.runLock.Unlock()
+	g.pauseCond.Broadcast()
+}
+
+// frameBuffer returns the image that frames are composed in, the caller must hold runLock.
+func (g *AnimatedGif) frameBuffer() *image.NRGBA {
+	if g.buffer == nil {
+		if g.frames != nil {
+			g.buffer = image.NewNRGBA(g.frames.frames[0].Bounds())
+		} else {
+			g.buffer = image.NewNRGBA(g.src.Image[0].Bounds())
+		}
+	}
+	return g.buffer
+}
+
+func (g *AnimatedGif) frameCount() int {
+	if g.frames != nil {
+		return len(g.frames.frames)
+	} else if g.src != nil {
+		return len(g.src.Image)
+	}
+	return 0
+}
+
+func (g *AnimatedGif) frameDelay(index int) time.Duration {
+	if g.frames != nil {
+		return g.frames.delays[index]
+	}
+	return time.Millisecond * time.Duration(g.src.Delay[index]) * 10
 }
 
 func (g *AnimatedGif) isStopping() bool {
@@ -204,7 +445,8 @@ func (g *AnimatedGif) isRunning() bool {
 }
 
 func newGif() *AnimatedGif {
-	ret := &AnimatedGif{}
+	ret := &AnimatedGif{autoStart: true}
+	ret.pauseCond = sync.NewCond(&ret.runLock)
 	ret.ExtendBaseWidget(ret)
 	ret.dst = &canvas.Image{}
 	ret.dst.FillMode = canvas.ImageFillContain
@@ -3,6 +3,7 @@ package widget
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,3 +45,114 @@ func TestAnimatedGif_MinSize(t *testing.T) {
 	assert.Equal(t, float32(10), gif.MinSize().Width)
 	assert.Equal(t, float32(10), gif.MinSize().Height)
 }
+
+func TestAnimatedGif_Seek(t *testing.T) {
+	gif, err := NewAnimatedGif(storage.NewFileURI("./testdata/gif/earth.gif"))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, gif.CurrentFrame())
+
+	gif.Seek(2)
+	assert.Equal(t, 2, gif.CurrentFrame())
+	gif.Seek(-1)
+	assert.Equal(t, 0, gif.CurrentFrame())
+	gif.Seek(1000)
+	assert.Equal(t, len(gif.src.Image)-1, gif.CurrentFrame())
+}
+
+func TestAnimatedGif_PauseResume(t *testing.T) {
+	gif, err := NewAnimatedGif(storage.NewFileURI("./testdata/gif/earth.gif"))
+	assert.Nil(t, err)
+
+	gif.Start()
+	gif.Pause()
+	time.Sleep(time.Millisecond * 10)
+	frame := gif.CurrentFrame()
+	time.Sleep(time.Millisecond * 200)
+	assert.Equal(t, frame, gif.CurrentFrame())
+	assert.True(t, gif.isRunning())
+
+	gif.Resume()
+	time.Sleep(time.Millisecond * 200)
+	assert.NotEqual(t, frame, gif.CurrentFrame())
+
+	gif.Stop()
+	time.Sleep(time.Millisecond * 200)
+	assert.False(t, gif.isRunning())
+}
+
+func TestAnimatedGif_SetAutoStart(t *testing.T) {
+	gif, err := NewAnimatedGif(storage.NewFileURI("./testdata/gif/earth.gif"))
+	assert.Nil(t, err)
+
+	gif.SetAutoStart(false)
+	gif.Seek(3)
+	gif.Start()
+	defer gif.Stop()
+	time.Sleep(time.Millisecond * 200)
+	assert.Equal(t, 0, gif.CurrentFrame())
+	assert.True(t, gif.isRunning())
+
+	gif.Resume()
+	time.Sleep(time.Millisecond * 200)
+	assert.NotEqual(t, 0, gif.CurrentFrame())
+}
+
+func TestAnimatedGif_SetLoopCount(t *testing.T) {
+	gif, err := NewAnimatedGif(storage.NewFileURI("./testdata/gif/earth.gif"))
+	assert.Nil(t, err)
+
+	gif.SetLoopCount(2)
+	gif.Start()
+	defer gif.Stop()
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, 2, gif.remaining)
+}
+
+func TestAnimatedGif_SetSpeed(t *testing.T) {
+	gif, err := NewAnimatedGif(storage.NewFileURI("./testdata/gif/earth.gif"))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, gif.SetSpeed(0))
+	assert.NotNil(t, gif.SetSpeed(-1))
+	assert.Nil(t, gif.SetSpeed(0.5))
+	assert.Equal(t, 0.5, gif.speed)
+}
+
+func TestAnimatedGif_SetOnFinished(t *testing.T) {
+	gif, err := NewAnimatedGif(storage.NewFileURI("./testdata/gif/earth.gif"))
+	assert.Nil(t, err)
+
+	loops := make(chan int, 2)
+	finished := make(chan bool, 1)
+	gif.SetOnLoop(func(i int) {
+		loops <- i
+	})
+	gif.SetOnFinished(func() {
+		finished <- true
+	})
+	gif.SetLoopCount(1)
+	assert.Nil(t, gif.SetSpeed(100))
+	gif.Start()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second * 5):
+		t.Fatal("animation did not finish")
+	}
+	assert.Equal(t, 1, <-loops)
+	assert.False(t, gif.isRunning())
+}
+
+func TestNewAnimatedGifFromReader(t *testing.T) {
+	f, err := os.Open("./testdata/gif/earth.gif")
+	assert.Nil(t, err)
+	defer f.Close()
+
+	gif, err := NewAnimatedGifFromReader(f)
+	assert.Nil(t, err)
+	assert.NotNil(t, gif.src)
+	assert.NotNil(t, gif.dst.Image)
+
+	_, err = NewAnimatedGifFromReader(strings.NewReader("not a gif"))
+	assert.NotNil(t, err)
+}
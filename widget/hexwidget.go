@@ -2,9 +2,14 @@ package widget
 
 import (
 	"image/color"
+	"math"
+	"strconv"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -40,24 +45,47 @@ const defaultHexOffset float32 = 0.1 * defaultHexWidth
 var defaultHexOnColor color.Color = color.RGBA{200, 25, 25, 255}
 var defaultHexOffColor color.Color = color.RGBA{25, 15, 15, 64}
 
+// hexCursorHeight is the height of the cursor shown below the digit being edited
+const hexCursorHeight float32 = 3
+
+// maxHexDigits is the number of digits needed to show a 64-bit value
+const maxHexDigits = 16
+
 type hexRenderer struct {
 	hex            *HexWidget
 	segmentObjects []fyne.CanvasObject
+	cursor         *canvas.Rectangle
+	objects        []fyne.CanvasObject
 }
 
 func (h *hexRenderer) MinSize() fyne.Size {
+	height := h.hex.size.Height
+	if h.hex.editable {
+		height += hexCursorHeight * 2
+	}
+
 	return fyne.NewSize(
-		h.hex.size.Width+h.hex.hexOffset,
-		h.hex.size.Height,
+		h.hex.digitX(h.hex.digitCount()-1)+h.hex.digitWidth(),
+		height,
 	)
 }
 
-func (h *hexRenderer) Layout(_ fyne.Size) {
+func (h *hexRenderer) Layout(size fyne.Size) {
+	h.hex.input.Resize(size)
+	for i := 0; i < h.hex.digitCount(); i++ {
+		h.layoutDigit(h.segmentObjects[i*7:(i+1)*7], h.hex.digitX(i))
+	}
+
+	h.cursor.Resize(fyne.NewSize(h.hex.size.Width, hexCursorHeight))
+	h.cursor.Move(fyne.NewPos(h.hex.digitX(h.hex.cursor), h.hex.size.Height+hexCursorHeight))
+}
+
+func (h *hexRenderer) layoutDigit(segments []fyne.CanvasObject, x float32) {
 	hexSegmentWidth := 0.2 * h.hex.size.Width
 
 	hexSegmentVLength := (h.hex.size.Height - hexSegmentWidth) / 2
 	hexSegmentHLength := h.hex.size.Width - hexSegmentWidth
-	pos := fyne.NewPos(h.hex.hexOffset, hexSegmentWidth/2)
+	pos := fyne.NewPos(x+h.hex.hexOffset, hexSegmentWidth/2)
 
 	pt0Center := fyne.NewPos(pos.X+h.hex.size.Width/2.0+h.hex.hexOffset, pos.Y)
 	pt05 := fyne.NewPos(float32(pt0Center.X)-(hexSegmentHLength/2), pt0Center.Y)
@@ -71,37 +99,52 @@ func (h *hexRenderer) Layout(_ fyne.Size) {
 	pt34 := fyne.NewPos(float32(pt3Center.X)-(hexSegmentHLength/2), pt3Center.Y)
 	pt32 := fyne.NewPos(float32(pt3Center.X)+(hexSegmentHLength/2), pt3Center.Y)
 
-	setLineEndpoints(h.segmentObjects[0].(*canvas.Line), pt05, pt01)
-	setLineEndpoints(h.segmentObjects[1].(*canvas.Line), pt01, pt61)
-	setLineEndpoints(h.segmentObjects[2].(*canvas.Line), pt61, pt32)
-	setLineEndpoints(h.segmentObjects[3].(*canvas.Line), pt32, pt34)
-	setLineEndpoints(h.segmentObjects[4].(*canvas.Line), pt34, pt65)
-	setLineEndpoints(h.segmentObjects[5].(*canvas.Line), pt65, pt05)
-	setLineEndpoints(h.segmentObjects[6].(*canvas.Line), pt65, pt61)
+	setLineEndpoints(segments[0].(*canvas.Line), pt05, pt01)
+	setLineEndpoints(segments[1].(*canvas.Line), pt01, pt61)
+	setLineEndpoints(segments[2].(*canvas.Line), pt61, pt32)
+	setLineEndpoints(segments[3].(*canvas.Line), pt32, pt34)
+	setLineEndpoints(segments[4].(*canvas.Line), pt34, pt65)
+	setLineEndpoints(segments[5].(*canvas.Line), pt65, pt05)
+	setLineEndpoints(segments[6].(*canvas.Line), pt65, pt61)
 }
 
 func (h *hexRenderer) Refresh() {
-	hexSegmentWidth := 0.2 * h.hex.size.Width
-
-	h.segmentObjects[0].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[1].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[2].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[3].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[4].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[5].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[6].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
+	if len(h.segmentObjects) != h.hex.digitCount()*7 {
+		h.createSegments()
+	}
 
+	hexSegmentWidth := 0.2 * h.hex.size.Width
 	for i, v := range h.segmentObjects {
-		v.(*canvas.Line).StrokeColor = h.hex.getSegmentColor(i)
+		v.(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
+		v.(*canvas.Line).StrokeColor = h.hex.getSegmentColor(i/7, i%7)
 		canvas.Refresh(v)
 	}
+
+	h.cursor.FillColor = theme.PrimaryColor()
+	if h.hex.editable && h.hex.focused {
+		h.cursor.Show()
+	} else {
+		h.cursor.Hide()
+	}
+	h.Layout(h.hex.Size())
+	canvas.Refresh(h.cursor)
 }
 
 func (h *hexRenderer) Destroy() {
 }
 
 func (h *hexRenderer) Objects() []fyne.CanvasObject {
-	return h.segmentObjects
+	return h.objects
+}
+
+func (h *hexRenderer) createSegments() {
+	h.segmentObjects = make([]fyne.CanvasObject, h.hex.digitCount()*7)
+	for i := range h.segmentObjects {
+		h.segmentObjects[i] = canvas.NewLine(h.hex.hexOffColor)
+	}
+
+	// the input is added last so that it is over the digits
+	h.objects = append(append([]fyne.CanvasObject{}, h.segmentObjects...), h.cursor, h.hex.input)
 }
 
 // HexWidget represents a 7-segment hexadecimal display. The segments
@@ -118,9 +161,32 @@ func (h *hexRenderer) Objects() []fyne.CanvasObject {
 //	4 |     | 2
 //	  |  3  |
 //	   -----
+//
+// When editable mode is turned on using SetEditable, a cursor can be moved between the
+// digits (nibbles) using the arrow keys and typing a hexadecimal digit replaces the
+// value under the cursor. A widget that is not editable does not take the focus or taps.
 type HexWidget struct {
 	widget.BaseWidget
-	segments uint8
+
+	// OnChanged is called with the new value when it is edited by the user.
+	OnChanged func(value uint64)
+
+	// valueLock guards segments, value and binder, which are used by a binding's listener on its own goroutine
+	valueLock sync.RWMutex
+
+	// segment state for each digit, the most significant digit first
+	segments []uint8
+
+	// value currently displayed, if it was set using Set or edited
+	value uint64
+
+	editable, focused bool
+	cursor            int
+	// input takes the focus and the taps while the widget is editable
+	input *hexInput
+
+	// number of digits between separators, 0 for no grouping
+	grouping int
 
 	// size of the hex widget
 	size fyne.Size
@@ -133,6 +199,10 @@ type HexWidget struct {
 
 	// color when the hex is off
 	hexOffColor color.Color
+
+	// bound value, if Bind was called, and the listener following it
+	binder       binding.Int
+	bindListener binding.DataListener
 }
 
 // SetOnColor changes the color that segments are shown as when they are
@@ -164,8 +234,180 @@ func (h *HexWidget) SetSlant(s float32) {
 	h.Refresh()
 }
 
-func (h *HexWidget) getSegmentColor(segno int) color.Color {
-	if (h.segments & (1 << uint(segno))) == 0 {
+// SetDigits changes the number of hexadecimal digits shown, between 1 and 16.
+// The current value is kept, dropping any digits that no longer fit.
+func (h *HexWidget) SetDigits(n int) {
+	if n < 1 {
+		n = 1
+	} else if n > maxHexDigits {
+		n = maxHexDigits
+	}
+
+	h.valueLock.Lock()
+	h.segments = make([]uint8, n)
+	h.setValue(h.value)
+	h.valueLock.Unlock()
+	if h.cursor >= n {
+		h.cursor = n - 1
+	}
+	h.Refresh()
+}
+
+// SetGrouping inserts a gap between every n digits, counting from the least significant digit,
+// so that 8 digits grouped by 4 are shown like "DEAD BEEF". Passing 0 turns grouping off.
+func (h *HexWidget) SetGrouping(n int) {
+	if n < 0 {
+		n = 0
+	}
+	h.grouping = n
+	h.Refresh()
+}
+
+// SetEditable sets whether the user can change the displayed value using the keyboard.
+func (h *HexWidget) SetEditable(editable bool) {
+	h.editable = editable
+	if editable {
+		h.input.Show()
+	} else {
+		h.input.Hide()
+		if c := fyne.CurrentApp().Driver().CanvasForObject(h); c != nil && c.Focused() == h.input {
+			c.Unfocus()
+		}
+	}
+	h.Refresh()
+}
+
+// Value returns the number currently displayed, as last set using Set or edited by the user.
+func (h *HexWidget) Value() uint64 {
+	h.valueLock.RLock()
+	defer h.valueLock.RUnlock()
+	return h.value
+}
+
+// focusChanged shows the cursor while the editable widget has the focus
+func (h *HexWidget) focusChanged(focused bool) {
+	h.focused = focused && h.editable
+	h.Refresh()
+}
+
+// tapped moves the cursor to the digit that was tapped and requests focus
+func (h *HexWidget) tapped(ev *fyne.PointEvent) {
+	if !h.editable {
+		return
+	}
+
+	h.cursor = h.digitAt(ev.Position.X)
+	if c := fyne.CurrentApp().Driver().CanvasForObject(h); c != nil {
+		c.Focus(h.input)
+	}
+	h.Refresh()
+}
+
+// typedRune replaces the digit under the cursor if a hexadecimal digit was typed
+func (h *HexWidget) typedRune(r rune) {
+	if !h.editable {
+		return
+	}
+
+	nibble, err := strconv.ParseUint(string(r), 16, 8)
+	if err != nil {
+		return
+	}
+
+	h.setNibble(h.cursor, nibble)
+	if h.cursor < h.digitCount()-1 {
+		h.cursor++
+	}
+	h.Refresh()
+}
+
+// typedKey moves the cursor using the arrow, home and end keys.
+// Backspace clears the digit under the cursor and moves left, Delete clears the digit under the cursor.
+func (h *HexWidget) typedKey(ev *fyne.KeyEvent) {
+	if !h.editable {
+		return
+	}
+
+	switch ev.Name {
+	case fyne.KeyLeft:
+		if h.cursor > 0 {
+			h.cursor--
+		}
+	case fyne.KeyRight:
+		if h.cursor < h.digitCount()-1 {
+			h.cursor++
+		}
+	case fyne.KeyHome:
+		h.cursor = 0
+	case fyne.KeyEnd:
+		h.cursor = h.digitCount() - 1
+	case fyne.KeyBackspace:
+		h.setNibble(h.cursor, 0)
+		if h.cursor > 0 {
+			h.cursor--
+		}
+	case fyne.KeyDelete:
+		h.setNibble(h.cursor, 0)
+	default:
+		return
+	}
+	h.Refresh()
+}
+
+func (h *HexWidget) digitAt(x float32) int {
+	for i := h.digitCount() - 1; i > 0; i-- {
+		if x >= h.digitX(i) {
+			return i
+		}
+	}
+	return 0
+}
+
+func (h *HexWidget) digitCount() int {
+	h.valueLock.RLock()
+	defer h.valueLock.RUnlock()
+	return len(h.segments)
+}
+
+func (h *HexWidget) digitWidth() float32 {
+	return h.size.Width + h.hexOffset
+}
+
+func (h *HexWidget) digitX(digit int) float32 {
+	x := float32(digit) * h.digitWidth()
+	if h.grouping > 0 {
+		last := h.digitCount() - 1
+		gaps := last/h.grouping - (last-digit)/h.grouping
+		x += float32(gaps) * h.digitWidth() / 2
+	}
+	return x
+}
+
+func (h *HexWidget) setNibble(digit int, nibble uint64) {
+	h.valueLock.Lock()
+	shift := uint(4 * (len(h.segments) - 1 - digit))
+	h.value = h.value&^(0xf<<shift) | nibble<<shift
+	h.segments[digit] = segmentLookupTable[nibble]
+	value := h.value
+	h.valueLock.Unlock()
+
+	if h.binder != nil {
+		bound := value
+		if bound > math.MaxInt {
+			bound = math.MaxInt
+		}
+		_ = h.binder.Set(int(bound))
+	}
+	if h.OnChanged != nil {
+		h.OnChanged(value)
+	}
+}
+
+func (h *HexWidget) getSegmentColor(digit, segno int) color.Color {
+	h.valueLock.RLock()
+	segments := h.segments[digit]
+	h.valueLock.RUnlock()
+	if (segments & (1 << uint(segno))) == 0 {
 		return h.hexOnColor
 	}
 
@@ -174,20 +416,12 @@ func (h *HexWidget) getSegmentColor(segno int) color.Color {
 
 // CreateRenderer implements fyne.Widget
 func (h *HexWidget) CreateRenderer() fyne.WidgetRenderer {
-
-	seg0 := canvas.NewLine(h.hexOffColor)
-	seg1 := canvas.NewLine(h.hexOffColor)
-	seg2 := canvas.NewLine(h.hexOffColor)
-	seg3 := canvas.NewLine(h.hexOffColor)
-	seg4 := canvas.NewLine(h.hexOffColor)
-	seg5 := canvas.NewLine(h.hexOffColor)
-	seg6 := canvas.NewLine(h.hexOffColor)
-
 	r := &hexRenderer{
-		hex:            h,
-		segmentObjects: []fyne.CanvasObject{seg0, seg1, seg2, seg3, seg4, seg5, seg6},
+		hex:    h,
+		cursor: canvas.NewRectangle(theme.PrimaryColor()),
 	}
 
+	r.createSegments()
 	r.Refresh()
 
 	return r
@@ -197,12 +431,15 @@ func (h *HexWidget) CreateRenderer() fyne.WidgetRenderer {
 // disabled.
 func NewHexWidget() *HexWidget {
 	h := &HexWidget{
-		segments:    0xff,
+		segments:    []uint8{0xff},
 		size:        fyne.NewSize(defaultHexWidth, defaultHexHeight),
 		hexOffset:   defaultHexOffset,
 		hexOnColor:  defaultHexOnColor,
 		hexOffColor: defaultHexOffColor,
 	}
+	h.input = &hexInput{hex: h}
+	h.input.ExtendBaseWidget(h.input)
+	h.input.Hide()
 
 	h.ExtendBaseWidget(h)
 	return h
@@ -212,20 +449,136 @@ func NewHexWidget() *HexWidget {
 // refresh so the changes are visible to the user. Segments values are packed
 // into the 8-bit segments integer, see the documentation for HexWidget for
 // more information on the appropriate packing.
+// The segments are applied to the least significant (rightmost) digit.
 func (h *HexWidget) UpdateSegments(segments uint8) {
-	h.segments = segments
+	h.valueLock.Lock()
+	h.segments[len(h.segments)-1] = segments
+	h.valueLock.Unlock()
 	h.Refresh()
 }
 
-// Set updates the hex widget to show a specific number between 0 and 15, which
-// will be rendered in hexadecimal in 0...f. If the number is greater than 15,
-// it will be modulo-ed by 16.
+// Set updates the hex widget to show a specific number, which will be rendered
+// in hexadecimal with one digit per nibble. If the number does not fit in the
+// digits of the widget it will be modulo-ed, so a single digit shows 0...f.
 func (h *HexWidget) Set(val uint) {
-	val = val % 16
-	h.UpdateSegments(segmentLookupTable[val])
+	h.showValue(uint64(val))
+}
+
+// Bind connects the widget to an integer binding, so that the digits show the bound value and, in editable mode,
+// the value edited by the user is set to the binding. A bound value that does not fit in the digits, or is
+// negative, is clamped to the largest value that can be shown or to 0.
+func (h *HexWidget) Bind(data binding.Int) {
+	h.Unbind()
+	h.valueLock.Lock()
+	h.binder = data
+	h.valueLock.Unlock()
+	h.bindListener = binding.NewDataListener(func() {
+		h.bindingChanged(data)
+	})
+	data.AddListener(h.bindListener)
+}
+
+// Unbind disconnects the widget from the binding set by Bind, leaving the current value displayed.
+func (h *HexWidget) Unbind() {
+	if h.binder == nil {
+		return
+	}
+	h.binder.RemoveListener(h.bindListener)
+	h.valueLock.Lock()
+	h.binder = nil
+	h.valueLock.Unlock()
+	h.bindListener = nil
+}
+
+func (h *HexWidget) bindingChanged(data binding.Int) {
+	val, err := data.Get()
+	if err != nil {
+		return
+	}
+	if val < 0 {
+		val = 0
+	}
+
+	h.valueLock.Lock()
+	if h.binder != data { // unbound while the change was queued
+		h.valueLock.Unlock()
+		return
+	}
+	limit := uint64(math.MaxUint64)
+	if len(h.segments) < maxHexDigits {
+		limit = 1<<uint(4*len(h.segments)) - 1
+	}
+	value := uint64(val)
+	if value > limit {
+		value = limit
+	}
+	changed := value != h.value
+	if changed {
+		h.setValue(value)
+	}
+	h.valueLock.Unlock()
+
+	if changed {
+		h.Refresh()
+	}
+}
+
+func (h *HexWidget) showValue(val uint64) {
+	h.valueLock.Lock()
+	h.setValue(val)
+	h.valueLock.Unlock()
+	h.Refresh()
+}
+
+// setValue updates the value and the segments of each digit, the caller must hold valueLock.
+func (h *HexWidget) setValue(val uint64) {
+	digits := len(h.segments)
+	h.value = 0
+	for i := digits - 1; i >= 0; i-- {
+		nibble := val % 16
+		h.value |= nibble << uint(4*(digits-1-i))
+		h.segments[i] = segmentLookupTable[nibble]
+		val /= 16
+	}
 }
 
 func setLineEndpoints(l *canvas.Line, pt1, pt2 fyne.Position) {
 	l.Position1 = pt1
 	l.Position2 = pt2
 }
+
+// hexInput covers an editable HexWidget to receive the focus, taps and keys that edit it.
+// It is hidden while the widget is not editable, so that a display does not take the taps meant for its parent.
+type hexInput struct {
+	widget.BaseWidget
+	hex *HexWidget
+}
+
+func (i *hexInput) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+// Implements: fyne.Focusable
+func (i *hexInput) FocusGained() {
+	i.hex.focusChanged(true)
+}
+
+// Implements: fyne.Focusable
+func (i *hexInput) FocusLost() {
+	i.hex.focusChanged(false)
+}
+
+// Implements: fyne.Tappable
+func (i *hexInput) Tapped(ev *fyne.PointEvent) {
+	i.hex.tapped(ev)
+}
+
+// Implements: fyne.Focusable
+func (i *hexInput) TypedKey(ev *fyne.KeyEvent) {
+	i.hex.typedKey(ev)
+}
+
+// Implements: fyne.Focusable
+func (i *hexInput) TypedRune(r rune) {
+	i.hex.typedRune(r)
+}
@@ -0,0 +1,166 @@
+package widget
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHexWidget_Set(t *testing.T) {
+	h := NewHexWidget()
+	h.Set(0x1f)
+	assert.Equal(t, uint64(0xf), h.Value())
+	assert.Equal(t, segmentLookupTable[0xf], h.segments[0])
+}
+
+func TestHexWidget_Editable(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	h := NewHexWidget()
+	var changed []uint64
+	h.OnChanged = func(v uint64) {
+		changed = append(changed, v)
+	}
+
+	h.input.TypedRune('a')
+	assert.Equal(t, uint64(0), h.Value())
+	assert.Nil(t, changed)
+
+	h.SetEditable(true)
+	h.input.TypedRune('x')
+	assert.Nil(t, changed)
+	h.input.TypedRune('a')
+	assert.Equal(t, uint64(0xa), h.Value())
+	assert.Equal(t, segmentLookupTable[0xa], h.segments[0])
+
+	h.input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDelete})
+	assert.Equal(t, uint64(0), h.Value())
+	assert.Equal(t, []uint64{0xa, 0}, changed)
+}
+
+func TestHexWidget_SetDigits(t *testing.T) {
+	h := NewHexWidget()
+	h.SetDigits(4)
+	h.Set(0x12345)
+	assert.Equal(t, uint64(0x2345), h.Value())
+	assert.Equal(t, segmentLookupTable[2], h.segments[0])
+	assert.Equal(t, segmentLookupTable[5], h.segments[3])
+	width := h.MinSize().Width
+
+	h.SetGrouping(2)
+	assert.Greater(t, h.MinSize().Width, width)
+	assert.Equal(t, h.digitX(1)+h.digitWidth()*1.5, h.digitX(2))
+
+	h.SetDigits(2)
+	assert.Equal(t, uint64(0x45), h.Value())
+	h.SetDigits(20)
+	assert.Equal(t, maxHexDigits, h.digitCount())
+}
+
+func TestHexWidget_EditCursor(t *testing.T) {
+	h := NewHexWidget()
+	h.SetDigits(4)
+	h.SetEditable(true)
+
+	h.input.TypedRune('d')
+	h.input.TypedRune('e')
+	h.input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEnd})
+	h.input.TypedRune('f')
+	assert.Equal(t, uint64(0xde0f), h.Value())
+
+	h.input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+	h.input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	h.input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	assert.Equal(t, uint64(0xd00f), h.Value())
+	assert.Equal(t, 0, h.cursor)
+}
+
+func TestHexWidget_Bind(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	h := NewHexWidget()
+	h.SetDigits(2)
+	value := binding.NewInt()
+	_ = value.Set(0x2a)
+	h.Bind(value)
+	assert.Eventually(t, func() bool { return h.Value() == 0x2a }, time.Second, 10*time.Millisecond)
+
+	// values that do not fit are clamped
+	_ = value.Set(0x1234)
+	assert.Eventually(t, func() bool { return h.Value() == 0xff }, time.Second, 10*time.Millisecond)
+	_ = value.Set(-5)
+	assert.Eventually(t, func() bool { return h.Value() == 0 }, time.Second, 10*time.Millisecond)
+	waitForListeners(t, value)
+
+	// edits are set to the binding
+	h.SetEditable(true)
+	h.input.TypedRune('7')
+	v, _ := value.Get()
+	assert.Equal(t, 0x70, v)
+
+	h.Unbind()
+	_ = value.Set(0x11)
+	waitForListeners(t, value)
+	assert.Equal(t, uint64(0x70), h.Value())
+	h.input.TypedRune('3')
+	v, _ = value.Get()
+	assert.Equal(t, 0x11, v)
+}
+
+func TestHexWidget_NotEditable(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	h := NewHexWidget()
+	h.SetDigits(4)
+	tapped := false
+	parent := widget.NewButton("", func() {
+		tapped = true
+	})
+	w := test.NewWindow(container.NewMax(parent, h))
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 100))
+	middle := fyne.NewPos(h.Size().Width/2, h.Size().Height/2)
+
+	// a display leaves the taps to its parent
+	test.TapCanvas(w.Canvas(), middle)
+	assert.True(t, tapped)
+	assert.NotEqual(t, h.input, w.Canvas().Focused())
+	h.input.FocusGained()
+	assert.False(t, h.focused)
+	h.input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEnd})
+	assert.Equal(t, 0, h.cursor)
+
+	tapped = false
+	h.SetEditable(true)
+	test.TapCanvas(w.Canvas(), middle)
+	assert.False(t, tapped)
+	assert.Equal(t, h.input, w.Canvas().Focused())
+	assert.True(t, h.focused)
+
+	// the focus is given up when the widget stops being editable
+	h.SetEditable(false)
+	assert.Nil(t, w.Canvas().Focused())
+	assert.False(t, h.focused)
+}
+
+// waitForListeners returns once the listeners of data have handled the changes made so far.
+// Listeners are notified in order on a single goroutine, so a listener added now is called after them.
+func waitForListeners(t *testing.T, data binding.DataItem) {
+	var called int32
+	listener := binding.NewDataListener(func() {
+		atomic.StoreInt32(&called, 1)
+	})
+	data.AddListener(listener)
+	defer data.RemoveListener(listener)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&called) == 1 }, time.Second, 10*time.Millisecond)
+}
@@ -5,8 +5,7 @@ import (
 	"math"
 	"net/http"
 	"net/url"
-
-	"github.com/nfnt/resize"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -16,6 +15,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
 )
 
 const tileSize = 256
@@ -25,17 +25,58 @@ type Map struct {
 	widget.BaseWidget
 
 	pixels     *image.NRGBA
+	unrotated  *image.NRGBA // tiles drawn before rotating by the bearing
 	w, h       int
 	zoom, x, y int
+	bearing    float64 // degrees clockwise from north of the direction shown at the top
+	compass    *mapCompass
+
+	// offsetX and offsetY move the center beyond the tile grid, as a fraction of a tile from -0.5 to 0.5,
+	// so that the map can be centered on any point rather than only on tile corners
+	offsetX, offsetY float64
+
+	markers       []*MapMarker
+	markerLayer   *fyne.Container
+	clustering    bool    // merge markers that are close together at the current zoom
+	clusterRadius float32 // distance within which markers are clustered
+
+	overlays     []*mapOverlay
+	overlayLayer *fyne.Container
+
+	geoJSONLayers []*GeoJSONLayer
+	geoJSONLayer  *fyne.Container
+
+	location       *mapLocation
+	locationLayer  *fyne.Container
+	followLocation bool // pan to keep the current location at the center when it changes
+
+	raster    *canvas.Raster
+	tileLock  sync.Mutex
+	tiles     map[mapTileKey]*mapTile
+	fading    bool // tiles are being faded in
+	fadeAgain bool // more tiles arrived while fading
+
+	prefetchMargin int          // number of tiles around the viewport that are downloaded in advance
+	prefetchQueue  []mapTileKey // tiles waiting to be prefetched, replaced whenever the view changes
+	prefetching    bool         // the prefetch goroutine is running
+
+	visibleTiles   []mapTileKey // tiles drawn the last time the map was drawn
+	loadingVisible bool         // some of the visible tiles were still loading when last checked
+	onTileError    func(z, x, y int, err error)
+	onTilesLoaded  func()
 
 	cl *http.Client
 
 	tileSource       string // url to download xyz tiles (example: "https://tile.openstreetmap.org/%d/%d/%d.png")
+	hiDPITileSource  string // url to download 512px tiles, by default "@2x" is added to the tile source
+	hiDPITiles       bool   // use the high resolution tiles on high DPI canvases
 	hideAttribution  bool   // enable copyright attribution
 	attributionLabel string // label for attribution (example: "OpenStreetMap")
 	attributionURL   string // url for attribution (example: "https://openstreetmap.org")
 	hideZoomButtons  bool   // enable zoom buttons
 	hideMoveButtons  bool   // enable move map buttons
+	showScaleBar     bool   // draw a scale bar showing distances
+	scaleBar         *mapScaleBar
 }
 
 // MapOption configures the provided map with different features.
@@ -58,6 +99,15 @@ func WithTileSource(tileSource string) MapOption {
 	}
 }
 
+// WithHiDPITileSource configures the map to download tiles of 512 pixels from the source on high DPI canvases.
+// The tiles must cover the same area as those of the normal tile source. It turns the high resolution tiles on.
+func WithHiDPITileSource(tileSource string) MapOption {
+	return func(m *Map) {
+		m.hiDPITileSource = tileSource
+		m.hiDPITiles = true
+	}
+}
+
 // WithAttribution configures the map widget to display an attribution.
 func WithAttribution(enable bool, label, url string) MapOption {
 	return func(m *Map) {
@@ -90,7 +140,7 @@ func WithHTTPClient(client *http.Client) MapOption {
 
 // NewMap creates a new instance of the map widget.
 func NewMap() *Map {
-	m := &Map{cl: &http.Client{}}
+	m := &Map{cl: &http.Client{}, clusterRadius: defaultClusterRadius, prefetchMargin: defaultPrefetchMargin}
 	WithOsmTiles()(m)
 	m.ExtendBaseWidget(m)
 	return m
@@ -105,6 +155,35 @@ func NewMapWithOptions(opts ...MapOption) *Map {
 	return m
 }
 
+// Bearing returns the compass direction, in degrees clockwise from north, that is shown at the top of the map.
+func (m *Map) Bearing() float64 {
+	return m.bearing
+}
+
+// LatLonToPixel returns the position within the widget at which the latitude and longitude are drawn,
+// taking the zoom, panning and bearing of the map into account.
+func (m *Map) LatLonToPixel(lat, lon float64) fyne.Position {
+	tileX, tileY := latLonToTile(lat, lon, m.zoom)
+	centerX, centerY := m.centerTile()
+	dx, dy := (tileX-centerX)*tileSize, (tileY-centerY)*tileSize
+	// The map is rotated anti-clockwise by the bearing
+	sin, cos := math.Sincos(m.bearing * math.Pi / 180)
+	size := m.Size()
+	return fyne.NewPos(size.Width/2+float32(dx*cos+dy*sin), size.Height/2+float32(-dx*sin+dy*cos))
+}
+
+// PixelToLatLon returns the latitude and longitude drawn at the position within the widget,
+// taking the zoom, panning and bearing of the map into account.
+func (m *Map) PixelToLatLon(pos fyne.Position) (lat, lon float64) {
+	size := m.Size()
+	sx, sy := float64(pos.X-size.Width/2), float64(pos.Y-size.Height/2)
+	sin, cos := math.Sincos(m.bearing * math.Pi / 180)
+	dx, dy := sx*cos-sy*sin, sx*sin+sy*cos
+
+	centerX, centerY := m.centerTile()
+	return tileToLatLon(centerX+dx/tileSize, centerY+dy/tileSize, m.zoom)
+}
+
 // MinSize returns the smallest possible size for a widget.
 // For our map this is a constant size representing a single tile on a device with
 // the highest known DPI (4x).
@@ -136,6 +215,84 @@ func (m *Map) PanWest() {
 	m.Refresh()
 }
 
+// SetPrefetchMargin sets how many tiles beyond each edge of the visible map are downloaded in advance, so that they
+// are ready when the map is panned. The tiles are downloaded one at a time after the visible ones, and those that
+// are no longer near the view are skipped when the map moves. A margin of 0 turns prefetching off, the default is 1.
+func (m *Map) SetPrefetchMargin(tiles int) {
+	m.prefetchMargin = tiles
+	m.Refresh()
+}
+
+// SetHiDPITiles sets whether the map downloads tiles of 512 pixels for canvases that are scaled up,
+// which look sharper than enlarging the normal tiles. The tile source set with WithHiDPITileSource is used,
+// or if there is none "@2x" is added before the extension of the normal tile source.
+func (m *Map) SetHiDPITiles(hiDPI bool) {
+	m.hiDPITiles = hiDPI
+	m.Refresh()
+}
+
+// SetOnTileError sets a function that is called with the zoom level, coordinates and error of each tile that
+// cannot be downloaded once its retries are used up, for example because the tile source is unreachable or returns
+// an unexpected status code. The function is called from the goroutine downloading the tile.
+func (m *Map) SetOnTileError(f func(z, x, y int, err error)) {
+	m.tileLock.Lock()
+	m.onTileError = f
+	m.tileLock.Unlock()
+}
+
+// SetOnTilesLoaded sets a function that is called when the last of the visible tiles that were loading has been
+// downloaded or has failed, so that an application can hide a loading indicator. It is not called when all of the
+// visible tiles were already cached. The function may be called from the goroutine downloading the tile.
+func (m *Map) SetOnTilesLoaded(f func()) {
+	m.tileLock.Lock()
+	m.onTilesLoaded = f
+	m.tileLock.Unlock()
+}
+
+// SetBearing rotates the map so that the compass direction, in degrees clockwise from north, is shown at the top.
+// A compass showing north is displayed while the map is rotated, tapping it restores a bearing of 0.
+func (m *Map) SetBearing(degrees float64) {
+	m.bearing = math.Mod(degrees, 360)
+	if m.bearing < 0 {
+		m.bearing += 360
+	}
+
+	if m.compass != nil {
+		if m.bearing == 0 {
+			m.compass.Hide()
+		} else {
+			m.compass.Show()
+		}
+		m.compass.Refresh()
+	}
+	m.Refresh()
+}
+
+// Refresh updates the map and the GeoJSON layers, markers and overlays shown on it. Tiles that failed to load are tried again.
+func (m *Map) Refresh() {
+	m.forgetFailedTiles()
+	if m.geoJSONLayer != nil {
+		m.geoJSONLayer.Objects = m.geoJSONObjects()
+		m.geoJSONLayer.Refresh()
+	}
+	if m.locationLayer != nil {
+		m.locationLayer.Objects = m.locationObjects()
+		m.locationLayer.Refresh()
+	}
+	if m.markerLayer != nil {
+		m.markerLayer.Objects = m.markerObjects()
+		m.markerLayer.Refresh()
+	}
+	if m.overlayLayer != nil {
+		m.overlayLayer.Objects = m.overlayObjects()
+		m.overlayLayer.Refresh()
+	}
+	if m.scaleBar != nil {
+		m.scaleBar.Refresh()
+	}
+	m.BaseWidget.Refresh()
+}
+
 // Zoom sets the zoom level to a specific value, between 0 and 19.
 func (m *Map) Zoom(zoom int) {
 	if zoom < 0 || zoom > 19 {
@@ -172,6 +329,30 @@ func (m *Map) ZoomOut() {
 	m.Refresh()
 }
 
+// DoubleTapped zooms the map in by one step, keeping the point that was tapped under the pointer.
+//
+// Implements: fyne.DoubleTappable
+func (m *Map) DoubleTapped(ev *fyne.PointEvent) {
+	m.zoomAround(ev.Position, m.zoom+1)
+}
+
+// zoomAround sets the zoom level, panning the map so that the point at the position within the widget stays there
+func (m *Map) zoomAround(pos fyne.Position, zoom int) {
+	if zoom < 0 || zoom > 19 || zoom == m.zoom {
+		return
+	}
+	size := m.Size()
+	sx, sy := float64(pos.X-size.Width/2), float64(pos.Y-size.Height/2)
+	sin, cos := math.Sincos(m.bearing * math.Pi / 180)
+	dx, dy := (sx*cos-sy*sin)/tileSize, (sx*sin+sy*cos)/tileSize
+
+	centerX, centerY := m.centerTile()
+	scale := math.Pow(2, float64(zoom-m.zoom))
+	m.zoom = zoom
+	m.centerOnTile((centerX+dx)*scale-dx, (centerY+dy)*scale-dy)
+	m.Refresh()
+}
+
 // CreateRenderer returns the renderer for this widget.
 // A map renderer is simply the map Raster with user interface elements overlaid.
 func (m *Map) CreateRenderer() fyne.WidgetRenderer {
@@ -192,23 +373,37 @@ func (m *Map) CreateRenderer() fyne.WidgetRenderer {
 		move = container.NewVBox(buttonLayout)
 	}
 
-	var copyright fyne.CanvasObject
+	m.scaleBar = newMapScaleBar(m)
+	if !m.showScaleBar {
+		m.scaleBar.Hide()
+	}
+	bottom := container.NewHBox(m.scaleBar, layout.NewSpacer())
 	if !m.hideAttribution {
 		license, _ := url.Parse(m.attributionURL)
-		link := widget.NewHyperlink(m.attributionLabel, license)
-		copyright = container.NewHBox(layout.NewSpacer(), link)
+		bottom.Add(widget.NewHyperlink(m.attributionLabel, license))
+	}
+
+	m.compass = newMapCompass(m)
+	if m.bearing == 0 {
+		m.compass.Hide()
 	}
+	compass := container.NewHBox(layout.NewSpacer(), m.compass)
 
-	overlay := container.NewBorder(nil, copyright, move, zoom)
+	overlay := container.NewBorder(compass, bottom, move, zoom)
 
-	c := container.NewStack(canvas.NewRaster(m.draw), container.NewPadded(overlay))
+	m.geoJSONLayer = container.New(&mapGeoJSONLayout{m: m}, m.geoJSONObjects()...)
+	m.locationLayer = container.New(&mapLocationLayout{m: m}, m.locationObjects()...)
+	m.markerLayer = container.New(&mapMarkerLayout{m: m}, m.markerObjects()...)
+	m.overlayLayer = container.New(&mapOverlayLayout{m: m}, m.overlayObjects()...)
+
+	m.raster = canvas.NewRaster(m.draw)
+	c := container.NewStack(m.raster, m.geoJSONLayer, m.locationLayer, m.markerLayer, m.overlayLayer, container.NewPadded(overlay))
 	return widget.NewSimpleRenderer(c)
 }
 
 func (m *Map) draw(w, h int) image.Image {
 	scale := 1
 	tileSize := tileSize
-	// TODO use retina tiles once OSM supports it in their server (text scaling issues)...
 	if c := fyne.CurrentApp().Driver().CanvasForObject(m); c != nil {
 		scale = int(c.Scale())
 		if scale < 1 {
@@ -216,11 +411,38 @@ func (m *Map) draw(w, h int) image.Image {
 		}
 		tileSize = tileSize * scale
 	}
+	hiDPI := m.hiDPITiles && scale > 1
 
 	if m.w != w || m.h != h {
 		m.pixels = image.NewNRGBA(image.Rect(0, 0, w, h))
 	}
 
+	if m.bearing == 0 {
+		m.drawTiles(m.pixels, w, h, tileSize, hiDPI)
+		return m.pixels
+	}
+
+	// Draw the tiles unrotated into a square large enough to cover the widget at any angle, then rotate
+	d := int(math.Ceil(math.Hypot(float64(w), float64(h))))
+	if m.unrotated == nil || m.unrotated.Bounds().Dx() != d {
+		m.unrotated = image.NewNRGBA(image.Rect(0, 0, d, d))
+	} else {
+		draw.Draw(m.unrotated, m.unrotated.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	}
+	m.drawTiles(m.unrotated, d, d, tileSize, hiDPI)
+
+	sin, cos := math.Sincos(m.bearing * math.Pi / 180)
+	srcCenter, dstCenter := float64(d)/2, f64.Vec2{float64(w) / 2, float64(h) / 2}
+	rotation := f64.Aff3{
+		cos, sin, dstCenter[0] - (cos*srcCenter + sin*srcCenter),
+		-sin, cos, dstCenter[1] - (-sin*srcCenter + cos*srcCenter),
+	}
+	draw.Draw(m.pixels, m.pixels.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	draw.BiLinear.Transform(m.pixels, rotation, m.unrotated, m.unrotated.Bounds(), draw.Over, nil)
+	return m.pixels
+}
+
+func (m *Map) drawTiles(pixels *image.NRGBA, w, h, tileSize int, hiDPI bool) {
 	midTileX := (w - tileSize*2) / 2
 	midTileY := (h - tileSize*2) / 2
 	if m.zoom == 0 {
@@ -228,45 +450,81 @@ func (m *Map) draw(w, h int) image.Image {
 		midTileY += tileSize / 2
 	}
 
+	// the tiles are moved against the offset of the center
+	midTileX -= int(math.Round(m.offsetX * float64(tileSize)))
+	midTileY -= int(math.Round(m.offsetY * float64(tileSize)))
+
 	count := 1 << m.zoom
 	mx := m.x + int(float32(count)/2-0.5)
 	my := m.y + int(float32(count)/2-0.5)
 	firstTileX := mx - int(math.Ceil(float64(midTileX)/float64(tileSize)))
 	firstTileY := my - int(math.Ceil(float64(midTileY)/float64(tileSize)))
 
+	visible := []mapTileKey{}
 	for x := firstTileX; (x-firstTileX)*tileSize <= w+tileSize; x++ {
 		for y := firstTileY; (y-firstTileY)*tileSize <= h+tileSize; y++ {
 			if x < 0 || y < 0 || x >= int(count) || y >= int(count) {
 				continue
 			}
 
-			src, err := getTile(m.tileSource, x, y, m.zoom, m.cl)
-			if err != nil {
-				fyne.LogError("tile fetch error", err)
-				continue
-			}
-
+			key := mapTileKey{zoom: m.zoom, x: x, y: y, hiDPI: hiDPI}
+			visible = append(visible, key)
+			img, alpha, failed := m.tile(key, tileSize)
 			pos := image.Pt(midTileX+(x-mx)*tileSize,
 				midTileY+(y-my)*tileSize)
-			scaled := src
-			if scale > 1 {
-				scaled = resize.Resize(uint(tileSize), uint(tileSize), src, resize.Lanczos2)
-			}
-			draw.Copy(m.pixels, pos, scaled, image.Rect(0, 0, tileSize, tileSize), draw.Over, nil)
+			drawTile(pixels, pos, tileSize, img, alpha, failed)
 		}
 	}
 
-	return m.pixels
+	m.setVisibleTiles(visible)
+
+	lastTileX := firstTileX + (w+tileSize)/tileSize
+	lastTileY := firstTileY + (h+tileSize)/tileSize
+	around := m.tilesAround(firstTileX, firstTileY, lastTileX, lastTileY, hiDPI)
+	m.pruneTiles(visible, around)
+	m.prefetchTiles(around)
+}
+
+// centerTile returns the tile coordinates, at the current zoom, of the point shown at the center of the map
+func (m *Map) centerTile() (x, y float64) {
+	half := float64(int(1)<<m.zoom) / 2
+	return float64(m.x) + m.offsetX + half, float64(m.y) + m.offsetY + half
+}
+
+// centerOnTile pans the map so that the point at the tile coordinates is at the center
+func (m *Map) centerOnTile(x, y float64) {
+	half := float64(int(1)<<m.zoom) / 2
+	m.x = int(math.Round(x - half))
+	m.y = int(math.Round(y - half))
+	m.offsetX = x - half - float64(m.x)
+	m.offsetY = y - half - float64(m.y)
 }
 
 func (m *Map) zoomInStep() {
+	x, y := m.centerTile()
 	m.zoom++
-	m.x *= 2
-	m.y *= 2
+	m.centerOnTile(x*2, y*2)
 }
 
 func (m *Map) zoomOutStep() {
+	x, y := m.centerTile()
 	m.zoom--
-	m.x /= 2
-	m.y /= 2
+	m.centerOnTile(x/2, y/2)
+}
+
+// latLonToTile returns the tile coordinates of the latitude and longitude at the zoom level
+func latLonToTile(lat, lon float64, zoom int) (x, y float64) {
+	count := float64(int(1) << zoom)
+	latRad := lat * math.Pi / 180
+	x = (lon + 180) / 360 * count
+	y = (1 - math.Log(math.Tan(latRad)+1/math.Cos(latRad))/math.Pi) / 2 * count
+	return x, y
+}
+
+// tileToLatLon returns the latitude and longitude at the tile coordinates at the zoom level
+func tileToLatLon(x, y float64, zoom int) (lat, lon float64) {
+	count := float64(int(1) << zoom)
+	lon = x/count*360 - 180
+	lat = math.Atan(math.Sinh(math.Pi*(1-2*y/count))) * 180 / math.Pi
+	return lat, lon
 }
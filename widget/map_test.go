@@ -1,10 +1,20 @@
 package widget
 
 import (
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -74,3 +84,468 @@ func TestNewMap_WithOptions(t *testing.T) {
 	assert.True(t, m.hideMoveButtons)
 	assert.True(t, m.hideZoomButtons)
 }
+
+func TestMap_Bearing(t *testing.T) {
+	m := NewMap()
+	m.Resize(fyne.NewSize(200, 200))
+	m.SetBearing(450)
+	assert.Equal(t, 90.0, m.Bearing())
+	m.SetBearing(-90)
+	assert.Equal(t, 270.0, m.Bearing())
+
+	m.SetBearing(90)
+	m.Zoom(3)
+	lat, lon := m.PixelToLatLon(fyne.NewPos(100, 100))
+	assert.InDelta(t, 0, lat, 0.0001)
+	assert.InDelta(t, 0, lon, 0.0001)
+
+	// with east at the top, points above the center are east of it
+	lat, lon = m.PixelToLatLon(fyne.NewPos(100, 50))
+	assert.InDelta(t, 0, lat, 0.0001)
+	assert.Greater(t, lon, 0.0)
+
+	pos := m.LatLonToPixel(51.5, -0.12)
+	lat, lon = m.PixelToLatLon(pos)
+	assert.InDelta(t, 51.5, lat, 0.0001)
+	assert.InDelta(t, -0.12, lon, 0.0001)
+}
+
+func TestMap_ZoomGestures(t *testing.T) {
+	m := NewMap()
+	m.Resize(fyne.NewSize(1024, 1024))
+	m.Zoom(3)
+
+	// one tile east and south of the center stays under the tap
+	tap := fyne.NewPos(768, 768)
+	lat, lon := m.PixelToLatLon(tap)
+	m.DoubleTapped(&fyne.PointEvent{Position: tap})
+	assert.Equal(t, 4, m.zoom)
+	assert.Equal(t, 1, m.x)
+	assert.Equal(t, 1, m.y)
+	newLat, newLon := m.PixelToLatLon(tap)
+	assert.InDelta(t, lat, newLat, 0.0001)
+	assert.InDelta(t, lon, newLon, 0.0001)
+
+	// a point that is not on a tile corner stays under the tap too
+	tap = fyne.NewPos(300, 650)
+	lat, lon = m.PixelToLatLon(tap)
+	m.DoubleTapped(&fyne.PointEvent{Position: tap})
+	assert.Equal(t, 5, m.zoom)
+	pos := m.LatLonToPixel(lat, lon)
+	assert.InDelta(t, tap.X, pos.X, 0.01)
+	assert.InDelta(t, tap.Y, pos.Y, 0.01)
+
+	// and when the map is rotated
+	m.SetBearing(30)
+	tap = fyne.NewPos(610, 170)
+	lat, lon = m.PixelToLatLon(tap)
+	m.DoubleTapped(&fyne.PointEvent{Position: tap})
+	assert.Equal(t, 6, m.zoom)
+	pos = m.LatLonToPixel(lat, lon)
+	assert.InDelta(t, tap.X, pos.X, 0.01)
+	assert.InDelta(t, tap.Y, pos.Y, 0.01)
+}
+
+func TestMap_DrawOffset(t *testing.T) {
+	test.NewApp()
+	m := NewMap()
+	m.Resize(fyne.NewSize(1024, 1024))
+	m.Zoom(3)
+	m.centerOnTile(4.25, 3.75)
+	assert.Equal(t, 0, m.x)
+	assert.Equal(t, 0, m.y)
+	assert.InDelta(t, 0.25, m.offsetX, 0.0001)
+	assert.InDelta(t, -0.25, m.offsetY, 0.0001)
+
+	// the tile at the center of the map is drawn moved by the offset
+	assert.Equal(t, fyne.NewPos(512-64, 512+64), m.LatLonToPixel(tileToLatLon(4, 4, 3)))
+	pixels := image.NewNRGBA(image.Rect(0, 0, 1024, 1024))
+	m.tiles = map[mapTileKey]*mapTile{}
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			m.tiles[mapTileKey{zoom: 3, x: x, y: y}] = &mapTile{failed: true}
+		}
+	}
+	m.drawTiles(pixels, 1024, 1024, tileSize, false)
+	// failed tiles are marked with a cross starting a quarter of a tile in from their top left corner
+	errorColor := color.NRGBAModel.Convert(theme.ErrorColor())
+	assert.Equal(t, errorColor, pixels.At(512-64+tileSize/4, 512+64+tileSize/4))
+	assert.NotEqual(t, errorColor, pixels.At(512+tileSize/4, 512+tileSize/4))
+}
+
+func TestMap_Clustering(t *testing.T) {
+	m := NewMap()
+	m.Resize(fyne.NewSize(600, 600))
+	m.AddMarker(NewMapMarker(51.50, -0.12))
+	m.AddMarker(NewMapMarker(51.51, -0.13))
+	m.AddMarker(NewMapMarker(48.85, 2.35))
+	assert.Len(t, m.clusterMarkers(), 3)
+
+	m.SetClusteringEnabled(true)
+	clusters := m.clusterMarkers()
+	assert.Len(t, clusters, 1)
+	assert.Len(t, clusters[0], 3)
+
+	m.Zoom(5)
+	assert.Len(t, m.clusterMarkers(), 2)
+
+	m.SetClusterRadius(0.1)
+	assert.Len(t, m.clusterMarkers(), 3)
+
+	m.SetClusterRadius(defaultClusterRadius)
+	m.ZoomToMarkers(m.Markers()[:2])
+	assert.Greater(t, m.zoom, 5)
+	assert.Len(t, m.clusterMarkers(), 3)
+}
+
+func TestMap_ScaleBar(t *testing.T) {
+	meters, width := scaleBarLength(7.5, 100)
+	assert.Equal(t, 500.0, meters)
+	assert.InDelta(t, 66.67, width, 0.01)
+	meters, _ = scaleBarLength(25, 100)
+	assert.Equal(t, 2000.0, meters)
+	assert.Equal(t, "500 m", formatDistance(500))
+	assert.Equal(t, "2 km", formatDistance(2000))
+	assert.Equal(t, "2.5 km", formatDistance(2500))
+
+	m := NewMap()
+	m.Resize(fyne.NewSize(600, 600))
+	m.Zoom(3)
+	equator := m.metersPerPixel()
+	assert.InDelta(t, 19567.88, equator, 0.01)
+	m.PanNorth()
+	m.PanNorth()
+	assert.Less(t, m.metersPerPixel(), equator)
+}
+
+func TestMap_AsyncTiles(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first request fails so that it is retried, and the missing tile always fails
+		if atomic.AddInt32(&requests, 1) == 1 || strings.HasPrefix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		img := image.NewNRGBA(image.Rect(0, 0, tileSize, tileSize))
+		for i := range img.Pix {
+			img.Pix[i] = 0xff
+		}
+		_ = png.Encode(w, img)
+	}))
+	defer server.Close()
+
+	m := NewMapWithOptions(WithTileSource(server.URL + "/%d/%d/%d.png"))
+	assert.Equal(t, color.NRGBAModel.Convert(tilePlaceholderColor), m.draw(tileSize, tileSize).At(10, 10))
+	assert.Eventually(t, func() bool {
+		img, _, _ := m.tile(mapTileKey{}, tileSize)
+		return img != nil
+	}, time.Second, 10*time.Millisecond)
+	_, alpha, _ := m.tile(mapTileKey{}, tileSize)
+	assert.Less(t, alpha, float32(1))
+
+	time.Sleep(tileFadeDuration)
+	assert.Equal(t, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, m.draw(tileSize, tileSize).At(10, 10))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	missing := NewMapWithOptions(WithTileSource(server.URL + "/missing/%d/%d/%d.png"))
+	missing.draw(tileSize, tileSize)
+	assert.Eventually(t, func() bool {
+		_, _, failed := missing.tile(mapTileKey{}, tileSize)
+		return failed
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2+1+tileRetries), atomic.LoadInt32(&requests))
+}
+
+func TestMap_TileCallbacks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = png.Encode(w, image.NewNRGBA(image.Rect(0, 0, tileSize, tileSize)))
+	}))
+	defer server.Close()
+
+	loaded := make(chan bool, 1)
+	m := NewMapWithOptions(WithTileSource(server.URL + "/callbacks/%d/%d/%d.png"))
+	m.SetOnTileError(func(z, x, y int, err error) {
+		t.Errorf("unexpected tile error %v", err)
+	})
+	m.SetOnTilesLoaded(func() {
+		loaded <- true
+	})
+	m.draw(tileSize, tileSize)
+	select {
+	case <-loaded:
+	case <-time.After(time.Second):
+		t.Fatal("the tiles loaded callback was not called")
+	}
+
+	type tileError struct {
+		z, x, y int
+		err     error
+	}
+	errs := make(chan tileError, 1)
+	missing := NewMapWithOptions(WithTileSource(server.URL + "/missing/%d/%d/%d.png"))
+	missing.SetOnTileError(func(z, x, y int, err error) {
+		errs <- tileError{z, x, y, err}
+	})
+	missing.SetOnTilesLoaded(func() {
+		loaded <- true
+	})
+	missing.draw(tileSize, tileSize)
+	select {
+	case e := <-errs:
+		assert.Equal(t, 0, e.z)
+		assert.Equal(t, 0, e.x)
+		assert.Equal(t, 0, e.y)
+		assert.Contains(t, e.err.Error(), "429")
+	case <-time.After(2 * time.Second):
+		t.Fatal("the tile error callback was not called")
+	}
+	select {
+	case <-loaded:
+	case <-time.After(time.Second):
+		t.Fatal("the tiles loaded callback was not called after the tile failed")
+	}
+}
+
+func TestMap_HiDPITiles(t *testing.T) {
+	m := NewMapWithOptions(WithTileSource("https://example.com/%d/%d/%d.png"))
+	assert.Equal(t, "https://example.com/%d/%d/%d.png", m.sourceFor(mapTileKey{}))
+	assert.Equal(t, "https://example.com/%d/%d/%d@2x.png", m.sourceFor(mapTileKey{hiDPI: true}))
+	m.SetHiDPITiles(true)
+	assert.True(t, m.hiDPITiles)
+
+	requested := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested <- r.URL.Path
+		_ = png.Encode(w, image.NewNRGBA(image.Rect(0, 0, 2*tileSize, 2*tileSize)))
+	}))
+	defer server.Close()
+
+	m = NewMapWithOptions(WithHiDPITileSource(server.URL + "/retina/%d/%d/%d.png"))
+	assert.True(t, m.hiDPITiles)
+	key := mapTileKey{zoom: 1, x: 1, y: 0, hiDPI: true}
+	m.tile(key, 3*tileSize/2)
+	assert.Equal(t, "/retina/1/1/0.png", <-requested)
+	assert.Eventually(t, func() bool {
+		img, _, _ := m.tile(key, 3*tileSize/2)
+		return img != nil && img.Bounds().Dx() == 3*tileSize/2
+	}, time.Second, 10*time.Millisecond)
+
+	// positions use logical tile coordinates whatever the size of the tiles
+	m.Resize(fyne.NewSize(200, 200))
+	m.Zoom(1)
+	lat, lon := m.PixelToLatLon(fyne.NewPos(100+tileSize/2, 100))
+	assert.InDelta(t, 0, lat, 0.0001)
+	assert.InDelta(t, 90, lon, 0.0001)
+}
+
+func TestMap_Overlay(t *testing.T) {
+	m := NewMap()
+	w := test.NewWindow(m)
+	defer w.Close()
+	w.Resize(fyne.NewSize(600, 600))
+
+	card := canvas.NewRectangle(color.White)
+	card.SetMinSize(fyne.NewSize(40, 20))
+	m.AddOverlay(0, 0, card)
+	assert.True(t, card.Visible())
+	assert.Equal(t, m.LatLonToPixel(0, 0), card.Position())
+	assert.Equal(t, fyne.NewSize(40, 20), card.Size())
+
+	m.SetOverlayAnchor(card, fyne.NewPos(0.5, 1))
+	assert.Equal(t, m.LatLonToPixel(0, 0).SubtractXY(20, 20), card.Position())
+
+	// the overlay follows the map and is hidden while its location is off screen
+	m.PanEast()
+	assert.Equal(t, m.LatLonToPixel(0, 0).SubtractXY(20, 20), card.Position())
+	m.Zoom(5)
+	m.PanEast()
+	m.PanEast()
+	m.PanEast()
+	assert.False(t, card.Visible())
+
+	m.RemoveOverlay(card)
+	assert.Empty(t, m.overlayLayer.Objects)
+}
+
+func TestMap_CurrentLocation(t *testing.T) {
+	m := NewMap()
+	w := test.NewWindow(m)
+	defer w.Close()
+	w.Resize(fyne.NewSize(600, 600))
+	m.Zoom(10)
+
+	m.SetCurrentLocation(0, 0, 500)
+	dot, halo := m.location.dot, m.location.halo
+	assert.True(t, dot.Visible())
+	center := m.LatLonToPixel(0, 0)
+	assert.Equal(t, center, dot.Position().AddXY(mapLocationDotSize/2, mapLocationDotSize/2))
+
+	// the accuracy circle is sized in meters at the current zoom and latitude
+	radius := float32(500 / m.metersPerPixelAt(0))
+	assert.InDelta(t, radius*2, halo.Size().Width, 0.01)
+	m.ZoomOut()
+	assert.InDelta(t, radius, halo.Size().Width, 0.01)
+	m.SetCurrentLocation(60, 0, 500)
+	assert.False(t, dot.Visible())
+
+	// following the location keeps it near the center
+	m.FollowLocation(true)
+	assert.True(t, dot.Visible())
+	assert.InDelta(t, radius*2, halo.Size().Width, 0.01)
+	pos := m.LatLonToPixel(60, 0)
+	assert.InDelta(t, 300, pos.X, tileSize/2)
+	assert.InDelta(t, 300, pos.Y, tileSize/2)
+	m.SetCurrentLocation(60.5, 1, 500)
+	pos = m.LatLonToPixel(60.5, 1)
+	assert.InDelta(t, 300, pos.X, tileSize/2)
+	assert.InDelta(t, 300, pos.Y, tileSize/2)
+
+	m.ClearCurrentLocation()
+	assert.Empty(t, m.locationLayer.Objects)
+}
+
+func TestMap_Prefetch(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_ = png.Encode(w, image.NewNRGBA(image.Rect(0, 0, tileSize, tileSize)))
+	}))
+	defer server.Close()
+
+	m := NewMapWithOptions(WithTileSource(server.URL + "/%d/%d/%d.png"))
+	m.Zoom(4)
+	around := m.tilesAround(5, 6, 7, 8, false)
+	assert.Len(t, around, 5*5-3*3)
+	assert.Equal(t, mapTileKey{zoom: 4, x: 4, y: 5}, around[0])
+	m.SetPrefetchMargin(2)
+	assert.Len(t, m.tilesAround(5, 6, 7, 8, false), 7*7-3*3)
+	// tiles beyond the edge of the world are not fetched
+	assert.Len(t, m.tilesAround(0, 0, 0, 0, false), 3*3-1)
+
+	m.SetPrefetchMargin(1)
+	m.draw(tileSize, tileSize)
+	m.tileLock.Lock()
+	visible := len(m.tiles)
+	first, last := mapTileKey{x: 16, y: 16}, mapTileKey{}
+	for key := range m.tiles {
+		if key.x < first.x {
+			first.x = key.x
+		}
+		if key.y < first.y {
+			first.y = key.y
+		}
+		if key.x > last.x {
+			last.x = key.x
+		}
+		if key.y > last.y {
+			last.y = key.y
+		}
+	}
+	m.tileLock.Unlock()
+	assert.Eventually(t, func() bool {
+		m.tileLock.Lock()
+		defer m.tileLock.Unlock()
+		return !m.prefetching
+	}, 2*time.Second, 10*time.Millisecond)
+	ring := m.tilesAround(first.x, first.y, last.x, last.y, false)
+	assert.Equal(t, int32(visible+len(ring)), atomic.LoadInt32(&requests))
+	for _, key := range ring {
+		_, cached := cachedTile(m.sourceFor(key), key.x, key.y, key.zoom)
+		assert.True(t, cached)
+	}
+
+	// moving the view replaces the tiles waiting to be prefetched
+	m.tileLock.Lock()
+	m.prefetching = true
+	m.tileLock.Unlock()
+	m.prefetchTiles(ring)
+	m.prefetchTiles(nil)
+	assert.Empty(t, m.prefetchQueue)
+}
+
+func TestMap_PruneTiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = png.Encode(w, image.NewNRGBA(image.Rect(0, 0, tileSize, tileSize)))
+	}))
+	defer server.Close()
+
+	m := NewMapWithOptions(WithTileSource(server.URL + "/%d/%d/%d.png"))
+	m.SetPrefetchMargin(0)
+	m.Zoom(4)
+	m.draw(tileSize, tileSize)
+	m.tileLock.Lock()
+	visible := len(m.tiles)
+	m.tileLock.Unlock()
+	assert.NotZero(t, visible)
+
+	// the tiles of the previous view are forgotten when the map is drawn again
+	for i := 0; i < 4; i++ {
+		m.PanEast()
+		m.PanSouth()
+		m.draw(tileSize, tileSize)
+	}
+	m.ZoomIn()
+	m.draw(tileSize, tileSize)
+	m.tileLock.Lock()
+	defer m.tileLock.Unlock()
+	assert.Len(t, m.tiles, visible)
+	for key := range m.tiles {
+		assert.Equal(t, 5, key.zoom)
+	}
+}
+
+func TestMap_GeoJSON(t *testing.T) {
+	m := NewMap()
+	w := test.NewWindow(m)
+	defer w.Close()
+	w.Resize(fyne.NewSize(600, 600))
+
+	_, err := m.AddGeoJSON(strings.NewReader(`{"type": "Circle", "coordinates": [0, 0]}`))
+	assert.Error(t, err)
+	_, err = m.AddGeoJSON(strings.NewReader(`{"type": "Point", "coordinates": [0]}`))
+	assert.Error(t, err)
+
+	layer, err := m.AddGeoJSON(strings.NewReader(`{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "id": "p", "properties": {"name": "point"}, "geometry": {"type": "Point", "coordinates": [0, 0]}},
+		{"type": "Feature", "properties": {"name": "line"}, "geometry": {"type": "LineString", "coordinates": [[-20, 20], [20, 20]]}},
+		{"type": "Feature", "properties": {"name": "area"}, "geometry": {"type": "Polygon", "coordinates": [[[-20, -20], [20, -20], [20, -10], [-20, -10], [-20, -20]]]}}
+	]}`))
+	assert.NoError(t, err)
+	features := layer.Features()
+	assert.Len(t, features, 3)
+	assert.Equal(t, "p", features[0].ID)
+	assert.Equal(t, "LineString", features[1].GeometryType)
+	assert.Equal(t, "area", features[2].Properties["name"])
+
+	// the point is shown as a marker
+	assert.Len(t, m.geoJSONLayer.Objects, 2)
+	marker := m.geoJSONLayer.Objects[1]
+	assert.Equal(t, m.LatLonToPixel(0, 0).SubtractXY(markerSize/2, markerSize/2), marker.Position())
+
+	colors := map[string]color.Color{"line": color.NRGBA{R: 0xff, A: 0xff}, "area": color.NRGBA{B: 0xff, A: 0xff}}
+	layer.SetStyle(func(f *GeoJSONFeature) GeoJSONStyle {
+		return GeoJSONStyle{FillColor: colors[f.Properties["name"].(string)], StrokeColor: colors[f.Properties["name"].(string)], StrokeWidth: 4}
+	})
+	size := m.Size()
+	img := layer.draw(int(size.Width), int(size.Height))
+	line := m.LatLonToPixel(20, 0)
+	area := m.LatLonToPixel(-15, 0)
+	assert.Equal(t, colors["line"], color.NRGBAModel.Convert(img.At(int(line.X), int(line.Y))))
+	assert.Equal(t, colors["area"], color.NRGBAModel.Convert(img.At(int(area.X), int(area.Y))))
+	_, _, _, a := img.At(int(size.Width/2), int(size.Height/2)).RGBA()
+	assert.Zero(t, a)
+
+	// the shapes follow the map
+	m.Zoom(3)
+	img = layer.draw(int(size.Width), int(size.Height))
+	area = m.LatLonToPixel(-15, 0)
+	assert.Equal(t, colors["area"], color.NRGBAModel.Convert(img.At(int(area.X), int(area.Y))))
+	assert.Equal(t, m.LatLonToPixel(0, 0).SubtractXY(markerSize/2, markerSize/2), m.geoJSONLayer.Objects[1].Position())
+
+	m.RemoveGeoJSON(layer)
+	assert.Empty(t, m.geoJSONLayer.Objects)
+}
@@ -6,9 +6,21 @@ import (
 	"image"
 	"image/png"
 	"net/http"
+	"sync"
 )
 
-var tileMap = make(map[string]image.Image)
+var (
+	tileMap     = make(map[string]image.Image)
+	tileMapLock sync.RWMutex
+)
+
+// cachedTile returns the tile if it has already been downloaded
+func cachedTile(tileSource string, x, y, zoom int) (image.Image, bool) {
+	tileMapLock.RLock()
+	defer tileMapLock.RUnlock()
+	tile, ok := tileMap[fmt.Sprintf(tileSource, zoom, x, y)]
+	return tile, ok
+}
 
 func getTile(tileSource string, x, y, zoom int, cl *http.Client) (image.Image, error) {
 	if tileSource == "" {
@@ -16,7 +28,7 @@ func getTile(tileSource string, x, y, zoom int, cl *http.Client) (image.Image, e
 	}
 
 	u := fmt.Sprintf(tileSource, zoom, x, y)
-	if tile, ok := tileMap[u]; ok {
+	if tile, ok := cachedTile(tileSource, x, y, zoom); ok {
 		return tile, nil
 	}
 
@@ -31,10 +43,15 @@ func getTile(tileSource string, x, y, zoom int, cl *http.Client) (image.Image, e
 		return nil, err
 	}
 	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q downloading tile %s", res.Status, u)
+	}
 
 	img, err := png.Decode(res.Body)
 	if err == nil {
+		tileMapLock.Lock()
 		tileMap[u] = img
+		tileMapLock.Unlock()
 	}
 	return img, err
 }
@@ -0,0 +1,92 @@
+package widget
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const compassSize = 36
+
+// mapCompass shows the direction of north on a rotated map. Tapping it resets the bearing.
+type mapCompass struct {
+	widget.BaseWidget
+
+	m *Map
+}
+
+func newMapCompass(m *Map) *mapCompass {
+	c := &mapCompass{m: m}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *mapCompass) CreateRenderer() fyne.WidgetRenderer {
+	r := &mapCompassRenderer{c: c,
+		bg:    canvas.NewCircle(theme.ShadowColor()),
+		north: canvas.NewLine(theme.ErrorColor()),
+		south: canvas.NewLine(theme.ForegroundColor()),
+		label: canvas.NewText("N", theme.ForegroundColor()),
+	}
+	r.north.StrokeWidth = 3
+	r.south.StrokeWidth = 3
+	r.label.TextStyle.Bold = true
+	r.label.TextSize = theme.CaptionTextSize()
+	return r
+}
+
+func (c *mapCompass) MinSize() fyne.Size {
+	return fyne.NewSize(compassSize, compassSize)
+}
+
+func (c *mapCompass) Tapped(_ *fyne.PointEvent) {
+	c.m.SetBearing(0)
+}
+
+type mapCompassRenderer struct {
+	c *mapCompass
+
+	bg           *canvas.Circle
+	north, south *canvas.Line
+	label        *canvas.Text
+}
+
+func (r *mapCompassRenderer) Destroy() {
+}
+
+func (r *mapCompassRenderer) Layout(s fyne.Size) {
+	r.bg.Resize(s)
+
+	center := fyne.NewPos(s.Width/2, s.Height/2)
+	length := float64(fyne.Min(s.Width, s.Height)/2 - theme.Padding())
+	// north on the map points up, rotated anti-clockwise by the bearing
+	theta := r.c.m.bearing * math.Pi / 180
+	dx, dy := float32(-math.Sin(theta)*length), float32(-math.Cos(theta)*length)
+	r.north.Position1 = center
+	r.north.Position2 = center.AddXY(dx, dy)
+	r.south.Position1 = center
+	r.south.Position2 = center.AddXY(-dx, -dy)
+
+	labelSize := r.label.MinSize()
+	r.label.Move(center.AddXY(dx*0.6-labelSize.Width/2, dy*0.6-labelSize.Height/2))
+}
+
+func (r *mapCompassRenderer) MinSize() fyne.Size {
+	return r.c.MinSize()
+}
+
+func (r *mapCompassRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.bg, r.south, r.north, r.label}
+}
+
+func (r *mapCompassRenderer) Refresh() {
+	r.bg.FillColor = theme.ShadowColor()
+	r.north.StrokeColor = theme.ErrorColor()
+	r.south.StrokeColor = theme.ForegroundColor()
+	r.label.Color = theme.ForegroundColor()
+	r.Layout(r.c.Size())
+	canvas.Refresh(r.c)
+}
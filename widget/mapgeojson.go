@@ -0,0 +1,397 @@
+package widget
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"golang.org/x/image/vector"
+)
+
+// Declare conformity with Layout interface
+var _ fyne.Layout = (*mapGeoJSONLayout)(nil)
+
+var errInvalidGeoJSON = errors.New("invalid GeoJSON coordinates")
+
+// GeoJSONFeature is a feature read from GeoJSON data. Its geometry is drawn on the map and its properties
+// can be used to choose the style it is drawn with.
+type GeoJSONFeature struct {
+	// ID is the identifier of the feature, if it has one
+	ID interface{}
+	// Properties are the properties of the feature, which may be empty
+	Properties map[string]interface{}
+	// GeometryType is the type of the geometry, like "Point", "LineString" or "Polygon"
+	GeometryType string
+
+	points   [][2]float64     // longitude and latitude of each point
+	lines    [][][2]float64   // positions along each line
+	polygons [][][][2]float64 // rings of each polygon, the first is the outside and any others are holes
+}
+
+// GeoJSONStyle describes how a GeoJSON feature is drawn
+type GeoJSONStyle struct {
+	// FillColor fills polygons, it may be nil or transparent to only draw their outline
+	FillColor color.Color
+	// StrokeColor draws lines and the outline of polygons, it may be nil to not draw them
+	StrokeColor color.Color
+	// StrokeWidth is the width of lines and polygon outlines
+	StrokeWidth float32
+	// Icon is shown on points, a dot is shown if it is nil
+	Icon fyne.Resource
+}
+
+// GeoJSONLayer shows the features of GeoJSON data on a map.
+// It is created by Map.AddGeoJSON and removed by Map.RemoveGeoJSON.
+type GeoJSONLayer struct {
+	m        *Map
+	features []*GeoJSONFeature
+	style    func(*GeoJSONFeature) GeoJSONStyle
+	raster   *canvas.Raster
+}
+
+// AddGeoJSON reads GeoJSON data and shows its features on the map. The data may be a FeatureCollection,
+// a single Feature or a geometry. Points are shown as markers, lines as polylines and polygons as filled shapes,
+// as well as their Multi variants and geometry collections. The shapes follow the map as it is panned and zoomed.
+// The features are drawn in the primary color of the theme unless a style is set with SetStyle.
+func (m *Map) AddGeoJSON(r io.Reader) (*GeoJSONLayer, error) {
+	var data geoJSONObject
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	features, err := data.features()
+	if err != nil {
+		return nil, err
+	}
+
+	l := &GeoJSONLayer{m: m, features: features}
+	l.raster = canvas.NewRaster(l.draw)
+	m.geoJSONLayers = append(m.geoJSONLayers, l)
+	m.Refresh()
+	return l, nil
+}
+
+// RemoveGeoJSON removes a layer added with AddGeoJSON from the map.
+func (m *Map) RemoveGeoJSON(layer *GeoJSONLayer) {
+	for i, l := range m.geoJSONLayers {
+		if l == layer {
+			m.geoJSONLayers = append(m.geoJSONLayers[:i], m.geoJSONLayers[i+1:]...)
+			m.Refresh()
+			return
+		}
+	}
+}
+
+// Features returns the features of the layer in the order they were read
+func (l *GeoJSONLayer) Features() []*GeoJSONFeature {
+	return l.features
+}
+
+// SetStyle sets the function that returns the style each feature is drawn with, or nil for the default style.
+func (l *GeoJSONLayer) SetStyle(style func(*GeoJSONFeature) GeoJSONStyle) {
+	l.style = style
+	l.m.Refresh()
+}
+
+// styleFor returns the style of the feature, using the default style if there is no style function
+func (l *GeoJSONLayer) styleFor(f *GeoJSONFeature) GeoJSONStyle {
+	if l.style != nil {
+		return l.style(f)
+	}
+
+	primary := theme.PrimaryColor()
+	r, g, b, _ := primary.RGBA()
+	return GeoJSONStyle{
+		FillColor:   color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0x40},
+		StrokeColor: primary,
+		StrokeWidth: 2,
+	}
+}
+
+// objects returns the raster that draws the lines and polygons followed by a marker for each point
+func (l *GeoJSONLayer) objects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{l.raster}
+	for _, f := range l.features {
+		if len(f.points) == 0 {
+			continue
+		}
+		icon := l.styleFor(f).Icon
+		for _, p := range f.points {
+			objects = append(objects, newMapMarkerWidget(&MapMarker{Lat: p[1], Lon: p[0], Icon: icon}))
+		}
+	}
+	return objects
+}
+
+// draw renders the lines and polygons of the layer at their current position on the map
+func (l *GeoJSONLayer) draw(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	size := l.m.Size()
+	if size.Width <= 0 || size.Height <= 0 {
+		return img
+	}
+	scale := float32(w) / size.Width
+	project := func(p [2]float64) (float32, float32) {
+		pos := l.m.LatLonToPixel(p[1], p[0])
+		return pos.X * scale, pos.Y * scale
+	}
+
+	r := &vector.Rasterizer{}
+	for _, f := range l.features {
+		style := l.styleFor(f)
+		if isVisibleColor(style.FillColor) {
+			for _, polygon := range f.polygons {
+				r.Reset(w, h)
+				for _, ring := range polygon {
+					addRing(r, ring, project)
+				}
+				r.Draw(img, img.Bounds(), image.NewUniform(style.FillColor), image.Point{})
+			}
+		}
+
+		if !isVisibleColor(style.StrokeColor) || style.StrokeWidth <= 0 {
+			continue
+		}
+		width := style.StrokeWidth * scale
+		lines := append([][][2]float64{}, f.lines...)
+		for _, polygon := range f.polygons {
+			for _, ring := range polygon {
+				lines = append(lines, ring)
+			}
+		}
+		for _, line := range lines {
+			r.Reset(w, h)
+			addPolyline(r, line, width, project)
+			r.Draw(img, img.Bounds(), image.NewUniform(style.StrokeColor), image.Point{})
+		}
+	}
+	return img
+}
+
+// addRing adds a closed path through the positions to the rasterizer
+func addRing(r *vector.Rasterizer, ring [][2]float64, project func([2]float64) (float32, float32)) {
+	if len(ring) < 3 {
+		return
+	}
+	r.MoveTo(project(ring[0]))
+	for _, p := range ring[1:] {
+		r.LineTo(project(p))
+	}
+	r.ClosePath()
+}
+
+// addPolyline adds a line of the width through the positions to the rasterizer, as a rectangle for each segment
+// and a square at each joint so that corners are not left open
+func addPolyline(r *vector.Rasterizer, line [][2]float64, width float32, project func([2]float64) (float32, float32)) {
+	half := width / 2
+	for i := 0; i+1 < len(line); i++ {
+		x1, y1 := project(line[i])
+		x2, y2 := project(line[i+1])
+		dx, dy := x2-x1, y2-y1
+		length := float32(math.Hypot(float64(dx), float64(dy)))
+		if length == 0 {
+			continue
+		}
+		// nx, ny is perpendicular to the segment with a length of half the width
+		nx, ny := -dy/length*half, dx/length*half
+		r.MoveTo(x1+nx, y1+ny)
+		r.LineTo(x2+nx, y2+ny)
+		r.LineTo(x2-nx, y2-ny)
+		r.LineTo(x1-nx, y1-ny)
+		r.ClosePath()
+
+		if i > 0 {
+			// wound the same way as the segments so that the overlaps do not cancel out
+			r.MoveTo(x1-half, y1-half)
+			r.LineTo(x1-half, y1+half)
+			r.LineTo(x1+half, y1+half)
+			r.LineTo(x1+half, y1-half)
+			r.ClosePath()
+		}
+	}
+}
+
+func isVisibleColor(c color.Color) bool {
+	if c == nil {
+		return false
+	}
+	_, _, _, a := c.RGBA()
+	return a > 0
+}
+
+// geoJSONObjects returns the objects of the GeoJSON layers in the order they were added
+func (m *Map) geoJSONObjects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{}
+	for _, l := range m.geoJSONLayers {
+		objects = append(objects, l.objects()...)
+	}
+	return objects
+}
+
+// mapGeoJSONLayout stretches the rasters of the GeoJSON layers over the map and places the point markers
+type mapGeoJSONLayout struct {
+	m *Map
+}
+
+func (l *mapGeoJSONLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, o := range objects {
+		if raster, ok := o.(*canvas.Raster); ok {
+			raster.Resize(size)
+			raster.Move(fyne.NewPos(0, 0))
+			raster.Refresh()
+		}
+	}
+	(&mapMarkerLayout{m: l.m}).Layout(objects, size)
+}
+
+func (l *mapGeoJSONLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+// geoJSONObject holds any GeoJSON object, only the fields used by its type are set
+type geoJSONObject struct {
+	Type        string                 `json:"type"`
+	ID          interface{}            `json:"id"`
+	Properties  map[string]interface{} `json:"properties"`
+	Geometry    *geoJSONObject         `json:"geometry"`
+	Features    []geoJSONObject        `json:"features"`
+	Geometries  []geoJSONObject        `json:"geometries"`
+	Coordinates json.RawMessage        `json:"coordinates"`
+}
+
+// features returns the features of a FeatureCollection or Feature, or a feature without properties for a geometry
+func (o *geoJSONObject) features() ([]*GeoJSONFeature, error) {
+	switch o.Type {
+	case "FeatureCollection":
+		features := make([]*GeoJSONFeature, 0, len(o.Features))
+		for i := range o.Features {
+			if o.Features[i].Type != "Feature" {
+				return nil, errors.New("unsupported GeoJSON feature type " + o.Features[i].Type)
+			}
+			f, err := o.Features[i].features()
+			if err != nil {
+				return nil, err
+			}
+			features = append(features, f...)
+		}
+		return features, nil
+	case "Feature":
+		f := &GeoJSONFeature{ID: o.ID, Properties: o.Properties}
+		if f.Properties == nil {
+			f.Properties = map[string]interface{}{}
+		}
+		if o.Geometry != nil {
+			f.GeometryType = o.Geometry.Type
+			if err := o.Geometry.addGeometry(f); err != nil {
+				return nil, err
+			}
+		}
+		return []*GeoJSONFeature{f}, nil
+	default:
+		f := &GeoJSONFeature{GeometryType: o.Type, Properties: map[string]interface{}{}}
+		if err := o.addGeometry(f); err != nil {
+			return nil, err
+		}
+		return []*GeoJSONFeature{f}, nil
+	}
+}
+
+// addGeometry reads the coordinates of the geometry into the feature
+func (o *geoJSONObject) addGeometry(f *GeoJSONFeature) error {
+	var err error
+	switch o.Type {
+	case "Point":
+		var p []float64
+		if err = json.Unmarshal(o.Coordinates, &p); err == nil {
+			var pos [2]float64
+			if pos, err = toPosition(p); err == nil {
+				f.points = append(f.points, pos)
+			}
+		}
+	case "MultiPoint":
+		var ps [][]float64
+		if err = json.Unmarshal(o.Coordinates, &ps); err == nil {
+			var line [][2]float64
+			if line, err = toPositions(ps); err == nil {
+				f.points = append(f.points, line...)
+			}
+		}
+	case "LineString":
+		var ps [][]float64
+		if err = json.Unmarshal(o.Coordinates, &ps); err == nil {
+			var line [][2]float64
+			if line, err = toPositions(ps); err == nil {
+				f.lines = append(f.lines, line)
+			}
+		}
+	case "MultiLineString", "Polygon":
+		var ls [][][]float64
+		if err = json.Unmarshal(o.Coordinates, &ls); err == nil {
+			var lines [][][2]float64
+			if lines, err = toLines(ls); err == nil {
+				if o.Type == "Polygon" {
+					f.polygons = append(f.polygons, lines)
+				} else {
+					f.lines = append(f.lines, lines...)
+				}
+			}
+		}
+	case "MultiPolygon":
+		var polys [][][][]float64
+		if err = json.Unmarshal(o.Coordinates, &polys); err == nil {
+			for _, poly := range polys {
+				var rings [][][2]float64
+				if rings, err = toLines(poly); err != nil {
+					break
+				}
+				f.polygons = append(f.polygons, rings)
+			}
+		}
+	case "GeometryCollection":
+		for i := range o.Geometries {
+			if err = o.Geometries[i].addGeometry(f); err != nil {
+				break
+			}
+		}
+	default:
+		return errors.New("unsupported GeoJSON geometry type " + o.Type)
+	}
+	return err
+}
+
+// toPosition returns the longitude and latitude of a GeoJSON position, ignoring any altitude
+func toPosition(p []float64) ([2]float64, error) {
+	if len(p) < 2 {
+		return [2]float64{}, errInvalidGeoJSON
+	}
+	return [2]float64{p[0], p[1]}, nil
+}
+
+func toPositions(ps [][]float64) ([][2]float64, error) {
+	line := make([][2]float64, len(ps))
+	for i, p := range ps {
+		pos, err := toPosition(p)
+		if err != nil {
+			return nil, err
+		}
+		line[i] = pos
+	}
+	return line, nil
+}
+
+func toLines(ls [][][]float64) ([][][2]float64, error) {
+	lines := make([][][2]float64, len(ls))
+	for i, ps := range ls {
+		line, err := toPositions(ps)
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = line
+	}
+	return lines, nil
+}
@@ -0,0 +1,114 @@
+package widget
+
+import (
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Declare conformity with Layout interface
+var _ fyne.Layout = (*mapLocationLayout)(nil)
+
+const (
+	// mapLocationDotSize is the diameter of the dot showing the current location
+	mapLocationDotSize float32 = 16
+	// mapLocationHaloAlpha is the opacity of the fill of the accuracy circle
+	mapLocationHaloAlpha = 0x30
+)
+
+// mapLocation is the current location of the user, drawn as a dot inside a circle showing its accuracy
+type mapLocation struct {
+	lat, lon float64
+	accuracy float64 // radius in meters within which the user is likely to be
+	dot      *canvas.Circle
+	halo     *canvas.Circle
+}
+
+// SetCurrentLocation shows the current location of the user as a dot on the map, surrounded by a circle whose
+// radius is the accuracy in meters, so that a map can track the position reported by a geolocation source of
+// the app. Calling it again moves the dot, and if FollowLocation is on the map pans to keep it at the center.
+func (m *Map) SetCurrentLocation(lat, lon float64, accuracyMeters float64) {
+	if m.location == nil {
+		m.location = &mapLocation{dot: canvas.NewCircle(color.Transparent), halo: canvas.NewCircle(color.Transparent)}
+	}
+	m.location.lat, m.location.lon, m.location.accuracy = lat, lon, accuracyMeters
+	if m.followLocation {
+		m.centerOnTile(latLonToTile(lat, lon, m.zoom))
+	}
+	m.Refresh()
+}
+
+// ClearCurrentLocation removes the current location shown with SetCurrentLocation, for example when the
+// geolocation source stops reporting positions.
+func (m *Map) ClearCurrentLocation() {
+	m.location = nil
+	m.Refresh()
+}
+
+// FollowLocation sets whether the map pans to keep the current location at its center each time it is updated
+// with SetCurrentLocation. Turning it on centers the map straight away.
+func (m *Map) FollowLocation(follow bool) {
+	m.followLocation = follow
+	if follow && m.location != nil {
+		m.centerOnTile(latLonToTile(m.location.lat, m.location.lon, m.zoom))
+		m.Refresh()
+	}
+}
+
+// locationObjects returns the objects that draw the current location, if there is one
+func (m *Map) locationObjects() []fyne.CanvasObject {
+	if m.location == nil {
+		return nil
+	}
+
+	primary := theme.PrimaryColor()
+	r, g, b, _ := primary.RGBA()
+	halo := color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: mapLocationHaloAlpha}
+	m.location.halo.FillColor = halo
+	m.location.halo.StrokeColor = primary
+	m.location.halo.StrokeWidth = 1
+	m.location.dot.FillColor = primary
+	m.location.dot.StrokeColor = theme.BackgroundColor()
+	m.location.dot.StrokeWidth = 2
+	return []fyne.CanvasObject{m.location.halo, m.location.dot}
+}
+
+// metersPerPixelAt returns the distance on the ground covered by one unit at the latitude and the current zoom
+func (m *Map) metersPerPixelAt(lat float64) float64 {
+	return earthCircumference * math.Cos(lat*math.Pi/180) / float64(tileSize*(int(1)<<m.zoom))
+}
+
+// mapLocationLayout places the current location dot and its accuracy circle, hiding them while they are
+// outside of the map
+type mapLocationLayout struct {
+	m *Map
+}
+
+func (l *mapLocationLayout) Layout(_ []fyne.CanvasObject, size fyne.Size) {
+	location := l.m.location
+	if location == nil {
+		return
+	}
+
+	pos := l.m.LatLonToPixel(location.lat, location.lon)
+	radius := float32(location.accuracy / l.m.metersPerPixelAt(location.lat))
+	if pos.X < -radius || pos.Y < -radius || pos.X > size.Width+radius || pos.Y > size.Height+radius {
+		location.halo.Hide()
+		location.dot.Hide()
+		return
+	}
+
+	location.halo.Move(pos.SubtractXY(radius, radius))
+	location.halo.Resize(fyne.NewSize(radius*2, radius*2))
+	location.halo.Show()
+	location.dot.Move(pos.SubtractXY(mapLocationDotSize/2, mapLocationDotSize/2))
+	location.dot.Resize(fyne.NewSize(mapLocationDotSize, mapLocationDotSize))
+	location.dot.Show()
+}
+
+func (l *mapLocationLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
@@ -0,0 +1,259 @@
+package widget
+
+import (
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	defaultClusterRadius float32 = 40
+	markerSize           float32 = 16
+	clusterSize          float32 = 32
+)
+
+// Declare conformity with Layout interface
+var _ fyne.Layout = (*mapMarkerLayout)(nil)
+
+// MapMarker is a point of interest shown on a Map.
+type MapMarker struct {
+	Lat, Lon float64
+	// Icon is drawn centered on the location of the marker, if it is nil a dot is drawn instead
+	Icon fyne.Resource
+	// OnTapped is called when the marker is tapped
+	OnTapped func()
+}
+
+// NewMapMarker creates a marker for the latitude and longitude.
+func NewMapMarker(lat, lon float64) *MapMarker {
+	return &MapMarker{Lat: lat, Lon: lon}
+}
+
+// AddMarker adds the marker to the map.
+func (m *Map) AddMarker(marker *MapMarker) {
+	m.markers = append(m.markers, marker)
+	m.Refresh()
+}
+
+// Markers returns the markers that have been added to the map.
+func (m *Map) Markers() []*MapMarker {
+	return m.markers
+}
+
+// RemoveMarker removes the marker from the map.
+func (m *Map) RemoveMarker(marker *MapMarker) {
+	for i, existing := range m.markers {
+		if existing == marker {
+			m.markers = append(m.markers[:i], m.markers[i+1:]...)
+			m.Refresh()
+			return
+		}
+	}
+}
+
+// SetClusteringEnabled sets whether markers that are close together at the current zoom level are merged into
+// a single cluster showing the number of markers. Clusters split apart as the map is zoomed in,
+// and tapping a cluster zooms to show the markers it contains.
+func (m *Map) SetClusteringEnabled(enabled bool) {
+	m.clustering = enabled
+	m.Refresh()
+}
+
+// SetClusterRadius sets the distance within which markers are merged into a cluster when clustering is enabled.
+func (m *Map) SetClusterRadius(px float32) {
+	m.clusterRadius = px
+	m.Refresh()
+}
+
+// ZoomToMarkers zooms and pans the map so that all of the markers are shown, as far as the zoom levels allow.
+func (m *Map) ZoomToMarkers(markers []*MapMarker) {
+	if len(markers) == 0 {
+		return
+	}
+
+	minLat, maxLat, minLon, maxLon := markers[0].Lat, markers[0].Lat, markers[0].Lon, markers[0].Lon
+	for _, marker := range markers[1:] {
+		minLat, maxLat = math.Min(minLat, marker.Lat), math.Max(maxLat, marker.Lat)
+		minLon, maxLon = math.Min(minLon, marker.Lon), math.Max(maxLon, marker.Lon)
+	}
+
+	size := m.Size()
+	// leave half a tile around the markers so that they are not drawn on the edge of the map
+	available := float64(fyne.Min(size.Width, size.Height)) - tileSize
+	zoom := 0
+	for zoom < 19 {
+		left, top := latLonToTile(maxLat, minLon, zoom+1)
+		right, bottom := latLonToTile(minLat, maxLon, zoom+1)
+		if math.Max(right-left, bottom-top)*tileSize > available {
+			break
+		}
+		zoom++
+	}
+
+	m.zoom = zoom
+	x, y := latLonToTile((minLat+maxLat)/2, (minLon+maxLon)/2, zoom)
+	m.centerOnTile(x, y)
+	m.Refresh()
+}
+
+// markerObjects returns the objects that draw the markers, merging them into clusters if enabled
+func (m *Map) markerObjects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{}
+	for _, cluster := range m.clusterMarkers() {
+		if len(cluster) == 1 {
+			objects = append(objects, newMapMarkerWidget(cluster[0]))
+		} else {
+			objects = append(objects, newMapCluster(m, cluster))
+		}
+	}
+	return objects
+}
+
+// clusterMarkers groups the markers that are within the cluster radius of the first marker in a group.
+// Each marker is in a group of its own if clustering is disabled.
+func (m *Map) clusterMarkers() [][]*MapMarker {
+	clusters := [][]*MapMarker{}
+	if !m.clustering {
+		for _, marker := range m.markers {
+			clusters = append(clusters, []*MapMarker{marker})
+		}
+		return clusters
+	}
+
+	type point struct{ x, y float64 }
+	centers := []point{}
+	radius := float64(m.clusterRadius)
+	for _, marker := range m.markers {
+		x, y := latLonToTile(marker.Lat, marker.Lon, m.zoom)
+		p := point{x * tileSize, y * tileSize}
+		found := false
+		for i, center := range centers {
+			if math.Hypot(p.x-center.x, p.y-center.y) <= radius {
+				clusters[i] = append(clusters[i], marker)
+				found = true
+				break
+			}
+		}
+		if !found {
+			centers = append(centers, p)
+			clusters = append(clusters, []*MapMarker{marker})
+		}
+	}
+	return clusters
+}
+
+// mapMarkerLayout places each marker object centered on its location, hiding those outside the map
+type mapMarkerLayout struct {
+	m *Map
+}
+
+func (l *mapMarkerLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, o := range objects {
+		var lat, lon float64
+		switch marker := o.(type) {
+		case *mapMarkerWidget:
+			lat, lon = marker.marker.Lat, marker.marker.Lon
+		case *mapCluster:
+			lat, lon = marker.center()
+		default:
+			continue
+		}
+
+		pos := l.m.LatLonToPixel(lat, lon)
+		if pos.X < 0 || pos.Y < 0 || pos.X > size.Width || pos.Y > size.Height {
+			o.Hide()
+			continue
+		}
+		min := o.MinSize()
+		o.Resize(min)
+		o.Move(pos.SubtractXY(min.Width/2, min.Height/2))
+		o.Show()
+	}
+}
+
+func (l *mapMarkerLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+// mapMarkerWidget draws a single marker
+type mapMarkerWidget struct {
+	widget.BaseWidget
+
+	marker *MapMarker
+}
+
+func newMapMarkerWidget(marker *MapMarker) *mapMarkerWidget {
+	w := &mapMarkerWidget{marker: marker}
+	w.ExtendBaseWidget(w)
+	return w
+}
+
+func (w *mapMarkerWidget) CreateRenderer() fyne.WidgetRenderer {
+	if w.marker.Icon != nil {
+		return widget.NewSimpleRenderer(canvas.NewImageFromResource(w.marker.Icon))
+	}
+
+	dot := canvas.NewCircle(theme.PrimaryColor())
+	dot.StrokeColor = theme.BackgroundColor()
+	dot.StrokeWidth = 2
+	return widget.NewSimpleRenderer(dot)
+}
+
+func (w *mapMarkerWidget) MinSize() fyne.Size {
+	if w.marker.Icon != nil {
+		return fyne.NewSize(theme.IconInlineSize()*2, theme.IconInlineSize()*2)
+	}
+	return fyne.NewSize(markerSize, markerSize)
+}
+
+func (w *mapMarkerWidget) Tapped(_ *fyne.PointEvent) {
+	if w.marker.OnTapped != nil {
+		w.marker.OnTapped()
+	}
+}
+
+// mapCluster draws a group of markers as a circle showing how many it contains
+type mapCluster struct {
+	widget.BaseWidget
+
+	m       *Map
+	markers []*MapMarker
+}
+
+func newMapCluster(m *Map, markers []*MapMarker) *mapCluster {
+	c := &mapCluster{m: m, markers: markers}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// center returns the mean location of the markers in the cluster
+func (c *mapCluster) center() (lat, lon float64) {
+	for _, marker := range c.markers {
+		lat += marker.Lat
+		lon += marker.Lon
+	}
+	return lat / float64(len(c.markers)), lon / float64(len(c.markers))
+}
+
+func (c *mapCluster) CreateRenderer() fyne.WidgetRenderer {
+	circle := canvas.NewCircle(theme.PrimaryColor())
+	circle.StrokeColor = theme.BackgroundColor()
+	circle.StrokeWidth = 2
+	count := canvas.NewText(strconv.Itoa(len(c.markers)), theme.BackgroundColor())
+	count.Alignment = fyne.TextAlignCenter
+	count.TextStyle.Bold = true
+	return widget.NewSimpleRenderer(container.NewStack(circle, container.NewCenter(count)))
+}
+
+func (c *mapCluster) MinSize() fyne.Size {
+	return fyne.NewSize(clusterSize, clusterSize)
+}
+
+func (c *mapCluster) Tapped(_ *fyne.PointEvent) {
+	c.m.ZoomToMarkers(c.markers)
+}
@@ -0,0 +1,80 @@
+package widget
+
+import (
+	"fyne.io/fyne/v2"
+)
+
+// Declare conformity with Layout interface
+var _ fyne.Layout = (*mapOverlayLayout)(nil)
+
+// mapOverlay is a canvas object anchored to a geographic location
+type mapOverlay struct {
+	lat, lon float64
+	obj      fyne.CanvasObject
+	// anchor is the point of the object placed on the location, as a fraction of its size
+	anchor fyne.Position
+}
+
+// AddOverlay adds a canvas object, such as an info card, that is shown on the map at the latitude and longitude.
+// The top-left of the object is placed on the location unless an anchor is set with SetOverlayAnchor.
+// The object follows the location as the map is panned and zoomed, and it is hidden while the location is
+// outside of the map. The object is shown at its minimum size.
+func (m *Map) AddOverlay(lat, lon float64, obj fyne.CanvasObject) {
+	m.overlays = append(m.overlays, &mapOverlay{lat: lat, lon: lon, obj: obj})
+	m.Refresh()
+}
+
+// RemoveOverlay removes an object added with AddOverlay from the map.
+func (m *Map) RemoveOverlay(obj fyne.CanvasObject) {
+	for i, overlay := range m.overlays {
+		if overlay.obj == obj {
+			m.overlays = append(m.overlays[:i], m.overlays[i+1:]...)
+			m.Refresh()
+			return
+		}
+	}
+}
+
+// SetOverlayAnchor sets the point of an overlay object that is placed on its location, as a fraction of the size
+// of the object. For example (0.5, 1) places the bottom center of a callout on the location.
+func (m *Map) SetOverlayAnchor(obj fyne.CanvasObject, anchor fyne.Position) {
+	for _, overlay := range m.overlays {
+		if overlay.obj == obj {
+			overlay.anchor = anchor
+			m.Refresh()
+			return
+		}
+	}
+}
+
+// overlayObjects returns the objects of the overlays in the order they were added
+func (m *Map) overlayObjects() []fyne.CanvasObject {
+	objects := make([]fyne.CanvasObject, len(m.overlays))
+	for i, overlay := range m.overlays {
+		objects[i] = overlay.obj
+	}
+	return objects
+}
+
+// mapOverlayLayout places each overlay object at its location, hiding those whose location is outside the map
+type mapOverlayLayout struct {
+	m *Map
+}
+
+func (l *mapOverlayLayout) Layout(_ []fyne.CanvasObject, size fyne.Size) {
+	for _, overlay := range l.m.overlays {
+		pos := l.m.LatLonToPixel(overlay.lat, overlay.lon)
+		if pos.X < 0 || pos.Y < 0 || pos.X > size.Width || pos.Y > size.Height {
+			overlay.obj.Hide()
+			continue
+		}
+		min := overlay.obj.MinSize()
+		overlay.obj.Resize(min)
+		overlay.obj.Move(pos.SubtractXY(min.Width*overlay.anchor.X, min.Height*overlay.anchor.Y))
+		overlay.obj.Show()
+	}
+}
+
+func (l *mapOverlayLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
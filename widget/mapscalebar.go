@@ -0,0 +1,140 @@
+package widget
+
+import (
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	// earthCircumference is the length of the equator in meters as used by the Web Mercator projection
+	earthCircumference = 40075016.686
+	// scaleBarMaxWidth is the longest that the scale bar is drawn
+	scaleBarMaxWidth float32 = 100
+	scaleBarTick     float32 = 6
+)
+
+// SetShowScaleBar sets whether a scale bar showing distances at the center of the map is drawn.
+func (m *Map) SetShowScaleBar(show bool) {
+	m.showScaleBar = show
+	if m.scaleBar == nil {
+		return
+	}
+
+	if show {
+		m.scaleBar.Show()
+	} else {
+		m.scaleBar.Hide()
+	}
+	m.Refresh()
+}
+
+// metersPerPixel returns the distance on the ground covered by one unit at the center of the map
+func (m *Map) metersPerPixel() float64 {
+	centerX, centerY := m.centerTile()
+	lat, _ := tileToLatLon(centerX, centerY, m.zoom)
+	return m.metersPerPixelAt(lat)
+}
+
+// scaleBarLength returns the longest round distance, 1, 2 or 5 times a power of ten meters,
+// that fits within the maximum width and the width at which it is drawn.
+func scaleBarLength(metersPerPixel float64, maxWidth float32) (meters float64, width float32) {
+	maxMeters := metersPerPixel * float64(maxWidth)
+	if maxMeters <= 0 {
+		return 0, 0
+	}
+
+	power := math.Pow(10, math.Floor(math.Log10(maxMeters)))
+	meters = power
+	for _, step := range []float64{2, 5} {
+		if step*power <= maxMeters {
+			meters = step * power
+		}
+	}
+	return meters, float32(meters / metersPerPixel)
+}
+
+// formatDistance returns the distance in meters, or kilometers for distances of 1000 meters or more
+func formatDistance(meters float64) string {
+	if meters >= 1000 {
+		return strconv.FormatFloat(meters/1000, 'f', -1, 64) + " km"
+	}
+	return strconv.FormatFloat(meters, 'f', -1, 64) + " m"
+}
+
+// mapScaleBar draws a bar of a round distance at the current zoom and latitude of the map
+type mapScaleBar struct {
+	widget.BaseWidget
+
+	m *Map
+}
+
+func newMapScaleBar(m *Map) *mapScaleBar {
+	s := &mapScaleBar{m: m}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *mapScaleBar) CreateRenderer() fyne.WidgetRenderer {
+	r := &mapScaleBarRenderer{s: s,
+		bar:   canvas.NewLine(theme.ForegroundColor()),
+		left:  canvas.NewLine(theme.ForegroundColor()),
+		right: canvas.NewLine(theme.ForegroundColor()),
+		label: canvas.NewText("", theme.ForegroundColor()),
+	}
+	r.bar.StrokeWidth = 2
+	r.left.StrokeWidth = 2
+	r.right.StrokeWidth = 2
+	r.label.TextSize = theme.CaptionTextSize()
+	r.Refresh()
+	return r
+}
+
+type mapScaleBarRenderer struct {
+	s *mapScaleBar
+
+	bar, left, right *canvas.Line
+	label            *canvas.Text
+	width            float32
+}
+
+func (r *mapScaleBarRenderer) Destroy() {
+}
+
+func (r *mapScaleBarRenderer) Layout(size fyne.Size) {
+	bottom := size.Height - 1
+	r.bar.Position1 = fyne.NewPos(0, bottom)
+	r.bar.Position2 = fyne.NewPos(r.width, bottom)
+	r.left.Position1 = fyne.NewPos(0, bottom-scaleBarTick)
+	r.left.Position2 = fyne.NewPos(0, bottom)
+	r.right.Position1 = fyne.NewPos(r.width, bottom-scaleBarTick)
+	r.right.Position2 = fyne.NewPos(r.width, bottom)
+
+	labelSize := r.label.MinSize()
+	r.label.Move(fyne.NewPos(theme.Padding(), bottom-scaleBarTick/2-labelSize.Height))
+	r.label.Resize(labelSize)
+}
+
+func (r *mapScaleBarRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(scaleBarMaxWidth, r.label.MinSize().Height+scaleBarTick/2+1)
+}
+
+func (r *mapScaleBarRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.bar, r.left, r.right, r.label}
+}
+
+func (r *mapScaleBarRenderer) Refresh() {
+	meters, width := scaleBarLength(r.s.m.metersPerPixel(), scaleBarMaxWidth)
+	r.width = width
+	r.label.Text = formatDistance(meters)
+	for _, line := range []*canvas.Line{r.bar, r.left, r.right} {
+		line.StrokeColor = theme.ForegroundColor()
+	}
+	r.label.Color = theme.ForegroundColor()
+	r.Layout(r.s.Size())
+	canvas.Refresh(r.s)
+}
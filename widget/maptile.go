@@ -0,0 +1,295 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/nfnt/resize"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	tileFadeDuration = 250 * time.Millisecond
+	tileRetries      = 2                      // further attempts made after a tile fails to download
+	tileRetryDelay   = 250 * time.Millisecond // doubled after each failed retry
+
+	defaultPrefetchMargin = 1
+)
+
+// tilePlaceholderColor is drawn where a tile is still loading
+var tilePlaceholderColor = color.NRGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+
+type mapTileKey struct {
+	zoom, x, y int
+	hiDPI      bool // the tile is downloaded from the high DPI tile source
+}
+
+// mapTile is the loading state of a single tile. A tile with no image that has not failed is still loading.
+type mapTile struct {
+	img    image.Image
+	scaled image.Image // img resized for the scale of the canvas
+	loaded time.Time   // when the image arrived, zero if it was already cached and should not fade in
+	failed bool
+}
+
+// tile returns the tile image, resized to the tile size, and how far it has faded in, from 0 to 1.
+// If the tile is not available the image is nil and failed reports whether it could not be downloaded.
+// Tiles that are not cached are downloaded in the background.
+func (m *Map) tile(key mapTileKey, tileSize int) (img image.Image, alpha float32, failed bool) {
+	m.tileLock.Lock()
+	defer m.tileLock.Unlock()
+	if m.tiles == nil {
+		m.tiles = make(map[mapTileKey]*mapTile)
+	}
+
+	t, ok := m.tiles[key]
+	if !ok {
+		t = &mapTile{}
+		m.tiles[key] = t
+		if cached, ok := cachedTile(m.sourceFor(key), key.x, key.y, key.zoom); ok {
+			t.img = cached
+		} else {
+			go m.loadTile(key, t)
+		}
+	}
+	if t.img == nil {
+		return nil, 0, t.failed
+	}
+
+	if t.scaled == nil || t.scaled.Bounds().Dx() != tileSize {
+		t.scaled = t.img
+		if t.img.Bounds().Dx() != tileSize {
+			t.scaled = resize.Resize(uint(tileSize), uint(tileSize), t.img, resize.Lanczos2)
+		}
+	}
+	alpha = 1
+	if !t.loaded.IsZero() {
+		if elapsed := time.Since(t.loaded); elapsed < tileFadeDuration {
+			alpha = float32(elapsed) / float32(tileFadeDuration)
+		} else {
+			t.loaded = time.Time{}
+		}
+	}
+	return t.scaled, alpha, false
+}
+
+// loadTile downloads the tile, retrying with increasing delays if it fails
+func (m *Map) loadTile(key mapTileKey, t *mapTile) {
+	delay := tileRetryDelay
+	for attempt := 0; ; attempt++ {
+		img, err := getTile(m.sourceFor(key), key.x, key.y, key.zoom, m.cl)
+		if err == nil {
+			m.tileLock.Lock()
+			t.img = img
+			t.loaded = time.Now()
+			m.tileLock.Unlock()
+			m.fadeInTiles()
+			m.checkTilesLoaded()
+			return
+		}
+		if attempt == tileRetries {
+			fyne.LogError("tile fetch error", err)
+			m.tileLock.Lock()
+			t.failed = true
+			onTileError := m.onTileError
+			m.tileLock.Unlock()
+			m.refreshTiles()
+			if onTileError != nil {
+				onTileError(key.zoom, key.x, key.y, err)
+			}
+			m.checkTilesLoaded()
+			return
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// setVisibleTiles records the tiles that are shown, so that the map can tell when they have finished loading
+func (m *Map) setVisibleTiles(keys []mapTileKey) {
+	m.tileLock.Lock()
+	m.visibleTiles = keys
+	m.tileLock.Unlock()
+	m.checkTilesLoaded()
+}
+
+// checkTilesLoaded calls the tiles loaded callback if the visible tiles that were loading have all loaded or failed
+func (m *Map) checkTilesLoaded() {
+	m.tileLock.Lock()
+	loading := false
+	for _, key := range m.visibleTiles {
+		if t, ok := m.tiles[key]; ok && t.img == nil && !t.failed {
+			loading = true
+			break
+		}
+	}
+	finished := m.loadingVisible && !loading
+	m.loadingVisible = loading
+	onTilesLoaded := m.onTilesLoaded
+	m.tileLock.Unlock()
+
+	if finished && onTilesLoaded != nil {
+		onTilesLoaded()
+	}
+}
+
+// fadeInTiles redraws the tiles until the most recently loaded one has faded in
+func (m *Map) fadeInTiles() {
+	m.tileLock.Lock()
+	if m.fading {
+		m.fadeAgain = true
+		m.tileLock.Unlock()
+		return
+	}
+	m.fading = true
+	m.tileLock.Unlock()
+
+	fyne.NewAnimation(tileFadeDuration, func(progress float32) {
+		m.refreshTiles()
+		if progress < 1 {
+			return
+		}
+		m.tileLock.Lock()
+		again := m.fadeAgain
+		m.fading, m.fadeAgain = false, false
+		m.tileLock.Unlock()
+		if again {
+			m.fadeInTiles()
+		}
+	}).Start()
+}
+
+// tilesAround returns the tiles within the prefetch margin of the visible tiles, nearest first
+func (m *Map) tilesAround(firstX, firstY, lastX, lastY int, hiDPI bool) []mapTileKey {
+	count := 1 << m.zoom
+	keys := []mapTileKey{}
+	for ring := 1; ring <= m.prefetchMargin; ring++ {
+		for x := firstX - ring; x <= lastX+ring; x++ {
+			for y := firstY - ring; y <= lastY+ring; y++ {
+				onRing := x == firstX-ring || x == lastX+ring || y == firstY-ring || y == lastY+ring
+				if !onRing || x < 0 || y < 0 || x >= count || y >= count {
+					continue
+				}
+				keys = append(keys, mapTileKey{zoom: m.zoom, x: x, y: y, hiDPI: hiDPI})
+			}
+		}
+	}
+	return keys
+}
+
+// prefetchTiles downloads the tiles in the background so that they are cached before they are shown.
+// The tiles replace any that are still waiting from a previous view.
+func (m *Map) prefetchTiles(keys []mapTileKey) {
+	m.tileLock.Lock()
+	defer m.tileLock.Unlock()
+	m.prefetchQueue = keys
+	if len(keys) == 0 || m.prefetching {
+		return
+	}
+	m.prefetching = true
+	go m.prefetch()
+}
+
+// prefetch downloads the queued tiles one at a time, leaving the visible tiles to load first.
+// Tiles that are already cached or being loaded for display are skipped.
+func (m *Map) prefetch() {
+	for {
+		m.tileLock.Lock()
+		if len(m.prefetchQueue) == 0 {
+			m.prefetching = false
+			m.tileLock.Unlock()
+			return
+		}
+		key := m.prefetchQueue[0]
+		m.prefetchQueue = m.prefetchQueue[1:]
+		_, loading := m.tiles[key]
+		m.tileLock.Unlock()
+
+		source := m.sourceFor(key)
+		if _, cached := cachedTile(source, key.x, key.y, key.zoom); loading || cached {
+			continue
+		}
+		// failures are ignored, the tile is downloaded again if it is shown
+		_, _ = getTile(source, key.x, key.y, key.zoom, m.cl)
+	}
+}
+
+// pruneTiles forgets the tiles that are neither visible nor within the prefetch margin, so that the tiles of
+// earlier views are not kept while the map is panned and zoomed. They are still cached once downloaded.
+func (m *Map) pruneTiles(visible, around []mapTileKey) {
+	keep := make(map[mapTileKey]bool, len(visible)+len(around))
+	for _, key := range visible {
+		keep[key] = true
+	}
+	for _, key := range around {
+		keep[key] = true
+	}
+
+	m.tileLock.Lock()
+	defer m.tileLock.Unlock()
+	for key := range m.tiles {
+		if !keep[key] {
+			delete(m.tiles, key)
+		}
+	}
+}
+
+// sourceFor returns the url format used to download the tile
+func (m *Map) sourceFor(key mapTileKey) string {
+	if !key.hiDPI {
+		return m.tileSource
+	}
+	if m.hiDPITileSource != "" {
+		return m.hiDPITileSource
+	}
+	ext := path.Ext(m.tileSource)
+	return strings.TrimSuffix(m.tileSource, ext) + "@2x" + ext
+}
+
+// refreshTiles redraws the tiles without updating the rest of the map
+func (m *Map) refreshTiles() {
+	if m.raster != nil {
+		m.raster.Refresh()
+	}
+}
+
+// forgetFailedTiles allows tiles that failed to download to be tried again
+func (m *Map) forgetFailedTiles() {
+	m.tileLock.Lock()
+	defer m.tileLock.Unlock()
+	for key, t := range m.tiles {
+		if t.failed {
+			delete(m.tiles, key)
+		}
+	}
+}
+
+// drawTile draws a tile, or a placeholder if it is loading or failed, at the position
+func drawTile(pixels *image.NRGBA, pos image.Point, tileSize int, img image.Image, alpha float32, failed bool) {
+	rect := image.Rect(pos.X, pos.Y, pos.X+tileSize, pos.Y+tileSize)
+	if img != nil && alpha >= 1 {
+		draw.Copy(pixels, pos, img, image.Rect(0, 0, tileSize, tileSize), draw.Over, nil)
+		return
+	}
+
+	draw.Draw(pixels, rect, image.NewUniform(tilePlaceholderColor), image.Point{}, draw.Src)
+	if img != nil {
+		mask := image.NewUniform(color.Alpha{A: uint8(alpha * 0xff)})
+		draw.DrawMask(pixels, rect, img, image.Point{}, mask, image.Point{}, draw.Over)
+	} else if failed {
+		// mark the tile with a cross in the error color
+		errorColor := theme.ErrorColor()
+		inset := tileSize / 4
+		for i := inset; i < tileSize-inset; i++ {
+			pixels.Set(pos.X+i, pos.Y+i, errorColor)
+			pixels.Set(pos.X+tileSize-1-i, pos.Y+i, errorColor)
+		}
+	}
+}
@@ -1,38 +1,138 @@
 package widget
 
 import (
+	"errors"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/driver/mobile"
 	"fyne.io/fyne/v2/widget"
 )
 
+// NumericalInputMode selects the number formats that a NumericalEntry accepts.
+// Modes can be combined using a bitwise or, for example ModeDecimal | ModeHex.
+type NumericalInputMode int
+
+const (
+	// ModeDecimal accepts base 10 numbers, fractions are only allowed if AllowFloat is set.
+	ModeDecimal NumericalInputMode = 1 << iota
+	// ModeScientific accepts base 10 numbers with an optional exponent, like "1.2e-3".
+	ModeScientific
+	// ModeHex accepts base 16 integers prefixed by "0x", like "0x1F".
+	ModeHex
+)
+
+// PastePolicy selects how a NumericalEntry handles pasted text that contains characters which are not valid.
+type PastePolicy int
+
+const (
+	// PasteFilter inserts the valid characters of the pasted text and drops the others.
+	PasteFilter PastePolicy = iota
+	// PasteReject ignores the paste unless all of the pasted text is valid.
+	PasteReject
+)
+
+var (
+	intPartialPattern        = regexp.MustCompile(`^[0-9]*$`)
+	floatPartialPattern      = regexp.MustCompile(`^[0-9]*([.,][0-9]*)?$`)
+	scientificPartialPattern = regexp.MustCompile(`^([0-9]+([.,][0-9]*)?|[.,][0-9]+)([eE][+-]?[0-9]*)?$`)
+	hexPartialPattern        = regexp.MustCompile(`^0([xX][0-9a-fA-F]*)?$`)
+
+	errNotANumber = errors.New("not a valid number")
+)
+
 // NumericalEntry is an extended entry that only allows numerical input.
 // Only integers are allowed by default. Support for floats can be enabled by setting AllowFloat.
+// Other number formats can be enabled using SetInputMode.
 type NumericalEntry struct {
 	widget.Entry
 	AllowFloat bool
+	// OnValueChanged is called as the user edits the number with the parsed value and whether it is valid.
+	// The value is invalid if the text cannot be parsed or the Validator of the entry returns an error.
+	// If a delay is set with SetValueChangedDelay it is called on the goroutine of a timer rather than the one that
+	// changed the text, with the value the text had when it was last changed.
+	OnValueChanged func(value float64, valid bool)
+
+	inputMode     NumericalInputMode
+	pastePolicy   PastePolicy
+	allowNegative bool
+
+	valueLock         sync.Mutex
+	valueDelay        time.Duration
+	valueTimer        *time.Timer
+	valueNotifiedText string
 }
 
 // NewNumericalEntry returns an extended entry that only allows numerical input.
 func NewNumericalEntry() *NumericalEntry {
-	entry := &NumericalEntry{}
+	entry := &NumericalEntry{inputMode: ModeDecimal}
 	entry.ExtendBaseWidget(entry)
 	return entry
 }
 
+// GetValue parses the current text using the configured input modes and returns the resulting value.
+// Hexadecimal input is returned as the equivalent integer value.
+func (e *NumericalEntry) GetValue() (float64, error) {
+	return e.parse(e.Text)
+}
+
+// SetText sets the text of the entry and notifies OnValueChanged if the number changed.
+func (e *NumericalEntry) SetText(text string) {
+	e.Entry.SetText(text)
+	e.valueChanged()
+}
+
+// SetInputMode sets the number formats that are accepted while typing and when parsing the value.
+func (e *NumericalEntry) SetInputMode(mode NumericalInputMode) {
+	e.inputMode = mode
+}
+
+// SetAllowNegative sets whether a single leading minus sign may be typed to enter a negative number.
+// Negative numbers are not allowed by default, and the sign is removed from pasted text while they are not.
+func (e *NumericalEntry) SetAllowNegative(allow bool) {
+	e.allowNegative = allow
+}
+
+// SetPastePolicy sets how pasted text that contains invalid characters is handled.
+// By default only the valid characters are inserted.
+func (e *NumericalEntry) SetPastePolicy(policy PastePolicy) {
+	e.pastePolicy = policy
+}
+
+// SetValueChangedDelay sets how long the text must be left unchanged before OnValueChanged is called,
+// so that typing a long number does not call it for every digit. By default it is called on each change.
+func (e *NumericalEntry) SetValueChangedDelay(delay time.Duration) {
+	e.valueLock.Lock()
+	e.valueDelay = delay
+	e.valueLock.Unlock()
+}
+
+// TypedKey is called when this item receives a key event.
+//
+// Implements: fyne.Focusable
+func (e *NumericalEntry) TypedKey(key *fyne.KeyEvent) {
+	e.Entry.TypedKey(key)
+	e.valueChanged()
+}
+
 // TypedRune is called when this item receives a char event.
 //
 // Implements: fyne.Focusable
 func (e *NumericalEntry) TypedRune(r rune) {
-	if r >= '0' && r <= '9' {
-		e.Entry.TypedRune(r)
-		return
+	text := []rune(e.Text)
+	col := e.CursorColumn
+	if col > len(text) {
+		col = len(text)
 	}
+	candidate := string(text[:col]) + string(r) + string(text[col:])
 
-	if e.AllowFloat && (r == '.' || r == ',') {
+	if e.isPartialNumber(candidate) {
 		e.Entry.TypedRune(r)
+		e.valueChanged()
 	}
 }
 
@@ -40,30 +140,172 @@ func (e *NumericalEntry) TypedRune(r rune) {
 //
 // Implements: fyne.Shortcutable
 func (e *NumericalEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	defer e.valueChanged()
 	paste, ok := shortcut.(*fyne.ShortcutPaste)
 	if !ok {
 		e.Entry.TypedShortcut(shortcut)
 		return
 	}
 
-	if e.isNumber(paste.Clipboard.Content()) {
-		e.Entry.TypedShortcut(shortcut)
+	content := paste.Clipboard.Content()
+	if !e.allowNegative {
+		content = strings.TrimPrefix(content, "-")
+	}
+	filtered := e.filterPaste(content)
+	if filtered == "" || (e.pastePolicy == PasteReject && filtered != content) {
+		return
 	}
+	e.Entry.TypedShortcut(&fyne.ShortcutPaste{Clipboard: &pasteClipboard{content: filtered}})
 }
 
 // Keyboard sets up the right keyboard to use on mobile.
 //
 // Implements: mobile.Keyboardable
 func (e *NumericalEntry) Keyboard() mobile.KeyboardType {
+	if e.mode()&ModeHex != 0 {
+		return mobile.DefaultKeyboard
+	}
 	return mobile.NumberKeyboard
 }
 
-func (e *NumericalEntry) isNumber(content string) bool {
-	if e.AllowFloat {
-		_, err := strconv.ParseFloat(content, 64)
-		return err == nil
+// isPartialNumber returns true if the text is a number, or could become one as the user keeps typing.
+// A leading minus sign is accepted if negative numbers are allowed.
+func (e *NumericalEntry) isPartialNumber(text string) bool {
+	if e.allowNegative && strings.HasPrefix(text, "-") {
+		text = text[1:]
+		if text == "" {
+			// the digits are still to be typed
+			return true
+		}
+	}
+	return e.isPartialUnsigned(text)
+}
+
+// isPartialUnsigned returns true if the text is a number without a sign, or could become one.
+func (e *NumericalEntry) isPartialUnsigned(text string) bool {
+	mode := e.mode()
+	if mode&ModeHex != 0 && hexPartialPattern.MatchString(text) {
+		return true
+	}
+	if mode&ModeScientific != 0 && scientificPartialPattern.MatchString(text) {
+		return true
+	}
+	if mode&(ModeDecimal|ModeScientific) == 0 {
+		return false
+	}
+	if e.AllowFloat || mode&ModeScientific != 0 {
+		return floatPartialPattern.MatchString(text)
+	}
+	return intPartialPattern.MatchString(text)
+}
+
+// filterPaste returns the characters of the pasted text that would be accepted if they were typed at the cursor.
+// If text is selected it will be replaced, so the pasted text is checked on its own.
+func (e *NumericalEntry) filterPaste(content string) string {
+	before, after := "", ""
+	if e.SelectedText() == "" {
+		text := []rune(e.Text)
+		col := e.CursorColumn
+		if col > len(text) {
+			col = len(text)
+		}
+		before, after = string(text[:col]), string(text[col:])
+	}
+
+	accepted := []rune{}
+	for _, r := range content {
+		if e.isPartialNumber(before + string(accepted) + string(r) + after) {
+			accepted = append(accepted, r)
+		}
+	}
+	return string(accepted)
+}
+
+// valueChanged calls OnValueChanged if the text has changed since it was last called,
+// immediately or once the text has been left unchanged for the delay
+func (e *NumericalEntry) valueChanged() {
+	if e.OnValueChanged == nil {
+		return
+	}
+	e.valueLock.Lock()
+	if e.Text == e.valueNotifiedText {
+		e.valueLock.Unlock()
+		return
+	}
+	e.valueNotifiedText = e.Text
+
+	if e.valueTimer != nil {
+		e.valueTimer.Stop()
+	}
+	f := e.OnValueChanged
+	value, valid := e.textValue(e.Text)
+	if e.valueDelay > 0 {
+		// the value is worked out now, so that the timer does not read the entry while it is edited
+		e.valueTimer = time.AfterFunc(e.valueDelay, func() {
+			f(value, valid)
+		})
+		e.valueLock.Unlock()
+		return
+	}
+	e.valueLock.Unlock()
+	f(value, valid)
+}
+
+// textValue returns the value of the text and whether it is valid, as passed to OnValueChanged
+func (e *NumericalEntry) textValue(text string) (float64, bool) {
+	value, err := e.parse(text)
+	valid := err == nil
+	if valid && e.Validator != nil {
+		valid = e.Validator(text) == nil
 	}
+	return value, valid
+}
+
+func (e *NumericalEntry) mode() NumericalInputMode {
+	if e.inputMode == 0 {
+		return ModeDecimal
+	}
+	return e.inputMode
+}
+
+func (e *NumericalEntry) parse(content string) (float64, error) {
+	if e.allowNegative && strings.HasPrefix(content, "-") {
+		value, err := e.parseUnsigned(content[1:])
+		return -value, err
+	}
+	return e.parseUnsigned(content)
+}
+
+func (e *NumericalEntry) parseUnsigned(content string) (float64, error) {
+	mode := e.mode()
+	if mode&ModeHex != 0 && len(content) > 2 && (content[:2] == "0x" || content[:2] == "0X") {
+		i, err := strconv.ParseUint(content[2:], 16, 64)
+		return float64(i), err
+	}
+
+	if !e.isPartialUnsigned(content) {
+		return 0, errNotANumber
+	}
+	if mode&ModeScientific != 0 || (mode&ModeDecimal != 0 && e.AllowFloat) {
+		return strconv.ParseFloat(strings.Replace(content, ",", ".", 1), 64)
+	}
+
+	if mode&ModeDecimal != 0 {
+		i, err := strconv.Atoi(content)
+		return float64(i), err
+	}
+	return 0, errNotANumber
+}
+
+// pasteClipboard supplies the filtered text when a paste is passed on to the Entry
+type pasteClipboard struct {
+	content string
+}
+
+func (c *pasteClipboard) Content() string {
+	return c.content
+}
 
-	_, err := strconv.Atoi(content)
-	return err == nil
+func (c *pasteClipboard) SetContent(content string) {
+	c.content = content
 }
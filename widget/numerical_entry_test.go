@@ -1,8 +1,12 @@
 package widget
 
 import (
+	"errors"
+	"strconv"
 	"testing"
+	"time"
 
+	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,3 +33,164 @@ func TestNumericalnEntry_Float(t *testing.T) {
 	test.Type(entry, number)
 	assert.Equal(t, number, entry.Text)
 }
+
+func TestNumericalEntry_Scientific(t *testing.T) {
+	entry := NewNumericalEntry()
+	entry.SetInputMode(ModeScientific)
+
+	test.Type(entry, "1.2e-3")
+	assert.Equal(t, "1.2e-3", entry.Text)
+	v, err := entry.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0012, v)
+
+	test.Type(entry, "x")
+	assert.Equal(t, "1.2e-3", entry.Text)
+}
+
+func TestNumericalEntry_Hex(t *testing.T) {
+	entry := NewNumericalEntry()
+	entry.SetInputMode(ModeDecimal | ModeHex)
+
+	test.Type(entry, "0x")
+	assert.Equal(t, "0x", entry.Text)
+	_, err := entry.GetValue()
+	assert.Error(t, err)
+
+	test.Type(entry, "1Fz")
+	assert.Equal(t, "0x1F", entry.Text)
+	v, err := entry.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(31), v)
+
+	entry.SetText("")
+	test.Type(entry, "42")
+	v, err = entry.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), v)
+}
+
+func TestNumericalEntry_Negative(t *testing.T) {
+	entry := NewNumericalEntry()
+	test.Type(entry, "-12")
+	assert.Equal(t, "12", entry.Text)
+
+	clipboard := test.NewClipboard()
+	clipboard.SetContent("-5")
+	entry.SetText("")
+	entry.SetPastePolicy(PasteReject)
+	entry.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "5", entry.Text)
+
+	entry.SetAllowNegative(true)
+	entry.AllowFloat = true
+	entry.SetText("")
+	test.Type(entry, "-1-.5")
+	assert.Equal(t, "-1.5", entry.Text)
+	v, err := entry.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, -1.5, v)
+
+	entry.SetText("")
+	test.Type(entry, "--")
+	assert.Equal(t, "-", entry.Text)
+	_, err = entry.GetValue()
+	assert.Error(t, err)
+
+	entry.SetText("")
+	entry.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "-5", entry.Text)
+
+	entry.SetInputMode(ModeHex)
+	entry.SetText("")
+	test.Type(entry, "-0x10")
+	assert.Equal(t, "-0x10", entry.Text)
+	v, err = entry.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(-16), v)
+}
+
+func TestNumericalEntry_Paste(t *testing.T) {
+	entry := NewNumericalEntry()
+	clipboard := test.NewClipboard()
+	clipboard.SetContent("12a3")
+	entry.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "123", entry.Text)
+	assert.Equal(t, 3, entry.CursorColumn)
+
+	clipboard.SetContent("x4")
+	entry.CursorColumn = 1
+	entry.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "1423", entry.Text)
+	assert.Equal(t, 2, entry.CursorColumn)
+
+	entry.SetPastePolicy(PasteReject)
+	clipboard.SetContent("5b")
+	entry.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "1423", entry.Text)
+	clipboard.SetContent("5")
+	entry.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "14523", entry.Text)
+}
+
+func TestNumericalEntry_OnValueChanged(t *testing.T) {
+	entry := NewNumericalEntry()
+	entry.AllowFloat = true
+	entry.Validator = func(s string) error {
+		if value, _ := strconv.ParseFloat(s, 64); value > 100 {
+			return errors.New("too large")
+		}
+		return nil
+	}
+	var values []float64
+	var valid []bool
+	entry.OnValueChanged = func(value float64, ok bool) {
+		values = append(values, value)
+		valid = append(valid, ok)
+	}
+
+	test.Type(entry, "1.5")
+	assert.Equal(t, []float64{1, 1, 1.5}, values)
+	assert.Equal(t, []bool{true, true, true}, valid)
+
+	// invalid characters are not typed, so do not change the value
+	test.Type(entry, "x")
+	assert.Len(t, values, 3)
+
+	entry.SetText("150")
+	assert.Equal(t, 150.0, values[3])
+	assert.False(t, valid[3])
+
+	entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	assert.Equal(t, 15.0, values[4])
+	assert.True(t, valid[4])
+}
+
+func TestNumericalEntry_SetValueChangedDelay(t *testing.T) {
+	entry := NewNumericalEntry()
+	entry.SetValueChangedDelay(50 * time.Millisecond)
+	changed := make(chan float64, 5)
+	entry.OnValueChanged = func(value float64, _ bool) {
+		changed <- value
+	}
+
+	test.Type(entry, "1234")
+	select {
+	case value := <-changed:
+		assert.Equal(t, 1234.0, value)
+	case <-time.After(time.Second):
+		t.Error("value change not notified")
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, changed)
+
+	// the value is taken from the text when it changed, not when the delay has passed
+	test.Type(entry, "5")
+	entry.Entry.Text = "abc"
+	select {
+	case value := <-changed:
+		assert.Equal(t, 12345.0, value)
+	case <-time.After(time.Second):
+		t.Error("value change not notified")
+	}
+}
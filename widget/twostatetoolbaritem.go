@@ -0,0 +1,94 @@
+package widget
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// TwoStateToolbarItem is a toolbar item that toggles between an off and an on state when tapped,
+// showing a different icon for each state.
+type TwoStateToolbarItem struct {
+	OffIcon     fyne.Resource
+	OnIcon      fyne.Resource
+	OnActivated func(on bool)
+
+	on       bool
+	disabled bool
+	button   *widget.Button
+}
+
+// NewTwoStateToolbarItem returns a new toolbar item that switches between the off and on icons when tapped.
+// The onActivated function is called with the new state after each tap.
+func NewTwoStateToolbarItem(offIcon, onIcon fyne.Resource, onActivated func(on bool)) *TwoStateToolbarItem {
+	return &TwoStateToolbarItem{OffIcon: offIcon, OnIcon: onIcon, OnActivated: onActivated}
+}
+
+// Disabled returns true if the item is disabled and ignoring taps.
+func (t *TwoStateToolbarItem) Disabled() bool {
+	return t.disabled
+}
+
+// SetDisabled sets whether the item is disabled. A disabled item renders dimmed icons and ignores taps.
+func (t *TwoStateToolbarItem) SetDisabled(disabled bool) {
+	t.disabled = disabled
+	if t.button == nil {
+		return
+	}
+
+	if disabled {
+		t.button.Disable()
+	} else {
+		t.button.Enable()
+	}
+}
+
+// GetOn returns true if the item is currently in the on state.
+func (t *TwoStateToolbarItem) GetOn() bool {
+	return t.on
+}
+
+// SetOn sets the state of the item and updates the displayed icon.
+// The OnActivated callback is not invoked.
+func (t *TwoStateToolbarItem) SetOn(on bool) {
+	t.on = on
+	t.updateIcon()
+}
+
+// ToolbarObject gets a button to render this item.
+//
+// Implements: widget.ToolbarItem
+func (t *TwoStateToolbarItem) ToolbarObject() fyne.CanvasObject {
+	if t.button == nil {
+		t.button = widget.NewButtonWithIcon("", t.icon(), t.tapped)
+		t.button.Importance = widget.LowImportance
+		if t.disabled {
+			t.button.Disable()
+		}
+	}
+	return t.button
+}
+
+func (t *TwoStateToolbarItem) icon() fyne.Resource {
+	if t.on {
+		return t.OnIcon
+	}
+	return t.OffIcon
+}
+
+func (t *TwoStateToolbarItem) tapped() {
+	if t.disabled {
+		return
+	}
+
+	t.SetOn(!t.on)
+	if t.OnActivated != nil {
+		t.OnActivated(t.on)
+	}
+}
+
+func (t *TwoStateToolbarItem) updateIcon() {
+	if t.button == nil {
+		return
+	}
+	t.button.SetIcon(t.icon())
+}
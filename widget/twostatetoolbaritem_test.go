@@ -0,0 +1,63 @@
+package widget
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoStateToolbarItem_Tap(t *testing.T) {
+	var state []bool
+	item := NewTwoStateToolbarItem(theme.MediaPlayIcon(), theme.MediaPauseIcon(), func(on bool) {
+		state = append(state, on)
+	})
+	button := item.ToolbarObject().(*widget.Button)
+	assert.Equal(t, theme.MediaPlayIcon(), button.Icon)
+
+	test.Tap(button)
+	assert.True(t, item.GetOn())
+	assert.Equal(t, theme.MediaPauseIcon(), button.Icon)
+
+	test.Tap(button)
+	assert.False(t, item.GetOn())
+	assert.Equal(t, []bool{true, false}, state)
+}
+
+func TestTwoStateToolbarItem_SetOn(t *testing.T) {
+	called := false
+	item := NewTwoStateToolbarItem(theme.MediaPlayIcon(), theme.MediaPauseIcon(), func(bool) {
+		called = true
+	})
+	item.SetOn(true)
+	button := item.ToolbarObject().(*widget.Button)
+	assert.Equal(t, theme.MediaPauseIcon(), button.Icon)
+
+	item.SetOn(false)
+	assert.False(t, item.GetOn())
+	assert.Equal(t, theme.MediaPlayIcon(), button.Icon)
+	assert.False(t, called)
+}
+
+func TestTwoStateToolbarItem_SetDisabled(t *testing.T) {
+	called := false
+	item := NewTwoStateToolbarItem(theme.MediaPlayIcon(), theme.MediaPauseIcon(), func(bool) {
+		called = true
+	})
+	item.SetDisabled(true)
+	button := item.ToolbarObject().(*widget.Button)
+	assert.True(t, item.Disabled())
+	assert.True(t, button.Disabled())
+
+	test.Tap(button)
+	assert.False(t, item.GetOn())
+	assert.False(t, called)
+
+	item.SetDisabled(false)
+	assert.False(t, button.Disabled())
+	test.Tap(button)
+	assert.True(t, item.GetOn())
+	assert.True(t, called)
+}